@@ -0,0 +1,187 @@
+package main
+
+import (
+	"Gin/internal/config"
+	"Gin/internal/core/domain"
+	"Gin/internal/platform"
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+)
+
+const verifyAuditUsage = `usage: api verify-audit [CHECKPOINTS_FILE]
+
+Walks the hash chain of the audit log of every tenant and reports its first
+broken link. When AUDIT_SIGNING_KEY is set, each chain is also matched against
+its stored checkpoints and those of CHECKPOINTS_FILE, as written by
+export-audit-checkpoints. Exits with an error when a chain is broken.`
+
+const exportAuditCheckpointsUsage = `usage: api export-audit-checkpoints [FILE]
+
+Writes every signed checkpoint of the audit chain of every tenant to FILE
+(standard output by default), one JSON object per line. When AUDIT_SIGNING_KEY
+is set, the latest events of each tenant are checkpointed first.`
+
+// Runs the `verify-audit` subcommand with the arguments that follow it.
+func runVerifyAudit(args []string) error {
+	if len(args) > 1 {
+		return errors.New(verifyAuditUsage)
+	}
+
+	var checkpoints []domain.AuditCheckpoint
+	if len(args) == 1 {
+		var err error
+		if checkpoints, err = readAuditCheckpoints(args[0]); err != nil {
+			return err
+		}
+	}
+
+	_, services, closeDB, err := openAuditServices("verify-audit")
+	if err != nil {
+		return err
+	}
+	defer closeDB()
+
+	ctx := context.Background()
+
+	tenants, err := services.Audit.GetAuditTenants(ctx)
+	if err != nil {
+		return err
+	}
+
+	// The tenants whose events are all gone must still match the checkpoints of the file
+	tenants = append(tenants, domain.DefaultTenant)
+	for _, checkpoint := range checkpoints {
+		tenants = append(tenants, checkpoint.Tenant())
+	}
+	slices.Sort(tenants)
+	tenants = slices.Compact(tenants)
+
+	broken := 0
+
+	for _, tenant := range tenants {
+		verification, err := services.Audit.VerifyAudit(domain.WithTenant(ctx, tenant), checkpoints)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Tenant %s: checked %d chained events and %d checkpoints (%d events predate the chain)\n", tenant, verification.Events, verification.Checkpoints, verification.Unchained)
+
+		if !verification.Valid {
+			fmt.Printf("Tenant %s: the audit chain is broken at event %d: %s\n", tenant, verification.BrokenLink.EventID, verification.BrokenLink.Reason)
+			broken++
+		} else if verification.Events > 0 {
+			fmt.Printf("Tenant %s: the audit chain is intact up to event %d (%s)\n", tenant, verification.LastEventID, verification.LastHash)
+		}
+	}
+
+	if broken > 0 {
+		return fmt.Errorf("verify-audit: the audit chains of %d tenants are broken", broken)
+	}
+
+	return nil
+}
+
+// Runs the `export-audit-checkpoints` subcommand with the arguments that follow it.
+func runExportAuditCheckpoints(args []string) error {
+	if len(args) > 1 {
+		return errors.New(exportAuditCheckpointsUsage)
+	}
+
+	cfg, services, closeDB, err := openAuditServices("export-audit-checkpoints")
+	if err != nil {
+		return err
+	}
+	defer closeDB()
+
+	ctx := context.Background()
+
+	tenants, err := services.Audit.GetAuditTenants(ctx)
+	if err != nil {
+		return err
+	}
+
+	var checkpoints []domain.AuditCheckpoint
+
+	for _, tenant := range tenants {
+		tenantCtx := domain.WithTenant(ctx, tenant)
+
+		if cfg.AuditSigningKey != nil {
+			if _, err := services.Audit.CreateAuditCheckpoint(tenantCtx); err != nil {
+				return err
+			}
+		}
+
+		tenantCheckpoints, err := services.Audit.GetAuditCheckpoints(tenantCtx)
+		if err != nil {
+			return err
+		}
+		checkpoints = append(checkpoints, tenantCheckpoints...)
+	}
+
+	out := io.Writer(os.Stdout)
+	if len(args) == 1 {
+		file, err := os.Create(args[0])
+		if err != nil {
+			return fmt.Errorf("export-audit-checkpoints: %w", err)
+		}
+		defer file.Close()
+		out = file
+	}
+
+	encoder := json.NewEncoder(out)
+	for _, checkpoint := range checkpoints {
+		if err := encoder.Encode(checkpoint); err != nil {
+			return fmt.Errorf("export-audit-checkpoints: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Loads the configuration and opens the database of an audit subcommand; call closeDB once done.
+func openAuditServices(command string) (cfg *config.Config, services *platform.Services, closeDB func(), err error) {
+	if cfg, err = config.Load(); err != nil {
+		return nil, nil, nil, err
+	}
+
+	// Nothing outlives the process with the in-memory driver
+	if !cfg.UsesDatabase() {
+		return nil, nil, nil, fmt.Errorf("%s: the %s storage driver keeps no audit log", command, cfg.StorageDriver)
+	}
+
+	db, err := platform.OpenDB(cfg)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	// The whole log is read from the primary, which replicas may lag behind
+	return cfg, platform.SetupServices(cfg, db, nil), func() { platform.CloseDB(db) }, nil
+}
+
+// Reads the checkpoints written by export-audit-checkpoints to a file.
+func readAuditCheckpoints(path string) ([]domain.AuditCheckpoint, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("verify-audit: %w", err)
+	}
+	defer file.Close()
+
+	var checkpoints []domain.AuditCheckpoint
+	decoder := json.NewDecoder(bufio.NewReader(file))
+
+	for {
+		var checkpoint domain.AuditCheckpoint
+		if err := decoder.Decode(&checkpoint); err == io.EOF {
+			return checkpoints, nil
+		} else if err != nil {
+			return nil, fmt.Errorf("verify-audit: invalid checkpoint in %s: %w", path, err)
+		}
+		checkpoints = append(checkpoints, checkpoint)
+	}
+}
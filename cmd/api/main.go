@@ -1,8 +1,12 @@
 package main
 
 import (
+	"Gin/internal/adapters/db/postgresql"
+	"Gin/internal/config"
 	"Gin/internal/platform"
+	"database/sql"
 	"log"
+	"os"
 
 	"github.com/joho/godotenv"
 )
@@ -17,18 +21,71 @@ func main() {
 		log.Printf("Warning: Error loading .env file (it might not exist if running in production directly): %v", env)
 	}
 
-	// Initialize database
-	db, err := platform.InitDB()
+	// Run the migrate subcommand instead of the server if requested
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := runMigrate(os.Args[2:]); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	// Run the purge subcommand instead of the server if requested
+	if len(os.Args) > 1 && os.Args[1] == "purge" {
+		if err := runPurge(os.Args[2:]); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	// Run the audit subcommands instead of the server if requested
+	if len(os.Args) > 1 && os.Args[1] == "verify-audit" {
+		if err := runVerifyAudit(os.Args[2:]); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "export-audit-checkpoints" {
+		if err := runExportAuditCheckpoints(os.Args[2:]); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
 
+	cfg, err := config.Load()
 	if err != nil {
-		log.Fatalf("Error initializing the database: %v", err)
+		log.Fatalf("Error loading the configuration: %v", err)
 	}
 
-	// Close the database connection when exiting the program
-	defer db.Close()
+	// Initialize database (not needed by the in-memory storage driver)
+	var db *sql.DB
+	var replicas *postgresql.ReplicaRouter
+	if cfg.UsesDatabase() {
+		db, err = platform.InitDB(cfg)
+
+		if err != nil {
+			log.Fatalf("Error initializing the database: %v", err)
+		}
+
+		// Close the database connection when exiting the program
+		defer db.Close()
+
+		// Spread the reads over the read replicas, if any
+		replicas, err = platform.InitReplicas(cfg, db)
+
+		if err != nil {
+			log.Fatalf("Error initializing the database replicas: %v", err)
+		}
+
+		if replicas != nil {
+			defer replicas.Close()
+		}
+	} else {
+		log.Printf("Using the %s storage driver, no database connection opened", cfg.StorageDriver)
+	}
 
 	// Initialize the Gin server
-	r := platform.InitGinServer(db)
+	r := platform.InitGinServer(cfg, db, replicas)
 
 	// Start the server. Listen on 0.0.0.0:3000
 	log.Fatal(r.Run(":3000"))
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"Gin/internal/config"
+	"Gin/internal/platform"
+	"Gin/internal/platform/migrate"
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+const migrateUsage = `usage: api migrate <command>
+
+Runs against the database of the configured STORAGE_DRIVER (postgres or sqlite).
+
+commands:
+  up            apply all pending migrations
+  down [N]      revert the last N applied migrations (default 1)
+  status        list migrations and whether they are applied
+  create NAME   write a new empty up/down migration pair`
+
+// Runs the `migrate` subcommand with the arguments that follow it.
+func runMigrate(args []string) error {
+	if len(args) == 0 {
+		return errors.New(migrateUsage)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+
+	// Creating files does not need a database connection
+	if args[0] == "create" {
+		if len(args) != 2 {
+			return errors.New(migrateUsage)
+		}
+
+		dir, err := platform.MigrationsDir(cfg)
+		if err != nil {
+			return err
+		}
+
+		paths, err := migrate.Create(dir, args[1])
+		if err != nil {
+			return err
+		}
+
+		for _, path := range paths {
+			fmt.Println("Created", path)
+		}
+		return nil
+	}
+
+	db, err := platform.OpenDB(cfg)
+	if err != nil {
+		return err
+	}
+	defer platform.CloseDB(db)
+
+	migrator, err := platform.NewMigrator(cfg, db)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+
+	switch args[0] {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		for _, migration := range applied {
+			fmt.Printf("Applied  %04d_%s\n", migration.Version, migration.Name)
+		}
+		if err == nil && len(applied) == 0 {
+			fmt.Println("No pending migrations")
+		}
+		return err
+
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			if steps, err = strconv.Atoi(args[1]); err != nil || steps < 1 {
+				return errors.New("down: N must be a positive integer")
+			}
+		}
+
+		reverted, err := migrator.Down(ctx, steps)
+		for _, migration := range reverted {
+			fmt.Printf("Reverted %04d_%s\n", migration.Version, migration.Name)
+		}
+		if err == nil && len(reverted) == 0 {
+			fmt.Println("No applied migrations")
+		}
+		return err
+
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+
+		for _, status := range statuses {
+			state := "pending"
+			if status.AppliedAt != nil {
+				state = "applied " + status.AppliedAt.Local().Format("2006-01-02 15:04:05")
+			}
+			fmt.Printf("%04d_%-40s %s\n", status.Version, status.Name, state)
+		}
+		return nil
+	}
+
+	return errors.New(migrateUsage)
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"Gin/internal/config"
+	"Gin/internal/core/domain"
+	"Gin/internal/platform"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+const purgeUsage = `usage: api purge [RETENTION]
+
+Permanently removes the stories and users deleted more than RETENTION ago,
+the outbox events delivered more than RETENTION ago and the finished webhook
+deliveries created more than RETENTION ago
+(a duration such as 720h, defaults to SOFT_DELETE_RETENTION).`
+
+// Runs the `purge` subcommand with the arguments that follow it.
+func runPurge(args []string) error {
+	if len(args) > 1 {
+		return errors.New(purgeUsage)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+
+	// Nothing outlives the process with the in-memory driver
+	if !cfg.UsesDatabase() {
+		return fmt.Errorf("purge: the %s storage driver has nothing to purge", cfg.StorageDriver)
+	}
+
+	retention := cfg.SoftDeleteRetention
+	if len(args) == 1 {
+		if retention, err = time.ParseDuration(args[0]); err != nil || retention < 0 {
+			return errors.New(purgeUsage)
+		}
+	}
+
+	db, err := platform.OpenDB(cfg)
+	if err != nil {
+		return err
+	}
+	defer platform.CloseDB(db)
+
+	// Purging only writes, so there is no point in opening the read replicas
+	services := platform.SetupServices(cfg, db, nil)
+	// Attributes the purges to this command in the audit log
+	ctx := domain.WithAuditContext(context.Background(), domain.AuditContext{Actor: "purge"})
+
+	// Stories first, so a later failure on users still leaves them purged
+	stories, err := services.Stories.PurgeDeletedStories(ctx, retention)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Purged %d stories deleted more than %s ago\n", stories, retention)
+
+	users, err := services.Users.PurgeDeletedUsers(ctx, retention)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Purged %d users deleted more than %s ago\n", users, retention)
+
+	// The purge events themselves are delivered by the relay of the running server
+	events, err := services.Outbox.PurgeDeliveredOutbox(ctx, retention)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Purged %d outbox events delivered more than %s ago\n", events, retention)
+
+	deliveries, err := services.Webhooks.PurgeWebhookDeliveries(ctx, retention)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Purged %d webhook deliveries created more than %s ago\n", deliveries, retention)
+
+	return nil
+}
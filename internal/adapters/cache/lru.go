@@ -0,0 +1,129 @@
+package cache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// A least-recently-used cache whose entries also expire after a fixed time to live.
+// Safe for concurrent use.
+type lru[K comparable, V any] struct {
+	mu        sync.Mutex
+	capacity  int
+	ttl       time.Duration
+	order     *list.List // Most recently used first, holds *lruEntry[K, V]
+	entries   map[K]*list.Element
+	hits      int64
+	misses    int64
+	evictions int64 // Entries dropped to make room, not counting expired or invalidated ones
+}
+
+// Represents a cached value and when it expires.
+type lruEntry[K comparable, V any] struct {
+	key       K
+	value     V
+	expiresAt time.Time
+}
+
+// Creates a new, empty lru holding at most capacity entries for at most ttl each.
+func newLRU[K comparable, V any](capacity int, ttl time.Duration) *lru[K, V] {
+	return &lru[K, V]{capacity: capacity, ttl: ttl, order: list.New(), entries: make(map[K]*list.Element)}
+}
+
+// Returns the value cached under key, if it has not expired, and marks it as recently used.
+func (c *lru[K, V]) get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, ok := c.entries[key]; ok {
+		entry := element.Value.(*lruEntry[K, V])
+
+		if time.Now().Before(entry.expiresAt) {
+			c.order.MoveToFront(element)
+			c.hits++
+			return entry.value, true
+		}
+
+		c.remove(element)
+	}
+
+	c.misses++
+
+	var zero V
+	return zero, false
+}
+
+// Caches value under key if valid, called under the lock, reports true, evicting the least recently used entry
+// when the cache is full. Entries are deleted under the same lock, so a value that was still valid cannot be
+// cached after its deletion.
+func (c *lru[K, V]) putIf(key K, value V, valid func() bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !valid() {
+		return
+	}
+
+	entry := &lruEntry[K, V]{key: key, value: value, expiresAt: time.Now().Add(c.ttl)}
+
+	if element, ok := c.entries[key]; ok {
+		element.Value = entry
+		c.order.MoveToFront(element)
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(entry)
+
+	for c.order.Len() > c.capacity {
+		c.remove(c.order.Back())
+		c.evictions++
+	}
+}
+
+// Drops the entry cached under key, if any.
+func (c *lru[K, V]) delete(key K) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, ok := c.entries[key]; ok {
+		c.remove(element)
+	}
+}
+
+// Drops every entry whose value matches.
+func (c *lru[K, V]) deleteFunc(match func(V) bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for element := c.order.Front(); element != nil; {
+		next := element.Next()
+		if match(element.Value.(*lruEntry[K, V]).value) {
+			c.remove(element)
+		}
+		element = next
+	}
+}
+
+// Drops every entry.
+func (c *lru[K, V]) clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.order.Init()
+	clear(c.entries)
+}
+
+// Unlinks an entry. The caller holds the lock.
+func (c *lru[K, V]) remove(element *list.Element) {
+	c.order.Remove(element)
+	delete(c.entries, element.Value.(*lruEntry[K, V]).key)
+}
+
+// Returns the counters of the cache.
+func (c *lru[K, V]) stats() (size int, hits, misses, evictions int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.order.Len(), c.hits, c.misses, c.evictions
+}
@@ -0,0 +1,369 @@
+package cache
+
+import (
+	"Gin/internal/core/domain"
+	"Gin/internal/core/ports"
+	"context"
+	"encoding/json"
+	"slices"
+	"sync/atomic"
+	"time"
+)
+
+// Caches the story reads of a ports.StoryDrivingPort in process, with an LRU bounded in size and a time to live.
+// GetStoryByID is cached per story, GetAllStories, GetStoriesByAuthor and GetTags per query.
+// Every key starts with the tenant of the context, so a tenant never reads what another one cached.
+// Every write evicts the story it touched and, in its tenant, the tags and the listings whose filters the story
+// passes before or after the write: the other listings cannot have changed. The other methods are passed through
+// to the wrapped port.
+type StoryCache struct {
+	ports.StoryDrivingPort
+	stories    *lru[string, domain.Story]
+	lists      *lru[string, listing]
+	generation atomic.Uint64 // Incremented by every write before it evicts, so reads started before it do not cache stale results
+}
+
+// Represents a cached listing: a page of stories or the tags, with what its eviction depends on.
+type listing struct {
+	tenant string
+	userID string             // Author of a GetStoriesByAuthor page
+	query  *domain.StoryQuery // Filters of a page, nil for the tags
+	page   *domain.Page[domain.Story]
+	tags   []domain.TagUsage
+}
+
+// Reports whether a story in the given state is one of the stories the listing is taken from.
+// The tags are computed from every story, so they always are.
+func (l listing) includes(story *domain.Story) bool {
+	if l.query == nil {
+		return true
+	}
+
+	if l.userID != "" && (story.AuthorID == nil || *story.AuthorID != l.userID) {
+		return false
+	}
+
+	return l.query.Matches(story)
+}
+
+// Creates a new instance of StoryCache over next, holding at most size stories and size listings for ttl each.
+func NewStoryCache(next ports.StoryDrivingPort, size int, ttl time.Duration) *StoryCache {
+	return &StoryCache{
+		StoryDrivingPort: next,
+		stories:          newLRU[string, domain.Story](size, ttl),
+		lists:            newLRU[string, listing](size, ttl),
+	}
+}
+
+// Returns a story from the cache, or from the wrapped port on a miss.
+func (c *StoryCache) GetStoryByID(ctx context.Context, id string) (*domain.Story, error) {
+	key := storyKey(ctx, id)
+	if story, ok := c.stories.get(key); ok {
+		return cloneStory(&story), nil
+	}
+
+	generation := c.generation.Load()
+
+	story, err := c.StoryDrivingPort.GetStoryByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	c.stories.putIf(key, *cloneStory(story), c.unchangedSince(generation))
+
+	return story, nil
+}
+
+// Returns a page of stories from the cache, or from the wrapped port on a miss.
+func (c *StoryCache) GetAllStories(ctx context.Context, query domain.StoryQuery) (*domain.Page[domain.Story], error) {
+	return c.page(ctx, listKey(ctx, "stories", "", query), "", query, func() (*domain.Page[domain.Story], error) {
+		return c.StoryDrivingPort.GetAllStories(ctx, query)
+	})
+}
+
+// Returns a page of the stories of a user from the cache, or from the wrapped port on a miss.
+func (c *StoryCache) GetStoriesByAuthor(ctx context.Context, userID string, query domain.StoryQuery) (*domain.Page[domain.Story], error) {
+	return c.page(ctx, listKey(ctx, "author", userID, query), userID, query, func() (*domain.Page[domain.Story], error) {
+		return c.StoryDrivingPort.GetStoriesByAuthor(ctx, userID, query)
+	})
+}
+
+// Returns the tags from the cache, or from the wrapped port on a miss.
+func (c *StoryCache) GetTags(ctx context.Context) ([]domain.TagUsage, error) {
+	key := tagsKey(ctx)
+	if cached, ok := c.lists.get(key); ok {
+		return slices.Clone(cached.tags), nil
+	}
+
+	generation := c.generation.Load()
+
+	tags, err := c.StoryDrivingPort.GetTags(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	c.lists.putIf(key, listing{tenant: domain.TenantFrom(ctx), tags: slices.Clone(tags)}, c.unchangedSince(generation))
+
+	return tags, nil
+}
+
+// Returns the page cached under key, or loads and caches it on a miss.
+func (c *StoryCache) page(ctx context.Context, key, userID string, query domain.StoryQuery, load func() (*domain.Page[domain.Story], error)) (*domain.Page[domain.Story], error) {
+	if cached, ok := c.lists.get(key); ok {
+		return clonePage(cached.page), nil
+	}
+
+	generation := c.generation.Load()
+
+	page, err := load()
+	if err != nil {
+		return nil, err
+	}
+
+	c.lists.putIf(key, listing{tenant: domain.TenantFrom(ctx), userID: userID, query: &query, page: clonePage(page)}, c.unchangedSince(generation))
+
+	return page, nil
+}
+
+// Returns a check that no write started evicting since generation was read. Loaded results are only cached
+// when it passes under the lock of the cache: a write either evicts after they are cached, or prevents caching them.
+func (c *StoryCache) unchangedSince(generation uint64) func() bool {
+	return func() bool { return c.generation.Load() == generation }
+}
+
+// Creates a story and evicts the listings it shows up in.
+func (c *StoryCache) CreateStory(ctx context.Context, input *domain.NewStoryInput) (*domain.Story, error) {
+	story, err := c.StoryDrivingPort.CreateStory(ctx, input)
+	c.invalidate(ctx, "", err, story)
+
+	return story, err
+}
+
+// Imports stories and drops the cached listings of the tenant, as the report does not hold the stories.
+func (c *StoryCache) ImportStories(ctx context.Context, records []domain.StoryImportRecord, mode domain.StoryImportMode) (*domain.StoryImportReport, error) {
+	defer c.invalidateTenant(ctx)
+	return c.StoryDrivingPort.ImportStories(ctx, records, mode)
+}
+
+// Updates a story, evicting it and the listings it showed up in or shows up in now.
+func (c *StoryCache) UpdateStory(ctx context.Context, id string, input *domain.UpdateStoryInput, expectedVersion int64) (*domain.Story, error) {
+	before := c.current(ctx, id)
+
+	story, err := c.StoryDrivingPort.UpdateStory(ctx, id, input, expectedVersion)
+	c.invalidate(ctx, id, err, before, story)
+
+	return story, err
+}
+
+// Deletes a story, evicting it and the listings it showed up in.
+func (c *StoryCache) DeleteStory(ctx context.Context, id string, expectedVersion int64) error {
+	before := c.current(ctx, id)
+
+	err := c.StoryDrivingPort.DeleteStory(ctx, id, expectedVersion)
+	c.invalidate(ctx, id, err, before, deleted(before))
+
+	return err
+}
+
+// Restores a story, evicting it and the listings it showed up in while deleted or shows up in now.
+func (c *StoryCache) RestoreStory(ctx context.Context, id string) (*domain.Story, error) {
+	story, err := c.StoryDrivingPort.RestoreStory(ctx, id)
+	c.invalidate(ctx, id, err, deleted(story), story)
+
+	return story, err
+}
+
+// Purges deleted stories and evicts the listings that include deleted stories, in every tenant.
+func (c *StoryCache) PurgeDeletedStories(ctx context.Context, retention time.Duration) (int64, error) {
+	defer c.invalidateDeleted()
+	return c.StoryDrivingPort.PurgeDeletedStories(ctx, retention)
+}
+
+// Restores a revision of a story, evicting it and the listings it showed up in or shows up in now.
+func (c *StoryCache) RestoreStoryRevision(ctx context.Context, id string, revision int) (*domain.Story, error) {
+	before := c.current(ctx, id)
+
+	story, err := c.StoryDrivingPort.RestoreStoryRevision(ctx, id, revision)
+	c.invalidate(ctx, id, err, before, story)
+
+	return story, err
+}
+
+// Returns the story about to be written, or nil if it cannot be read.
+func (c *StoryCache) current(ctx context.Context, id string) *domain.Story {
+	story, err := c.GetStoryByID(ctx, id)
+	if err != nil {
+		return nil
+	}
+
+	return story
+}
+
+// Evicts the story of the tenant with the given ID, if any, and the listings of the tenant that include
+// any of the given states of the story; nil states are skipped.
+// Runs after a write whatever its outcome. A failed write may still have been applied in a state
+// that is not known, so it drops every listing of the tenant.
+func (c *StoryCache) invalidate(ctx context.Context, id string, err error, states ...*domain.Story) {
+	c.generation.Add(1)
+
+	if err != nil {
+		if id != "" {
+			c.stories.delete(storyKey(ctx, id))
+		}
+		c.invalidateTenant(ctx)
+		return
+	}
+
+	tenant := domain.TenantFrom(ctx)
+
+	if id != "" {
+		c.stories.delete(storyKey(ctx, id))
+	}
+
+	c.lists.deleteFunc(func(l listing) bool {
+		if l.tenant != tenant {
+			return false
+		}
+
+		for _, state := range states {
+			if state != nil && l.includes(state) {
+				return true
+			}
+		}
+
+		return false
+	})
+}
+
+// Drops every cached listing of the tenant.
+func (c *StoryCache) invalidateTenant(ctx context.Context) {
+	c.generation.Add(1)
+	tenant := domain.TenantFrom(ctx)
+
+	c.lists.deleteFunc(func(l listing) bool { return l.tenant == tenant })
+}
+
+// Drops the cached pages that include deleted stories, in every tenant. Deleted stories are neither cached by ID nor counted in the tags.
+func (c *StoryCache) invalidateDeleted() {
+	c.generation.Add(1)
+
+	c.lists.deleteFunc(func(l listing) bool { return l.query != nil && l.query.IncludeDeleted })
+}
+
+// Evicts the stories of the tenant linked to a user, after the user changed, and the listings showing them:
+// the pages of the user, those holding one of their stories and those filtered by author name, which may now match.
+func (c *StoryCache) invalidateAuthor(ctx context.Context, userID string) {
+	c.generation.Add(1)
+	tenant := domain.TenantFrom(ctx)
+
+	byAuthor := func(story *domain.Story) bool {
+		return story.TenantID == tenant && story.AuthorID != nil && *story.AuthorID == userID
+	}
+
+	c.stories.deleteFunc(func(story domain.Story) bool { return byAuthor(&story) })
+
+	c.lists.deleteFunc(func(l listing) bool {
+		if l.tenant != tenant || l.query == nil {
+			return false
+		}
+
+		if l.userID == userID || l.query.Author != "" || l.query.AuthorID == userID {
+			return true
+		}
+
+		return slices.ContainsFunc(l.page.Items, func(story domain.Story) bool { return byAuthor(&story) })
+	})
+}
+
+// Drops the cached pages of the stories of a user of the tenant, after the user was deleted or restored.
+func (c *StoryCache) invalidateUser(ctx context.Context, userID string) {
+	c.generation.Add(1)
+	tenant := domain.TenantFrom(ctx)
+
+	c.lists.deleteFunc(func(l listing) bool { return l.tenant == tenant && l.userID == userID })
+}
+
+// Returns a copy of the story marked as deleted, the state it has in the listings that include deleted stories.
+func deleted(story *domain.Story) *domain.Story {
+	if story == nil {
+		return nil
+	}
+
+	clone := cloneStory(story)
+	if clone.DeletedAt == nil {
+		deletedAt := time.Now()
+		clone.DeletedAt = &deletedAt
+	}
+
+	return clone
+}
+
+// Returns the counters of the story and listing caches.
+func (c *StoryCache) CacheStats() []domain.CacheStats {
+	return []domain.CacheStats{
+		cacheStats("stories", c.stories),
+		cacheStats("story_lists", c.lists),
+	}
+}
+
+// Reports the counters of one cache.
+func cacheStats[V any](name string, c *lru[string, V]) domain.CacheStats {
+	size, hits, misses, evictions := c.stats()
+
+	return domain.CacheStats{
+		Name:      name,
+		Size:      size,
+		Capacity:  c.capacity,
+		TTLMillis: c.ttl.Milliseconds(),
+		Hits:      hits,
+		Misses:    misses,
+		Evictions: evictions,
+	}
+}
+
+// Builds the cache key of a story of the tenant of the context.
+func storyKey(ctx context.Context, id string) string {
+	return domain.TenantFrom(ctx) + ":" + id
+}
+
+// Builds the cache key of the tag listing of the tenant of the context.
+func tagsKey(ctx context.Context) string {
+	return domain.TenantFrom(ctx) + ":tags"
+}
+
+// Builds the cache key of a listing of the tenant of the context.
+// The query is encoded as JSON, which covers every filter, the sort and the cursor.
+func listKey(ctx context.Context, kind, userID string, query domain.StoryQuery) string {
+	raw, _ := json.Marshal(query)
+	return domain.TenantFrom(ctx) + ":" + kind + ":" + userID + ":" + string(raw)
+}
+
+// Returns a copy of the story that shares nothing mutable with it.
+func cloneStory(story *domain.Story) *domain.Story {
+	clone := *story
+	clone.Tags = slices.Clone(story.Tags)
+
+	if story.AuthorID != nil {
+		authorID := *story.AuthorID
+		clone.AuthorID = &authorID
+	}
+
+	if story.DeletedAt != nil {
+		deletedAt := *story.DeletedAt
+		clone.DeletedAt = &deletedAt
+	}
+
+	return &clone
+}
+
+// Returns a copy of the page that shares nothing mutable with it.
+func clonePage(page *domain.Page[domain.Story]) *domain.Page[domain.Story] {
+	clone := *page
+	clone.Items = make([]domain.Story, len(page.Items))
+
+	for i := range page.Items {
+		clone.Items[i] = *cloneStory(&page.Items[i])
+	}
+
+	return &clone
+}
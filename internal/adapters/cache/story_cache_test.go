@@ -0,0 +1,67 @@
+package cache
+
+import (
+	"Gin/internal/core/domain"
+	"Gin/internal/core/ports"
+	"context"
+	"testing"
+	"time"
+)
+
+// Serves stories from a map, running during before the story is returned.
+type fakeStories struct {
+	ports.StoryDrivingPort
+	stories map[string]domain.Story
+	during  func()
+}
+
+func (f *fakeStories) GetStoryByID(ctx context.Context, id string) (*domain.Story, error) {
+	story := f.stories[id]
+	if f.during != nil {
+		f.during()
+	}
+
+	return &story, nil
+}
+
+func (f *fakeStories) RestoreStory(ctx context.Context, id string) (*domain.Story, error) {
+	story := f.stories[id]
+	return &story, nil
+}
+
+func TestGetStoryByIDDoesNotCacheAStoryWrittenWhileLoading(t *testing.T) {
+	ctx := context.Background()
+	next := &fakeStories{stories: map[string]domain.Story{"story": {ID: "story", Title: "Before"}}}
+	cache := NewStoryCache(next, 10, time.Minute)
+
+	// The story is written after it was read and before it is cached
+	next.during = func() {
+		next.stories["story"] = domain.Story{ID: "story", Title: "After"}
+		if _, err := cache.RestoreStory(ctx, "story"); err != nil {
+			t.Fatalf("RestoreStory failed: %v", err)
+		}
+	}
+
+	if story, _ := cache.GetStoryByID(ctx, "story"); story.Title != "Before" {
+		t.Fatalf("title = %q, want the version read", story.Title)
+	}
+
+	next.during = nil
+	if story, _ := cache.GetStoryByID(ctx, "story"); story.Title != "After" {
+		t.Errorf("title = %q, want the written version rather than the one cached before the write", story.Title)
+	}
+}
+
+func TestPutIfChecksUnderTheLock(t *testing.T) {
+	c := newLRU[string, int](10, time.Minute)
+
+	c.putIf("stale", 1, func() bool { return false })
+	if _, ok := c.get("stale"); ok {
+		t.Error("a value that was no longer valid was cached")
+	}
+
+	c.putIf("fresh", 2, func() bool { return true })
+	if value, ok := c.get("fresh"); !ok || value != 2 {
+		t.Errorf("get = %d, %v, want 2", value, ok)
+	}
+}
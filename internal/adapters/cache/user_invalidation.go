@@ -0,0 +1,37 @@
+package cache
+
+import (
+	"Gin/internal/core/domain"
+	"Gin/internal/core/ports"
+	"context"
+)
+
+// Wraps a ports.UserDriverPort so that the user writes that show through stories reach the story cache:
+// renaming a user renames the author of their stories, and deleting or restoring one changes their story listing.
+type userInvalidation struct {
+	ports.UserDriverPort
+	stories *StoryCache
+}
+
+// Returns users wrapped so that its writes invalidate the stories cached by c.
+func (c *StoryCache) WrapUsers(users ports.UserDriverPort) ports.UserDriverPort {
+	return &userInvalidation{UserDriverPort: users, stories: c}
+}
+
+// Updates a user and evicts their stories.
+func (u *userInvalidation) UpdateUser(ctx context.Context, id, email, name string, expectedVersion int64) (*domain.User, error) {
+	defer u.stories.invalidateAuthor(ctx, id)
+	return u.UserDriverPort.UpdateUser(ctx, id, email, name, expectedVersion)
+}
+
+// Deletes a user and drops the cached pages of their stories.
+func (u *userInvalidation) DeleteUser(ctx context.Context, id string, expectedVersion int64) error {
+	defer u.stories.invalidateUser(ctx, id)
+	return u.UserDriverPort.DeleteUser(ctx, id, expectedVersion)
+}
+
+// Restores a user and drops the cached pages of their stories.
+func (u *userInvalidation) RestoreUser(ctx context.Context, id string) (*domain.User, error) {
+	defer u.stories.invalidateUser(ctx, id)
+	return u.UserDriverPort.RestoreUser(ctx, id)
+}
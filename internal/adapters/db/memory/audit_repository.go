@@ -0,0 +1,177 @@
+package memory
+
+import (
+	"Gin/internal/core/domain"
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+)
+
+// Implements the ports.AuditDrivenPort interface with an in-memory slice.
+type AuditRepository struct {
+	mu          sync.RWMutex
+	events      []domain.AuditEvent      // In ID order; the ID of an event is its position plus one
+	checkpoints []domain.AuditCheckpoint // In ID order, likewise
+}
+
+// Creates a new, empty instance of AuditRepository.
+func NewAuditRepository() *AuditRepository {
+	return &AuditRepository{}
+}
+
+// Implements the logic to find the hash of the latest audit event of the tenant in memory.
+// There is nothing to lock: the unit of work already runs its transactions one at a time.
+func (r *AuditRepository) LockAuditTail(ctx context.Context) (string, error) {
+	last, err := r.FindLastAuditEvent(ctx)
+	if err != nil || last == nil {
+		return "", err
+	}
+
+	return last.Hash, nil
+}
+
+// Implements the logic to save audit events in memory.
+func (r *AuditRepository) SaveAuditEvents(ctx context.Context, events []*domain.AuditEvent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, event := range events {
+		event.ID = int64(len(r.events)) + 1
+
+		r.events = append(r.events, *event)
+	}
+
+	return nil
+}
+
+// Implements the logic to find a page of the audit events of the tenant matching a query in memory, newest first.
+func (r *AuditRepository) FindAuditEvents(ctx context.Context, query domain.AuditQuery) (*domain.Page[domain.AuditEvent], error) {
+	limit := query.Page.NormalizedLimit()
+	tenant := domain.TenantFrom(ctx)
+
+	r.mu.RLock()
+	end := len(r.events)
+	if query.Page.Cursor != nil {
+		before, err := query.Page.Cursor.AuditValue()
+		if err != nil {
+			r.mu.RUnlock()
+			return nil, fmt.Errorf("memory: %w", err)
+		}
+		end = min(end, int(max(before-1, 0)))
+	}
+
+	// Keep one extra event to know whether there is a next page
+	events := make([]domain.AuditEvent, 0, limit+1)
+	for i := end - 1; i >= 0 && len(events) <= limit; i-- {
+		if r.events[i].TenantID == tenant && auditMatches(r.events[i], query) {
+			events = append(events, r.events[i])
+		}
+	}
+	r.mu.RUnlock()
+
+	return domain.NewPage(events, limit, domain.AuditCursor), nil
+}
+
+// Implements the logic to find the latest audit event of the tenant in memory.
+func (r *AuditRepository) FindLastAuditEvent(ctx context.Context) (*domain.AuditEvent, error) {
+	tenant := domain.TenantFrom(ctx)
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for i := len(r.events) - 1; i >= 0; i-- {
+		if r.events[i].TenantID == tenant {
+			event := r.events[i]
+			return &event, nil
+		}
+	}
+
+	return nil, nil // The chain is empty
+}
+
+// Implements the logic to call fn with every audit event of the tenant in memory, oldest first.
+// fn sees the log as it was when the stream started.
+func (r *AuditRepository) StreamAuditEvents(ctx context.Context, fn func(*domain.AuditEvent) error) error {
+	tenant := domain.TenantFrom(ctx)
+
+	r.mu.RLock()
+	events := r.events // Events are only ever appended, so this snapshot stays as is
+	r.mu.RUnlock()
+
+	for _, event := range events {
+		if event.TenantID != tenant {
+			continue
+		}
+
+		if err := fn(&event); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Implements the logic to save an audit checkpoint of the tenant in memory.
+func (r *AuditRepository) SaveAuditCheckpoint(ctx context.Context, checkpoint *domain.AuditCheckpoint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	checkpoint.ID = int64(len(r.checkpoints)) + 1
+	stored := *checkpoint
+	stored.TenantID = domain.TenantFrom(ctx)
+	r.checkpoints = append(slices.Clip(r.checkpoints), stored)
+
+	return nil
+}
+
+// Implements the logic to find every audit checkpoint of the tenant in memory, oldest first.
+func (r *AuditRepository) FindAuditCheckpoints(ctx context.Context) ([]domain.AuditCheckpoint, error) {
+	tenant := domain.TenantFrom(ctx)
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	checkpoints := make([]domain.AuditCheckpoint, 0)
+	for _, checkpoint := range r.checkpoints {
+		if checkpoint.TenantID == tenant {
+			checkpoints = append(checkpoints, checkpoint)
+		}
+	}
+
+	return checkpoints, nil
+}
+
+// Implements the logic to find the tenants with audit events in memory, in every tenant.
+func (r *AuditRepository) FindAuditTenants(ctx context.Context) ([]string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	tenants := make([]string, 0)
+	for _, event := range r.events {
+		if !slices.Contains(tenants, event.TenantID) {
+			tenants = append(tenants, event.TenantID)
+		}
+	}
+
+	slices.Sort(tenants)
+	return tenants, nil
+}
+
+// Reports whether an event passes the filters of a query.
+func auditMatches(event domain.AuditEvent, query domain.AuditQuery) bool {
+	switch {
+	case query.EntityType != "" && event.EntityType != query.EntityType:
+		return false
+	case query.EntityID != "" && event.EntityID != query.EntityID:
+		return false
+	case query.Actor != "" && event.Actor != query.Actor:
+		return false
+	case query.From != nil && event.OccurredAt.Before(*query.From):
+		return false
+	case query.To != nil && !event.OccurredAt.Before(*query.To):
+		return false
+	default:
+		return true
+	}
+}
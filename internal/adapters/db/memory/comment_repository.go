@@ -0,0 +1,172 @@
+package memory
+
+import (
+	"Gin/internal/core/domain"
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// Implements the ports.CommentDrivenPort interface with an in-memory map.
+// Comments are keyed by tenantKey, so they are only found in the tenant of their story.
+type CommentRepository struct {
+	mu       sync.RWMutex
+	comments map[string]domain.Comment
+}
+
+// Creates a new, empty instance of CommentRepository.
+func NewCommentRepository() *CommentRepository {
+	return &CommentRepository{comments: make(map[string]domain.Comment)}
+}
+
+// Implements the logic to save a comment in memory.
+func (r *CommentRepository) SaveComment(ctx context.Context, comment *domain.Comment) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if comment.ID == "" {
+		comment.ID = uuid.New().String()
+	}
+
+	key := tenantKey(ctx, comment.ID)
+	if _, exists := r.comments[key]; exists {
+		return fmt.Errorf("memory: failed to insert comment: duplicate id %s", comment.ID)
+	}
+
+	comment.CreatedAt = now()
+	comment.UpdatedAt = comment.CreatedAt
+	comment.ReplyCount = 0
+
+	r.comments[key] = *comment
+
+	return nil
+}
+
+// Implements the logic to find a comment by ID in memory.
+func (r *CommentRepository) FindCommentByID(ctx context.Context, id string) (*domain.Comment, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	comment, ok := r.comments[tenantKey(ctx, id)]
+	if !ok {
+		return nil, nil // Comment not found
+	}
+
+	comment.ReplyCount = r.replyCount(id)
+	return &comment, nil
+}
+
+// Implements the logic to find a page of the top-level comments of a story, or of the replies to a comment, in memory.
+// Oldest comments first, like the SQL adapters.
+func (r *CommentRepository) FindComments(ctx context.Context, q domain.CommentQuery) (*domain.Page[domain.Comment], error) {
+	limit := q.Page.NormalizedLimit()
+
+	var after *domain.Comment
+	if q.Page.Cursor != nil {
+		createdAt, err := q.Page.Cursor.TimeValue()
+		if err != nil {
+			return nil, fmt.Errorf("memory: %w", err)
+		}
+		after = &domain.Comment{ID: q.Page.Cursor.ID, CreatedAt: createdAt}
+	}
+
+	r.mu.RLock()
+	comments := make([]domain.Comment, 0)
+	for key, comment := range r.comments {
+		if comment.StoryID != q.StoryID || parentOf(comment) != q.ParentID || key != tenantKey(ctx, comment.ID) {
+			continue
+		}
+
+		if after != nil && !commentBefore(*after, comment) {
+			continue
+		}
+
+		comment.ReplyCount = r.replyCount(comment.ID)
+		comments = append(comments, comment)
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(comments, func(i, j int) bool {
+		return commentBefore(comments[i], comments[j])
+	})
+
+	// Keep one extra comment to know whether there is a next page
+	if len(comments) > limit+1 {
+		comments = comments[:limit+1]
+	}
+
+	return domain.NewPage(comments, limit, domain.CommentCursor), nil
+}
+
+// Implements the logic to update the body of a comment in memory.
+func (r *CommentRepository) UpdateComment(ctx context.Context, comment *domain.Comment) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := tenantKey(ctx, comment.ID)
+	existing, ok := r.comments[key]
+	if !ok {
+		return domain.ErrCommentNotFound
+	}
+
+	existing.Body = comment.Body
+	existing.UpdatedAt = now()
+	r.comments[key] = existing
+
+	comment.UpdatedAt = existing.UpdatedAt
+	return nil
+}
+
+// Implements the logic to delete a comment and its replies in memory.
+func (r *CommentRepository) DeleteComment(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := tenantKey(ctx, id)
+	if _, ok := r.comments[key]; !ok {
+		return domain.ErrCommentNotFound
+	}
+
+	// Replies are in the tenant of the comment they reply to
+	for replyKey, comment := range r.comments {
+		if parentOf(comment) == id {
+			delete(r.comments, replyKey)
+		}
+	}
+
+	delete(r.comments, key)
+	return nil
+}
+
+// Returns the number of replies to a comment. The caller holds the lock.
+func (r *CommentRepository) replyCount(id string) int64 {
+	var count int64
+	for _, comment := range r.comments {
+		if parentOf(comment) == id {
+			count++
+		}
+	}
+
+	return count
+}
+
+// Returns the ID of the comment replied to, or an empty string for a top-level comment.
+func parentOf(comment domain.Comment) string {
+	if comment.ParentID == nil {
+		return ""
+	}
+
+	return *comment.ParentID
+}
+
+// Reports whether a comes before b in (created_at, id) order.
+func commentBefore(a, b domain.Comment) bool {
+	if !a.CreatedAt.Equal(b.CreatedAt) {
+		return a.CreatedAt.Before(b.CreatedAt)
+	}
+
+	return a.ID < b.ID
+}
@@ -0,0 +1,175 @@
+package memory
+
+import (
+	"Gin/internal/core/domain"
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Implements the ports.OutboxDrivenPort interface with an in-memory map.
+type OutboxRepository struct {
+	mu      sync.RWMutex
+	entries map[int64]domain.OutboxEntry
+	lastID  int64 // IDs are assigned in increasing order, like a sequence
+}
+
+// Creates a new, empty instance of OutboxRepository.
+func NewOutboxRepository() *OutboxRepository {
+	return &OutboxRepository{entries: make(map[int64]domain.OutboxEntry)}
+}
+
+// Implements the logic to save outbox entries in memory, in the tenant of the context, due immediately.
+func (r *OutboxRepository) SaveOutboxEntries(ctx context.Context, entries []*domain.OutboxEntry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, entry := range entries {
+		r.lastID++
+		entry.ID = r.lastID
+		entry.TenantID = domain.TenantFrom(ctx)
+		entry.CreatedAt = now()
+		entry.NextAttemptAt = entry.CreatedAt
+
+		r.entries[entry.ID] = *entry
+	}
+
+	return nil
+}
+
+// Implements the logic to claim the due outbox entries of every tenant in memory.
+func (r *OutboxRepository) ClaimOutboxEntries(ctx context.Context, at time.Time, lease time.Duration, limit int) ([]*domain.OutboxEntry, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	due := r.matching(func(entry domain.OutboxEntry) bool {
+		return entry.Status() == domain.OutboxPending && !entry.NextAttemptAt.After(at)
+	})
+
+	claimed := make([]*domain.OutboxEntry, 0, min(len(due), limit))
+
+	for _, entry := range due[:min(len(due), limit)] {
+		entry.NextAttemptAt = at.Add(lease)
+		r.entries[entry.ID] = entry
+		claimed = append(claimed, &entry)
+	}
+
+	return claimed, nil
+}
+
+// Implements the logic to save the delivery state of an outbox entry of the tenant in memory.
+func (r *OutboxRepository) UpdateOutboxEntry(ctx context.Context, entry *domain.OutboxEntry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, ok := r.entries[entry.ID]
+	if !ok || existing.TenantID != domain.TenantFrom(ctx) {
+		return domain.ErrOutboxEntryNotFound
+	}
+
+	updated := *entry
+	updated.TenantID = existing.TenantID
+	r.entries[entry.ID] = updated
+
+	return nil
+}
+
+// Implements the logic to find an outbox entry of the tenant in memory.
+func (r *OutboxRepository) FindOutboxEntry(ctx context.Context, id int64) (*domain.OutboxEntry, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	entry, ok := r.entries[id]
+	if !ok || entry.TenantID != domain.TenantFrom(ctx) {
+		return nil, nil // Entry not found
+	}
+
+	return &entry, nil
+}
+
+// Implements the logic to find a page of the outbox entries of the tenant with a status in memory, oldest first.
+func (r *OutboxRepository) FindOutboxEntries(ctx context.Context, query domain.OutboxQuery) (*domain.Page[domain.OutboxEntry], error) {
+	limit := query.Page.NormalizedLimit()
+
+	var after int64
+	if query.Page.Cursor != nil {
+		id, err := query.Page.Cursor.OutboxValue()
+		if err != nil {
+			return nil, fmt.Errorf("memory: %w", err)
+		}
+		after = id
+	}
+
+	tenant := domain.TenantFrom(ctx)
+
+	r.mu.RLock()
+	entries := r.matching(func(entry domain.OutboxEntry) bool {
+		return entry.TenantID == tenant && entry.ID > after && entry.Status() == query.Status
+	})
+	r.mu.RUnlock()
+
+	// Keep one extra entry to know whether there is a next page
+	if len(entries) > limit+1 {
+		entries = entries[:limit+1]
+	}
+
+	return domain.NewPage(entries, limit, domain.OutboxCursor), nil
+}
+
+// Implements the logic to count the pending and failed outbox entries of the tenant in memory.
+func (r *OutboxRepository) CountOutboxEntries(ctx context.Context) (*domain.OutboxCounts, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	counts := &domain.OutboxCounts{}
+	tenant := domain.TenantFrom(ctx)
+
+	for _, entry := range r.entries {
+		if entry.TenantID != tenant {
+			continue
+		}
+
+		switch entry.Status() {
+		case domain.OutboxPending:
+			counts.Pending++
+		case domain.OutboxFailed:
+			counts.Failed++
+		}
+	}
+
+	return counts, nil
+}
+
+// Implements the logic to remove the outbox entries delivered before the given time in memory, in every tenant.
+func (r *OutboxRepository) PurgeDeliveredOutboxEntries(ctx context.Context, deliveredBefore time.Time) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var purged int64
+
+	for id, entry := range r.entries {
+		if entry.DeliveredAt != nil && entry.DeliveredAt.Before(deliveredBefore) {
+			delete(r.entries, id)
+			purged++
+		}
+	}
+
+	return purged, nil
+}
+
+// Returns the entries that match, oldest first. The caller holds the lock.
+func (r *OutboxRepository) matching(match func(domain.OutboxEntry) bool) []domain.OutboxEntry {
+	entries := make([]domain.OutboxEntry, 0)
+
+	for _, entry := range r.entries {
+		if match(entry) {
+			entries = append(entries, entry)
+		}
+	}
+
+	sort.Slice(entries, func(i, j int) bool { return entries[i].ID < entries[j].ID })
+
+	return entries
+}
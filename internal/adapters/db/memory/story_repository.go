@@ -0,0 +1,414 @@
+package memory
+
+import (
+	"Gin/internal/core/domain"
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Implements the ports.StoryDrivenPort interface with an in-memory map.
+// Intended for development and tests: data is lost when the process exits.
+type StoryRepository struct {
+	mu      sync.RWMutex
+	stories map[string]domain.Story
+}
+
+// Creates a new, empty instance of StoryRepository.
+func NewStoryRepository() *StoryRepository {
+	return &StoryRepository{stories: make(map[string]domain.Story)}
+}
+
+// Implements the logic to save a story in memory, in the tenant of the context.
+func (r *StoryRepository) SaveStory(ctx context.Context, story *domain.Story) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Generate a new UUID if no ID is provided (for new stories)
+	if story.ID == "" {
+		story.ID = uuid.New().String()
+	}
+
+	if _, exists := r.stories[story.ID]; exists {
+		return fmt.Errorf("memory: failed to insert story: duplicate id %s", story.ID)
+	}
+
+	story.CreatedAt = now()
+	story.UpdatedAt = story.CreatedAt
+	story.Version = 1
+	story.TenantID = domain.TenantFrom(ctx)
+	story.Tags = slices.Clone(story.Tags) // Never share the slice with the caller
+
+	r.stories[story.ID] = *story
+
+	return nil
+}
+
+// Implements the logic to save new stories in memory, in the tenant of the context.
+// Stops at the first story that cannot be saved; the earlier ones stay saved unless a unit of work rolls them back.
+func (r *StoryRepository) SaveStories(ctx context.Context, stories []*domain.Story) error {
+	for _, story := range stories {
+		if err := r.SaveStory(ctx, story); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Implements the logic to find a story by ID in memory. Soft-deleted stories are not found.
+func (r *StoryRepository) FindStoryByID(ctx context.Context, id string) (*domain.Story, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	story, ok := r.stories[id]
+	if !ok || story.DeletedAt != nil || story.TenantID != domain.TenantFrom(ctx) {
+		return nil, nil // Story not found
+	}
+
+	return &story, nil
+}
+
+// Implements the logic to find a filtered, sorted page of stories in memory.
+// Mirrors the PostgreSQL keyset pagination on (sort field, id).
+func (r *StoryRepository) FindAllStories(ctx context.Context, q domain.StoryQuery) (*domain.Page[domain.Story], error) {
+	limit := q.Page.NormalizedLimit()
+
+	var after *domain.Story
+	if q.Page.Cursor != nil {
+		probe, err := cursorStory(q.Sort, *q.Page.Cursor)
+		if err != nil {
+			return nil, fmt.Errorf("memory: %w", err)
+		}
+		after = probe
+	}
+
+	tenant := domain.TenantFrom(ctx)
+
+	r.mu.RLock()
+	stories := make([]domain.Story, 0, len(r.stories))
+	for _, story := range r.stories {
+		if story.TenantID == tenant && q.Matches(&story) && (after == nil || compareStories(story, *after, q.Sort) > 0) {
+			stories = append(stories, story)
+		}
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(stories, func(i, j int) bool { return compareStories(stories[i], stories[j], q.Sort) < 0 })
+
+	// Keep one extra story to know whether there is a next page
+	if len(stories) > limit+1 {
+		stories = stories[:limit+1]
+	}
+
+	return domain.NewPage(stories, limit, q.Sort.CursorFor), nil
+}
+
+// Implements the logic to stream the stories of a listing in memory, with the filters and sort of FindAllStories.
+// The matching stories are copied under the lock first, so fn never runs while the repository is locked.
+func (r *StoryRepository) StreamStories(ctx context.Context, q domain.StoryQuery, fn func(*domain.Story) error) error {
+	tenant := domain.TenantFrom(ctx)
+
+	r.mu.RLock()
+	stories := make([]domain.Story, 0, len(r.stories))
+	for _, story := range r.stories {
+		if story.TenantID == tenant && q.Matches(&story) {
+			stories = append(stories, story)
+		}
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(stories, func(i, j int) bool { return compareStories(stories[i], stories[j], q.Sort) < 0 })
+
+	for i := range stories {
+		if err := fn(&stories[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Implements a simple full-text search in memory: every term must appear in the title or content.
+// Terms prefixed with "-" exclude stories. Unlike PostgreSQL there is no stemming.
+func (r *StoryRepository) SearchStories(ctx context.Context, q domain.StorySearchQuery) ([]domain.StorySearchResult, error) {
+	include, exclude := q.Terms()
+	tenant := domain.TenantFrom(ctx)
+
+	r.mu.RLock()
+	results := make([]domain.StorySearchResult, 0)
+	for _, story := range r.stories {
+		if story.DeletedAt != nil || story.TenantID != tenant {
+			continue
+		}
+
+		rank, ok := searchRank(story, include, exclude)
+		if !ok {
+			continue
+		}
+
+		results = append(results, domain.StorySearchResult{
+			Story:   story,
+			Rank:    rank,
+			Snippet: searchSnippet(story.Content, include),
+		})
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Rank != results[j].Rank {
+			return results[i].Rank > results[j].Rank
+		}
+		return compareStories(results[i].Story, results[j].Story, domain.DefaultStorySort) < 0
+	})
+
+	if q.Offset >= len(results) {
+		return make([]domain.StorySearchResult, 0), nil
+	}
+
+	results = results[q.Offset:]
+	if len(results) > q.Limit {
+		results = results[:q.Limit]
+	}
+
+	return results, nil
+}
+
+// Implements the logic to update a story in memory. Soft-deleted stories cannot be updated.
+// The update only applies if the stored version still equals story.Version, which is then incremented.
+func (r *StoryRepository) UpdateStory(ctx context.Context, story *domain.Story) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, ok := r.stories[story.ID]
+	if !ok || existing.DeletedAt != nil || existing.TenantID != domain.TenantFrom(ctx) {
+		return domain.ErrStoryNotFound
+	}
+
+	if existing.Version != story.Version {
+		return domain.ErrVersionConflict
+	}
+
+	story.TenantID = existing.TenantID
+	story.CreatedAt = existing.CreatedAt
+	story.UpdatedAt = now() // Update the updated_at column
+	story.DeletedAt = nil
+	story.Version++
+	story.Tags = slices.Clone(story.Tags)
+
+	r.stories[story.ID] = *story
+
+	return nil
+}
+
+// Implements the logic to soft-delete a story in memory.
+func (r *StoryRepository) DeleteStory(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	story, ok := r.stories[id]
+	if !ok || story.DeletedAt != nil || story.TenantID != domain.TenantFrom(ctx) {
+		return domain.ErrStoryNotFound
+	}
+
+	deletedAt := now()
+	story.DeletedAt = &deletedAt
+	r.stories[id] = story
+
+	return nil
+}
+
+// Implements the logic to restore a soft-deleted story in memory.
+func (r *StoryRepository) RestoreStory(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	story, ok := r.stories[id]
+	if !ok || story.DeletedAt == nil || story.TenantID != domain.TenantFrom(ctx) {
+		return domain.ErrStoryNotFound
+	}
+
+	story.DeletedAt = nil
+	r.stories[id] = story
+
+	return nil
+}
+
+// Implements the logic to find the tags used by stories in memory, with the number of stories using each one.
+// Deleted stories are not counted. Most used tags first.
+func (r *StoryRepository) FindTags(ctx context.Context) ([]domain.TagUsage, error) {
+	tenant := domain.TenantFrom(ctx)
+
+	r.mu.RLock()
+	counts := make(map[string]int64)
+	for _, story := range r.stories {
+		if story.DeletedAt != nil || story.TenantID != tenant {
+			continue
+		}
+
+		for _, tag := range story.Tags {
+			counts[tag]++
+		}
+	}
+	r.mu.RUnlock()
+
+	tags := make([]domain.TagUsage, 0, len(counts))
+	for name, stories := range counts {
+		tags = append(tags, domain.TagUsage{Name: name, Stories: stories})
+	}
+
+	sort.Slice(tags, func(i, j int) bool {
+		if tags[i].Stories != tags[j].Stories {
+			return tags[i].Stories > tags[j].Stories
+		}
+		return tags[i].Name < tags[j].Name
+	})
+
+	return tags, nil
+}
+
+// Implements the logic to permanently remove the stories soft-deleted before the given time in memory.
+// The purge is maintenance of the whole deployment, so it covers every tenant.
+func (r *StoryRepository) PurgeDeletedStories(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var purged int64
+	for id, story := range r.stories {
+		if story.DeletedAt != nil && story.DeletedAt.Before(deletedBefore) {
+			delete(r.stories, id)
+			purged++
+		}
+	}
+
+	return purged, nil
+}
+
+// Compares two stories in listing order: by the sort field, then by ID, both in the sort direction.
+func compareStories(a, b domain.Story, s domain.StorySort) int {
+	var result int
+
+	switch s.Field {
+	case domain.StorySortUpdatedAt:
+		result = a.UpdatedAt.Compare(b.UpdatedAt)
+	case domain.StorySortTitle:
+		result = strings.Compare(a.Title, b.Title)
+	case domain.StorySortAuthor:
+		result = strings.Compare(a.Author, b.Author)
+	default:
+		result = a.CreatedAt.Compare(b.CreatedAt)
+	}
+
+	if result == 0 {
+		result = strings.Compare(a.ID, b.ID)
+	}
+
+	if s.Desc {
+		return -result
+	}
+
+	return result
+}
+
+// Returns a story holding only the sort key and ID stored in the cursor, for comparison.
+func cursorStory(s domain.StorySort, cursor domain.Cursor) (*domain.Story, error) {
+	value, err := s.CursorValue(cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	probe := &domain.Story{ID: cursor.ID}
+
+	switch s.Field {
+	case domain.StorySortUpdatedAt:
+		probe.UpdatedAt = value.(time.Time)
+	case domain.StorySortTitle:
+		probe.Title = value.(string)
+	case domain.StorySortAuthor:
+		probe.Author = value.(string)
+	default:
+		probe.CreatedAt = value.(time.Time)
+	}
+
+	return probe, nil
+}
+
+// Returns the rank of the story for the terms, or false if it does not match.
+// Title occurrences weigh more than content occurrences, as with the weighted PostgreSQL vector.
+func searchRank(story domain.Story, include, exclude []string) (float64, bool) {
+	title := strings.ToLower(story.Title)
+	content := strings.ToLower(story.Content)
+
+	for _, term := range exclude {
+		if strings.Contains(title, term) || strings.Contains(content, term) {
+			return 0, false
+		}
+	}
+
+	if len(include) == 0 {
+		return 0, false
+	}
+
+	rank := 0.0
+	for _, term := range include {
+		inTitle := strings.Count(title, term)
+		inContent := strings.Count(content, term)
+
+		if inTitle+inContent == 0 {
+			return 0, false
+		}
+
+		rank += float64(inTitle) + 0.4*float64(inContent)
+	}
+
+	return rank, true
+}
+
+// Returns an excerpt of up to 30 words of the content starting near the first match, with terms wrapped in <mark></mark>.
+func searchSnippet(content string, terms []string) string {
+	const maxWords = 30
+
+	words := strings.Fields(content)
+	start := 0
+
+	for i, word := range words {
+		if containsAny(strings.ToLower(word), terms) {
+			start = max(0, i-5)
+			break
+		}
+	}
+
+	end := min(len(words), start+maxWords)
+	snippet := make([]string, 0, end-start)
+
+	for _, word := range words[start:end] {
+		if containsAny(strings.ToLower(word), terms) {
+			word = "<mark>" + word + "</mark>"
+		}
+		snippet = append(snippet, word)
+	}
+
+	return strings.Join(snippet, " ")
+}
+
+// Reports whether s contains any of the terms.
+func containsAny(s string, terms []string) bool {
+	for _, term := range terms {
+		if strings.Contains(s, term) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Returns the current time truncated to microseconds, the precision PostgreSQL stores.
+func now() time.Time {
+	return time.Now().UTC().Truncate(time.Microsecond)
+}
@@ -0,0 +1,87 @@
+package memory
+
+import (
+	"Gin/internal/core/domain"
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+)
+
+// Implements the ports.StoryRevisionDrivenPort interface with an in-memory map.
+// The revisions of each story are kept in ascending order, so revision n is at index n-1.
+// They are keyed by tenantKey, so the revisions of a story are only found in the tenant of the story.
+type StoryRevisionRepository struct {
+	mu        sync.RWMutex
+	revisions map[string][]domain.StoryRevision
+}
+
+// Creates a new, empty instance of StoryRevisionRepository.
+func NewStoryRevisionRepository() *StoryRevisionRepository {
+	return &StoryRevisionRepository{revisions: make(map[string][]domain.StoryRevision)}
+}
+
+// Implements the logic to save a story revision in memory, numbered after the latest revision of the story.
+func (r *StoryRevisionRepository) SaveRevision(ctx context.Context, revision *domain.StoryRevision) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := tenantKey(ctx, revision.StoryID)
+	revisions := r.revisions[key]
+
+	revision.Revision = len(revisions) + 1
+	revision.CreatedAt = now()
+
+	// Clip so the append never writes into an array shared with a unit of work copy
+	r.revisions[key] = append(slices.Clip(revisions), *revision)
+
+	return nil
+}
+
+// Implements the logic to find a revision of a story in memory.
+func (r *StoryRevisionRepository) FindRevision(ctx context.Context, storyID string, revision int) (*domain.StoryRevision, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	revisions := r.revisions[tenantKey(ctx, storyID)]
+	if revision < 1 || revision > len(revisions) {
+		return nil, nil // Revision not found
+	}
+
+	found := revisions[revision-1]
+	return &found, nil
+}
+
+// Implements the logic to find a page of the revisions of a story in memory, newest first.
+func (r *StoryRevisionRepository) FindRevisions(ctx context.Context, storyID string, page domain.PageRequest) (*domain.Page[domain.StoryRevision], error) {
+	limit := page.NormalizedLimit()
+
+	r.mu.RLock()
+	revisions := slices.Clone(r.revisions[tenantKey(ctx, storyID)])
+	r.mu.RUnlock()
+
+	if page.Cursor != nil {
+		before, err := page.Cursor.RevisionValue(storyID)
+		if err != nil {
+			return nil, fmt.Errorf("memory: %w", err)
+		}
+		revisions = revisions[:min(max(before-1, 0), len(revisions))]
+	}
+
+	slices.Reverse(revisions)
+
+	// Keep one extra revision to know whether there is a next page
+	if len(revisions) > limit+1 {
+		revisions = revisions[:limit+1]
+	}
+
+	return domain.NewPage(revisions, limit, domain.StoryRevisionCursor), nil
+}
+
+// Implements the logic to find the number of the latest revision of a story in memory.
+func (r *StoryRevisionRepository) LatestRevision(ctx context.Context, storyID string) (int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return len(r.revisions[tenantKey(ctx, storyID)]), nil
+}
@@ -0,0 +1,13 @@
+package memory
+
+import (
+	"Gin/internal/core/domain"
+	"context"
+)
+
+// Returns the key of a row in the maps of the repositories whose rows have no tenant of their own,
+// like story revisions and comments, which belong to the tenant of their story.
+// Rows saved in another tenant have another key, so they are never found.
+func tenantKey(ctx context.Context, id string) string {
+	return domain.TenantFrom(ctx) + "/" + id
+}
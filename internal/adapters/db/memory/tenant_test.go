@@ -0,0 +1,227 @@
+package memory
+
+import (
+	"Gin/internal/core/domain"
+	"context"
+	"errors"
+	"testing"
+)
+
+var (
+	acme   = domain.WithTenant(context.Background(), "acme")
+	globex = domain.WithTenant(context.Background(), "globex")
+)
+
+// Saves a story of acme with the given tags.
+func saveAcmeStory(t *testing.T, repo *StoryRepository, tags ...string) *domain.Story {
+	t.Helper()
+
+	story := &domain.Story{Title: "Title", Author: "Author", Content: "Content", Tags: tags}
+	if err := repo.SaveStory(acme, story); err != nil {
+		t.Fatalf("SaveStory failed: %v", err)
+	}
+
+	return story
+}
+
+func TestFindStoryByIDIsScopedToTheTenant(t *testing.T) {
+	repo := NewStoryRepository()
+	story := saveAcmeStory(t, repo)
+
+	if found, err := repo.FindStoryByID(globex, story.ID); err != nil || found != nil {
+		t.Errorf("FindStoryByID in globex = %+v, %v, want not found", found, err)
+	}
+	if found, err := repo.FindStoryByID(acme, story.ID); err != nil || found == nil {
+		t.Errorf("FindStoryByID in acme = %+v, %v, want the story", found, err)
+	}
+}
+
+func TestFindAllStoriesIsScopedToTheTenant(t *testing.T) {
+	repo := NewStoryRepository()
+	story := saveAcmeStory(t, repo)
+
+	page, err := repo.FindAllStories(globex, domain.StoryQuery{})
+	if err != nil {
+		t.Fatalf("FindAllStories failed: %v", err)
+	}
+	if len(page.Items) != 0 {
+		t.Errorf("globex lists %d stories, want none", len(page.Items))
+	}
+
+	page, err = repo.FindAllStories(acme, domain.StoryQuery{})
+	if err != nil {
+		t.Fatalf("FindAllStories failed: %v", err)
+	}
+	if len(page.Items) != 1 || page.Items[0].ID != story.ID {
+		t.Errorf("acme lists %+v, want its story", page.Items)
+	}
+}
+
+func TestUpdateStoryIsScopedToTheTenant(t *testing.T) {
+	repo := NewStoryRepository()
+	story := saveAcmeStory(t, repo)
+
+	update := *story
+	update.Title = "Changed"
+	if err := repo.UpdateStory(globex, &update); !errors.Is(err, domain.ErrStoryNotFound) {
+		t.Errorf("UpdateStory in globex = %v, want %v", err, domain.ErrStoryNotFound)
+	}
+
+	found, _ := repo.FindStoryByID(acme, story.ID)
+	if found.Title != story.Title || found.Version != story.Version {
+		t.Errorf("story = %+v, want it unchanged", found)
+	}
+}
+
+func TestDeleteStoryIsScopedToTheTenant(t *testing.T) {
+	repo := NewStoryRepository()
+	story := saveAcmeStory(t, repo)
+
+	if err := repo.DeleteStory(globex, story.ID); !errors.Is(err, domain.ErrStoryNotFound) {
+		t.Errorf("DeleteStory in globex = %v, want %v", err, domain.ErrStoryNotFound)
+	}
+	if err := repo.RestoreStory(globex, story.ID); !errors.Is(err, domain.ErrStoryNotFound) {
+		t.Errorf("RestoreStory in globex = %v, want %v", err, domain.ErrStoryNotFound)
+	}
+
+	if found, _ := repo.FindStoryByID(acme, story.ID); found == nil {
+		t.Error("the story of acme was deleted from globex")
+	}
+}
+
+func TestFindTagsIsScopedToTheTenant(t *testing.T) {
+	repo := NewStoryRepository()
+	saveAcmeStory(t, repo, "go")
+
+	other := &domain.Story{Title: "Title", Author: "Author", Content: "Content", Tags: []string{"go", "rust"}}
+	if err := repo.SaveStory(globex, other); err != nil {
+		t.Fatalf("SaveStory failed: %v", err)
+	}
+
+	tags, err := repo.FindTags(acme)
+	if err != nil {
+		t.Fatalf("FindTags failed: %v", err)
+	}
+	if len(tags) != 1 || tags[0] != (domain.TagUsage{Name: "go", Stories: 1}) {
+		t.Errorf("acme tags = %+v, want go used once", tags)
+	}
+}
+
+func TestCommentsAreScopedToTheTenant(t *testing.T) {
+	repo := NewCommentRepository()
+
+	comment := &domain.Comment{StoryID: "story", AuthorID: "user", Body: "Body"}
+	if err := repo.SaveComment(acme, comment); err != nil {
+		t.Fatalf("SaveComment failed: %v", err)
+	}
+
+	if found, err := repo.FindCommentByID(globex, comment.ID); err != nil || found != nil {
+		t.Errorf("FindCommentByID in globex = %+v, %v, want not found", found, err)
+	}
+
+	page, err := repo.FindComments(globex, domain.CommentQuery{StoryID: "story"})
+	if err != nil {
+		t.Fatalf("FindComments failed: %v", err)
+	}
+	if len(page.Items) != 0 {
+		t.Errorf("globex lists %d comments, want none", len(page.Items))
+	}
+
+	update := *comment
+	update.Body = "Changed"
+	if err := repo.UpdateComment(globex, &update); !errors.Is(err, domain.ErrCommentNotFound) {
+		t.Errorf("UpdateComment in globex = %v, want %v", err, domain.ErrCommentNotFound)
+	}
+	if err := repo.DeleteComment(globex, comment.ID); !errors.Is(err, domain.ErrCommentNotFound) {
+		t.Errorf("DeleteComment in globex = %v, want %v", err, domain.ErrCommentNotFound)
+	}
+
+	if found, _ := repo.FindCommentByID(acme, comment.ID); found == nil || found.Body != comment.Body {
+		t.Errorf("comment of acme = %+v, want it unchanged", found)
+	}
+}
+
+func TestUserEmailsAreUniqueWithinTheTenant(t *testing.T) {
+	repo := NewUserRepository()
+
+	if err := repo.SaveUser(acme, &domain.User{ID: "jane-acme", Email: "jane@example.com", Name: "Jane"}); err != nil {
+		t.Fatalf("SaveUser failed: %v", err)
+	}
+
+	if err := repo.SaveUser(globex, &domain.User{ID: "jane-globex", Email: "jane@example.com", Name: "Jane"}); err != nil {
+		t.Errorf("SaveUser of the same email in globex failed: %v", err)
+	}
+	if err := repo.SaveUser(acme, &domain.User{ID: "other", Email: "jane@example.com", Name: "Other"}); err == nil {
+		t.Error("SaveUser of a taken email in acme succeeded")
+	}
+}
+
+func TestAuditEventsAreListedToTheirTenant(t *testing.T) {
+	repo := NewAuditRepository()
+
+	event, err := domain.NewAuditEvent(acme, domain.AuditCreate, domain.AuditUser, "user", nil, map[string]any{"email": "jane@example.com"})
+	if err != nil {
+		t.Fatalf("NewAuditEvent failed: %v", err)
+	}
+	if err := repo.SaveAuditEvents(acme, []*domain.AuditEvent{event}); err != nil {
+		t.Fatalf("SaveAuditEvents failed: %v", err)
+	}
+
+	page, err := repo.FindAuditEvents(globex, domain.AuditQuery{})
+	if err != nil {
+		t.Fatalf("FindAuditEvents failed: %v", err)
+	}
+	if len(page.Items) != 0 {
+		t.Errorf("globex lists %d audit events, want none", len(page.Items))
+	}
+}
+
+func TestWebhooksAreScopedToTheTenant(t *testing.T) {
+	repo := NewWebhookRepository()
+
+	webhook := &domain.Webhook{URL: "https://example.com", Secret: "0123456789abcdef"}
+	if err := repo.SaveWebhook(acme, webhook); err != nil {
+		t.Fatalf("SaveWebhook failed: %v", err)
+	}
+
+	if found, err := repo.FindWebhookByID(globex, webhook.ID); err != nil || found != nil {
+		t.Errorf("FindWebhookByID in globex = %+v, %v, want not found", found, err)
+	}
+	if webhooks, err := repo.FindWebhooks(globex); err != nil || len(webhooks) != 0 {
+		t.Errorf("FindWebhooks in globex = %+v, %v, want none", webhooks, err)
+	}
+	if err := repo.DeleteWebhook(globex, webhook.ID); !errors.Is(err, domain.ErrWebhookNotFound) {
+		t.Errorf("DeleteWebhook in globex = %v, want %v", err, domain.ErrWebhookNotFound)
+	}
+
+	// A delivery can only be queued in the tenant of its webhook
+	delivery, err := domain.NewWebhookDelivery(webhook, 1, domain.StoryDeleted{StoryID: "story"}, now())
+	if err != nil {
+		t.Fatalf("NewWebhookDelivery failed: %v", err)
+	}
+	if err := repo.SaveWebhookDeliveries(globex, []*domain.WebhookDelivery{delivery}); err == nil {
+		t.Error("SaveWebhookDeliveries in globex succeeded for a webhook of acme")
+	}
+}
+
+func TestOutboxEntriesCarryTheirTenant(t *testing.T) {
+	repo := NewOutboxRepository()
+
+	entry := &domain.OutboxEntry{EventType: domain.EventStoryDeleted, Payload: []byte(`{}`)}
+	if err := repo.SaveOutboxEntries(acme, []*domain.OutboxEntry{entry}); err != nil {
+		t.Fatalf("SaveOutboxEntries failed: %v", err)
+	}
+
+	if found, err := repo.FindOutboxEntry(globex, entry.ID); err != nil || found != nil {
+		t.Errorf("FindOutboxEntry in globex = %+v, %v, want not found", found, err)
+	}
+
+	// The relay claims the entries of every tenant, each with the tenant it is delivered in
+	claimed, err := repo.ClaimOutboxEntries(context.Background(), now(), 0, 10)
+	if err != nil {
+		t.Fatalf("ClaimOutboxEntries failed: %v", err)
+	}
+	if len(claimed) != 1 || claimed[0].TenantID != "acme" {
+		t.Errorf("claimed %+v, want the entry of acme", claimed)
+	}
+}
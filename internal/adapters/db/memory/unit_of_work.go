@@ -0,0 +1,66 @@
+package memory
+
+import (
+	"Gin/internal/core/ports"
+	"context"
+	"maps"
+	"slices"
+)
+
+// Implements the ports.UnitOfWork interface for the in-memory repositories.
+// A transaction holds the locks of every repository until it ends and works on copies of their data,
+// which replace the originals only when it succeeds. Transactions are therefore serializable and never need a retry.
+type UnitOfWork struct {
+	stories        *StoryRepository
+	storyRevisions *StoryRevisionRepository
+	users          *UserRepository
+	comments       *CommentRepository
+	outbox         *OutboxRepository
+	audit          *AuditRepository
+}
+
+// Creates a new instance of UnitOfWork over the given repositories.
+func NewUnitOfWork(stories *StoryRepository, storyRevisions *StoryRevisionRepository, users *UserRepository, comments *CommentRepository, outbox *OutboxRepository, audit *AuditRepository) *UnitOfWork {
+	return &UnitOfWork{stories: stories, storyRevisions: storyRevisions, users: users, comments: comments, outbox: outbox, audit: audit}
+}
+
+// Implements the logic to run fn atomically against copies of the repositories.
+// fn must only use the repositories it receives: the shared ones are locked until it returns.
+func (u *UnitOfWork) Execute(ctx context.Context, fn func(ctx context.Context, repos ports.Repositories) error) error {
+	// Always lock in the same order so concurrent transactions cannot deadlock
+	u.stories.mu.Lock()
+	defer u.stories.mu.Unlock()
+	u.storyRevisions.mu.Lock()
+	defer u.storyRevisions.mu.Unlock()
+	u.users.mu.Lock()
+	defer u.users.mu.Unlock()
+	u.comments.mu.Lock()
+	defer u.comments.mu.Unlock()
+	u.outbox.mu.Lock()
+	defer u.outbox.mu.Unlock()
+	u.audit.mu.Lock()
+	defer u.audit.mu.Unlock()
+
+	txStories := &StoryRepository{stories: maps.Clone(u.stories.stories)}
+	txStoryRevisions := &StoryRevisionRepository{revisions: maps.Clone(u.storyRevisions.revisions)}
+	txUsers := &UserRepository{users: maps.Clone(u.users.users)}
+	txComments := &CommentRepository{comments: maps.Clone(u.comments.comments)}
+	txOutbox := &OutboxRepository{entries: maps.Clone(u.outbox.entries), lastID: u.outbox.lastID}
+	txAudit := &AuditRepository{events: slices.Clip(u.audit.events), checkpoints: slices.Clip(u.audit.checkpoints)} // Appends copy the log instead of writing past its end
+
+	repos := ports.Repositories{Stories: txStories, StoryRevisions: txStoryRevisions, Users: txUsers, Comments: txComments, Outbox: txOutbox, Audit: txAudit}
+	if err := fn(ctx, repos); err != nil {
+		return err // Rollback: the copies are discarded
+	}
+
+	// Commit
+	u.stories.stories = txStories.stories
+	u.storyRevisions.revisions = txStoryRevisions.revisions
+	u.users.users = txUsers.users
+	u.comments.comments = txComments.comments
+	u.outbox.entries, u.outbox.lastID = txOutbox.entries, txOutbox.lastID
+	u.audit.events = txAudit.events
+	u.audit.checkpoints = txAudit.checkpoints
+
+	return nil
+}
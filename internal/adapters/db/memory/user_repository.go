@@ -0,0 +1,225 @@
+package memory
+
+import (
+	"Gin/internal/core/domain"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Implements the ports.UserDrivenPort interface with an in-memory map.
+// Emails are unique among the active users of a tenant, like the users_email_active_key index in PostgreSQL.
+type UserRepository struct {
+	mu    sync.RWMutex
+	users map[string]domain.User
+}
+
+// Creates a new, empty instance of UserRepository.
+func NewUserRepository() *UserRepository {
+	return &UserRepository{users: make(map[string]domain.User)}
+}
+
+// Implements the logic to save a user in memory, in the tenant of the context.
+func (r *UserRepository) SaveUser(ctx context.Context, user *domain.User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	user.TenantID = domain.TenantFrom(ctx)
+
+	if _, exists := r.users[user.ID]; exists {
+		return fmt.Errorf("memory: failed to insert user: duplicate id %s", user.ID)
+	}
+
+	if err := r.checkEmailAvailable(user.TenantID, user.Email, user.ID); err != nil {
+		return fmt.Errorf("memory: failed to insert user: %w", err)
+	}
+
+	r.users[user.ID] = *user
+
+	return nil
+}
+
+// Implements the logic to find a user by ID in memory. Soft-deleted users are not found.
+func (r *UserRepository) FindUserByID(ctx context.Context, id string) (*domain.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	user, ok := r.users[id]
+	if !ok || user.DeletedAt != nil || user.TenantID != domain.TenantFrom(ctx) {
+		return nil, nil // User not found
+	}
+
+	return &user, nil
+}
+
+// Implements the logic to find a page of users in memory, newest first.
+// Soft-deleted users are left out unless the query includes them.
+func (r *UserRepository) FindAllUsers(ctx context.Context, q domain.UserQuery) (*domain.Page[domain.User], error) {
+	limit := q.Page.NormalizedLimit()
+
+	var after *domain.User
+	if q.Page.Cursor != nil {
+		createdAt, err := q.Page.Cursor.TimeValue()
+		if err != nil {
+			return nil, fmt.Errorf("memory: %w", err)
+		}
+		after = &domain.User{ID: q.Page.Cursor.ID, CreatedAt: createdAt}
+	}
+
+	tenant := domain.TenantFrom(ctx)
+
+	r.mu.RLock()
+	users := make([]domain.User, 0, len(r.users))
+	for _, user := range r.users {
+		if user.TenantID != tenant || (user.DeletedAt != nil && !q.IncludeDeleted) {
+			continue
+		}
+
+		if after == nil || compareUsers(user, *after) > 0 {
+			users = append(users, user)
+		}
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(users, func(i, j int) bool { return compareUsers(users[i], users[j]) < 0 })
+
+	// Keep one extra user to know whether there is a next page
+	if len(users) > limit+1 {
+		users = users[:limit+1]
+	}
+
+	return domain.NewPage(users, limit, func(user domain.User) domain.Cursor {
+		return domain.NewTimeCursor("", user.CreatedAt, user.ID)
+	}), nil
+}
+
+// Implements the logic to stream users in memory, newest first like FindAllUsers.
+// The users are copied under the lock first, so fn never runs while the repository is locked.
+func (r *UserRepository) StreamUsers(ctx context.Context, q domain.UserQuery, fn func(*domain.User) error) error {
+	tenant := domain.TenantFrom(ctx)
+
+	r.mu.RLock()
+	users := make([]domain.User, 0, len(r.users))
+	for _, user := range r.users {
+		if user.TenantID == tenant && (user.DeletedAt == nil || q.IncludeDeleted) {
+			users = append(users, user)
+		}
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(users, func(i, j int) bool { return compareUsers(users[i], users[j]) < 0 })
+
+	for i := range users {
+		if err := fn(&users[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Implements the logic to update an existing user in memory. Soft-deleted users cannot be updated.
+// The update only applies if the stored version still equals user.Version, which is then incremented.
+func (r *UserRepository) UpdateUser(ctx context.Context, user *domain.User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, ok := r.users[user.ID]
+	if !ok || existing.DeletedAt != nil || existing.TenantID != domain.TenantFrom(ctx) {
+		return domain.ErrUserNotFound
+	}
+
+	if existing.Version != user.Version {
+		return domain.ErrVersionConflict
+	}
+
+	if err := r.checkEmailAvailable(existing.TenantID, user.Email, user.ID); err != nil {
+		return fmt.Errorf("memory: failed to update user: %w", err)
+	}
+
+	user.TenantID = existing.TenantID
+	user.CreatedAt = existing.CreatedAt
+	user.DeletedAt = nil
+	user.Version++
+	r.users[user.ID] = *user
+
+	return nil
+}
+
+// Implements the logic to soft-delete a user in memory.
+func (r *UserRepository) DeleteUser(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	user, ok := r.users[id]
+	if !ok || user.DeletedAt != nil || user.TenantID != domain.TenantFrom(ctx) {
+		return domain.ErrUserNotFound
+	}
+
+	deletedAt := now()
+	user.DeletedAt = &deletedAt
+	r.users[id] = user
+
+	return nil
+}
+
+// Implements the logic to restore a soft-deleted user in memory.
+// Returns domain.ErrEmailInUse if an active user took the email in the meantime.
+func (r *UserRepository) RestoreUser(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	user, ok := r.users[id]
+	if !ok || user.DeletedAt == nil || user.TenantID != domain.TenantFrom(ctx) {
+		return domain.ErrUserNotFound
+	}
+
+	if err := r.checkEmailAvailable(user.TenantID, user.Email, user.ID); err != nil {
+		return domain.ErrEmailInUse
+	}
+
+	user.DeletedAt = nil
+	r.users[id] = user
+
+	return nil
+}
+
+// Implements the logic to permanently remove the users soft-deleted before the given time in memory.
+// The purge is maintenance of the whole deployment, so it covers every tenant.
+func (r *UserRepository) PurgeDeletedUsers(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var purged int64
+	for id, user := range r.users {
+		if user.DeletedAt != nil && user.DeletedAt.Before(deletedBefore) {
+			delete(r.users, id)
+			purged++
+		}
+	}
+
+	return purged, nil
+}
+
+// Returns an error if another active user of the tenant already has the email. Must be called with the lock held.
+func (r *UserRepository) checkEmailAvailable(tenant, email, id string) error {
+	for _, other := range r.users {
+		if other.TenantID == tenant && other.Email == email && other.ID != id && other.DeletedAt == nil {
+			return fmt.Errorf("email %s is already in use", email)
+		}
+	}
+
+	return nil
+}
+
+// Compares two users in listing order: newest first, ties broken by descending ID.
+func compareUsers(a, b domain.User) int {
+	if result := b.CreatedAt.Compare(a.CreatedAt); result != 0 {
+		return result
+	}
+
+	return strings.Compare(b.ID, a.ID)
+}
@@ -0,0 +1,303 @@
+package memory
+
+import (
+	"Gin/internal/core/domain"
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Implements the ports.WebhookDrivenPort interface with in-memory maps.
+type WebhookRepository struct {
+	mu         sync.RWMutex
+	webhooks   map[string]domain.Webhook
+	deliveries map[string]domain.WebhookDelivery
+}
+
+// Creates a new, empty instance of WebhookRepository.
+func NewWebhookRepository() *WebhookRepository {
+	return &WebhookRepository{webhooks: make(map[string]domain.Webhook), deliveries: make(map[string]domain.WebhookDelivery)}
+}
+
+// Implements the logic to save a webhook in memory, in the tenant of the context.
+func (r *WebhookRepository) SaveWebhook(ctx context.Context, webhook *domain.Webhook) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if webhook.ID == "" {
+		webhook.ID = uuid.New().String()
+	}
+
+	webhook.TenantID = domain.TenantFrom(ctx)
+	webhook.CreatedAt = now()
+	if webhook.Events == nil {
+		webhook.Events = []domain.EventType{}
+	}
+
+	stored := *webhook
+	stored.Events = slices.Clone(webhook.Events)
+	r.webhooks[webhook.ID] = stored
+
+	return nil
+}
+
+// Implements the logic to find a webhook of the tenant by ID in memory.
+func (r *WebhookRepository) FindWebhookByID(ctx context.Context, id string) (*domain.Webhook, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	webhook, ok := r.webhooks[id]
+	if !ok || webhook.TenantID != domain.TenantFrom(ctx) {
+		return nil, nil // Webhook not found
+	}
+
+	webhook.Events = slices.Clone(webhook.Events)
+	return &webhook, nil
+}
+
+// Implements the logic to find every webhook of the tenant in memory, oldest first.
+func (r *WebhookRepository) FindWebhooks(ctx context.Context) ([]domain.Webhook, error) {
+	tenant := domain.TenantFrom(ctx)
+
+	r.mu.RLock()
+	webhooks := make([]domain.Webhook, 0)
+	for _, webhook := range r.webhooks {
+		if webhook.TenantID != tenant {
+			continue
+		}
+
+		webhook.Events = slices.Clone(webhook.Events)
+		webhooks = append(webhooks, webhook)
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(webhooks, func(i, j int) bool {
+		if !webhooks[i].CreatedAt.Equal(webhooks[j].CreatedAt) {
+			return webhooks[i].CreatedAt.Before(webhooks[j].CreatedAt)
+		}
+		return webhooks[i].ID < webhooks[j].ID
+	})
+
+	return webhooks, nil
+}
+
+// Implements the logic to delete a webhook of the tenant and its deliveries in memory.
+func (r *WebhookRepository) DeleteWebhook(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if webhook, ok := r.webhooks[id]; !ok || webhook.TenantID != domain.TenantFrom(ctx) {
+		return domain.ErrWebhookNotFound
+	}
+
+	delete(r.webhooks, id)
+
+	for deliveryID, delivery := range r.deliveries {
+		if delivery.WebhookID == id {
+			delete(r.deliveries, deliveryID)
+		}
+	}
+
+	// Like the ON DELETE SET NULL of the SQL adapters
+	for deliveryID, delivery := range r.deliveries {
+		if delivery.RedeliveryOf != nil {
+			if _, ok := r.deliveries[*delivery.RedeliveryOf]; !ok {
+				delivery.RedeliveryOf = nil
+				r.deliveries[deliveryID] = delivery
+			}
+		}
+	}
+
+	return nil
+}
+
+// Implements the logic to save webhook deliveries in memory, in the tenant of the context, due immediately,
+// skipping those already queued for their outbox entry.
+func (r *WebhookRepository) SaveWebhookDeliveries(ctx context.Context, deliveries []*domain.WebhookDelivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	tenant := domain.TenantFrom(ctx)
+
+	for _, delivery := range deliveries {
+		if webhook, ok := r.webhooks[delivery.WebhookID]; !ok || webhook.TenantID != tenant {
+			return fmt.Errorf("memory: webhook %s of delivery: %w", delivery.WebhookID, domain.ErrWebhookNotFound)
+		}
+	}
+
+	createdAt := now()
+
+	for _, delivery := range deliveries {
+		delivery.ID = uuid.New().String()
+		delivery.TenantID = tenant
+		delivery.CreatedAt = createdAt
+		delivery.NextAttemptAt = createdAt
+
+		if !r.queued(delivery) {
+			r.deliveries[delivery.ID] = *delivery
+		}
+	}
+
+	return nil
+}
+
+// Reports whether the outbox entry of a delivery already has a delivery to its webhook.
+func (r *WebhookRepository) queued(delivery *domain.WebhookDelivery) bool {
+	if delivery.OutboxEntryID == nil {
+		return false
+	}
+
+	for _, other := range r.deliveries {
+		if other.WebhookID == delivery.WebhookID && other.OutboxEntryID != nil && *other.OutboxEntryID == *delivery.OutboxEntryID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Implements the logic to claim the due webhook deliveries of every tenant in memory.
+func (r *WebhookRepository) ClaimWebhookDeliveries(ctx context.Context, at time.Time, lease time.Duration, limit int) ([]*domain.WebhookDelivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	due := make([]domain.WebhookDelivery, 0)
+	for _, delivery := range r.deliveries {
+		if delivery.Status() == domain.OutboxPending && !delivery.NextAttemptAt.After(at) {
+			due = append(due, delivery)
+		}
+	}
+
+	sort.Slice(due, func(i, j int) bool {
+		if !due[i].NextAttemptAt.Equal(due[j].NextAttemptAt) {
+			return due[i].NextAttemptAt.Before(due[j].NextAttemptAt)
+		}
+		return deliveryBefore(due[i], due[j])
+	})
+
+	claimed := make([]*domain.WebhookDelivery, 0, min(len(due), limit))
+
+	for _, delivery := range due[:min(len(due), limit)] {
+		delivery.NextAttemptAt = at.Add(lease)
+		r.deliveries[delivery.ID] = delivery
+		claimed = append(claimed, &delivery)
+	}
+
+	// Oldest first, like the SQL adapters
+	sort.Slice(claimed, func(i, j int) bool { return deliveryBefore(*claimed[i], *claimed[j]) })
+
+	return claimed, nil
+}
+
+// Implements the logic to save the outcome of the latest attempt of a webhook delivery of the tenant in memory.
+func (r *WebhookRepository) UpdateWebhookDelivery(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, ok := r.deliveries[delivery.ID]
+	if !ok || existing.TenantID != domain.TenantFrom(ctx) {
+		return domain.ErrDeliveryNotFound
+	}
+
+	updated := *delivery
+	updated.TenantID = existing.TenantID
+	r.deliveries[delivery.ID] = updated
+
+	return nil
+}
+
+// Implements the logic to find a delivery of a webhook of the tenant in memory.
+func (r *WebhookRepository) FindWebhookDelivery(ctx context.Context, webhookID, id string) (*domain.WebhookDelivery, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	delivery, ok := r.deliveries[id]
+	if !ok || delivery.WebhookID != webhookID || delivery.TenantID != domain.TenantFrom(ctx) {
+		return nil, nil // Delivery not found
+	}
+
+	return &delivery, nil
+}
+
+// Implements the logic to find a page of the deliveries of a webhook of the tenant in memory.
+// Newest deliveries first, like the SQL adapters.
+func (r *WebhookRepository) FindWebhookDeliveries(ctx context.Context, webhookID string, page domain.PageRequest) (*domain.Page[domain.WebhookDelivery], error) {
+	limit := page.NormalizedLimit()
+
+	var before *domain.WebhookDelivery
+	if page.Cursor != nil {
+		createdAt, err := page.Cursor.TimeValue()
+		if err != nil {
+			return nil, fmt.Errorf("memory: %w", err)
+		}
+		before = &domain.WebhookDelivery{ID: page.Cursor.ID, CreatedAt: createdAt}
+	}
+
+	tenant := domain.TenantFrom(ctx)
+
+	r.mu.RLock()
+	deliveries := make([]domain.WebhookDelivery, 0)
+	for _, delivery := range r.deliveries {
+		if delivery.WebhookID != webhookID || delivery.TenantID != tenant {
+			continue
+		}
+
+		if before != nil && !deliveryBefore(delivery, *before) {
+			continue
+		}
+
+		deliveries = append(deliveries, delivery)
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(deliveries, func(i, j int) bool {
+		return deliveryBefore(deliveries[j], deliveries[i])
+	})
+
+	// Keep one extra delivery to know whether there is a next page
+	if len(deliveries) > limit+1 {
+		deliveries = deliveries[:limit+1]
+	}
+
+	return domain.NewPage(deliveries, limit, domain.WebhookDeliveryCursor), nil
+}
+
+// Implements the logic to remove the delivered and failed webhook deliveries created before the given time in memory, in every tenant.
+func (r *WebhookRepository) PurgeWebhookDeliveries(ctx context.Context, createdBefore time.Time) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var purged int64
+
+	for id, delivery := range r.deliveries {
+		if delivery.Status() != domain.OutboxPending && delivery.CreatedAt.Before(createdBefore) {
+			delete(r.deliveries, id)
+			purged++
+		}
+	}
+
+	for id, delivery := range r.deliveries {
+		if delivery.RedeliveryOf != nil {
+			if _, ok := r.deliveries[*delivery.RedeliveryOf]; !ok {
+				delivery.RedeliveryOf = nil
+				r.deliveries[id] = delivery
+			}
+		}
+	}
+
+	return purged, nil
+}
+
+// Reports whether delivery a comes before delivery b in the (created_at, id) order.
+func deliveryBefore(a, b domain.WebhookDelivery) bool {
+	if !a.CreatedAt.Equal(b.CreatedAt) {
+		return a.CreatedAt.Before(b.CreatedAt)
+	}
+
+	return a.ID < b.ID
+}
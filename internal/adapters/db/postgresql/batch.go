@@ -0,0 +1,72 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Most placeholders in one multi-row INSERT, below the limit of 65535 parameters per statement of PostgreSQL.
+const maxBatchParams = 60000
+
+// Calls fn on consecutive chunks of items small enough for a multi-row INSERT of the given number of columns.
+func inChunks[T any](items []T, columns int, fn func(chunk []T) error) error {
+	size := maxBatchParams / columns
+
+	for start := 0; start < len(items); start += size {
+		if err := fn(items[start:min(start+size, len(items))]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Returns the rows of the VALUES clause of a multi-row INSERT, such as ($1, $2), ($3, $4), numbering the placeholders from $1.
+func valuesRows(rows, columns int) string {
+	var values strings.Builder
+
+	for row := range rows {
+		if row > 0 {
+			values.WriteString(", ")
+		}
+
+		values.WriteString("(")
+		for column := range columns {
+			if column > 0 {
+				values.WriteString(", ")
+			}
+			fmt.Fprintf(&values, "$%d", row*columns+column+1)
+		}
+		values.WriteString(")")
+	}
+
+	return values.String()
+}
+
+// Runs a multi-row INSERT returning the id column and returns the IDs in increasing order, which is the order
+// of its VALUES: RETURNING does not promise it.
+func queryIDs(ctx context.Context, db DBTX, query string, args ...any) ([]int64, error) {
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	slices.Sort(ids)
+	return ids, nil
+}
@@ -0,0 +1,165 @@
+package postgresql
+
+import (
+	"Gin/internal/core/domain"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Implements the ports.CommentDrivenPort interface for PostgreSQL.
+type CommentRepository struct {
+	db DBTX
+}
+
+// Creates a new instance of CommentRepository over a database or a transaction.
+func NewCommentRepository(db DBTX) *CommentRepository {
+	return &CommentRepository{db: db}
+}
+
+// Columns selected for a comment, in the order scanComment reads them.
+const commentColumns = `id, story_id, parent_id, author_id, body, created_at, updated_at,
+	(SELECT count(*) FROM comments r WHERE r.parent_id = comments.id) AS reply_count`
+
+// Reads a comment selected with commentColumns.
+func scanComment(row scanner) (*domain.Comment, error) {
+	comment := &domain.Comment{}
+	err := row.Scan(&comment.ID, &comment.StoryID, &comment.ParentID, &comment.AuthorID, &comment.Body, &comment.CreatedAt, &comment.UpdatedAt, &comment.ReplyCount)
+
+	return comment, err
+}
+
+// Implements the logic to save a comment in PostgreSQL.
+// Returns domain.ErrStoryNotFound if the story is not one of the tenant.
+func (r *CommentRepository) SaveComment(ctx context.Context, comment *domain.Comment) error {
+	if comment.ID == "" {
+		comment.ID = uuid.New().String()
+	}
+
+	comment.CreatedAt = time.Now()
+	comment.UpdatedAt = comment.CreatedAt
+
+	query := `
+		INSERT INTO comments (id, story_id, parent_id, author_id, body, created_at, updated_at)
+		SELECT $1, $2, $3, $4, $5, $6, $7 WHERE EXISTS (SELECT 1 FROM stories WHERE id = $2 AND tenant_id = $8)`
+	result, err := r.db.ExecContext(ctx, query, comment.ID, comment.StoryID, comment.ParentID, comment.AuthorID, comment.Body, comment.CreatedAt, comment.UpdatedAt, domain.TenantFrom(ctx))
+
+	if err != nil {
+		return fmt.Errorf("postgresql: failed to insert comment: %w", err)
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return domain.ErrStoryNotFound
+	}
+
+	return nil
+}
+
+// Implements the logic to find a comment by ID in PostgreSQL.
+func (r *CommentRepository) FindCommentByID(ctx context.Context, id string) (*domain.Comment, error) {
+	query := `SELECT ` + commentColumns + ` FROM comments WHERE id = $1 AND ` + storyTenantCondition("comments", 2)
+	comment, err := scanComment(r.db.QueryRowContext(ctx, query, id, domain.TenantFrom(ctx)))
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil // Comment not found
+		}
+
+		return nil, fmt.Errorf("postgresql: failed to find comment by ID (scan error): %w", err)
+	}
+
+	return comment, nil
+}
+
+// Implements the logic to find a page of the top-level comments of a story, or of the replies to a comment, in PostgreSQL.
+// Uses keyset pagination on (created_at, id), oldest comments first.
+func (r *CommentRepository) FindComments(ctx context.Context, q domain.CommentQuery) (*domain.Page[domain.Comment], error) {
+	limit := q.Page.NormalizedLimit()
+
+	conditions := []string{`story_id = $1`, storyTenantCondition("comments", 2)}
+	args := []any{q.StoryID, domain.TenantFrom(ctx)}
+
+	if q.ParentID == "" {
+		conditions = append(conditions, `parent_id IS NULL`)
+	} else {
+		args = append(args, q.ParentID)
+		conditions = append(conditions, fmt.Sprintf(`parent_id = $%d`, len(args)))
+	}
+
+	if q.Page.Cursor != nil {
+		createdAt, err := q.Page.Cursor.TimeValue()
+		if err != nil {
+			return nil, fmt.Errorf("postgresql: %w", err)
+		}
+		args = append(args, createdAt, q.Page.Cursor.ID)
+		conditions = append(conditions, fmt.Sprintf(`(created_at, id) > ($%d, $%d)`, len(args)-1, len(args)))
+	}
+
+	// One extra row tells us whether there is a next page
+	query := `SELECT ` + commentColumns + ` FROM comments WHERE ` + strings.Join(conditions, ` AND `) +
+		fmt.Sprintf(` ORDER BY created_at ASC, id ASC LIMIT $%d`, len(args)+1)
+	args = append(args, limit+1)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("postgresql: failed to query comments: %w", err)
+	}
+	defer rows.Close()
+
+	comments := make([]domain.Comment, 0, limit+1)
+
+	for rows.Next() {
+		comment, err := scanComment(rows)
+		if err != nil {
+			return nil, fmt.Errorf("postgresql: failed to scan comment row: %w", err)
+		}
+		comments = append(comments, *comment)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("postgresql: rows iteration error: %w", err)
+	}
+
+	return domain.NewPage(comments, limit, domain.CommentCursor), nil
+}
+
+// Implements the logic to update the body of a comment in PostgreSQL.
+func (r *CommentRepository) UpdateComment(ctx context.Context, comment *domain.Comment) error {
+	comment.UpdatedAt = time.Now()
+
+	query := `UPDATE comments SET body = $1, updated_at = $2 WHERE id = $3 AND ` + storyTenantCondition("comments", 4)
+	result, err := r.db.ExecContext(ctx, query, comment.Body, comment.UpdatedAt, comment.ID, domain.TenantFrom(ctx))
+
+	if err != nil {
+		return fmt.Errorf("postgresql: failed to update comment: %w", err)
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return domain.ErrCommentNotFound
+	}
+
+	return nil
+}
+
+// Implements the logic to delete a comment in PostgreSQL. Its replies are deleted by the foreign key cascade.
+func (r *CommentRepository) DeleteComment(ctx context.Context, id string) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM comments WHERE id = $1 AND `+storyTenantCondition("comments", 2), id, domain.TenantFrom(ctx))
+
+	if err != nil {
+		return fmt.Errorf("postgresql: failed to delete comment: %w", err)
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return domain.ErrCommentNotFound
+	}
+
+	return nil
+}
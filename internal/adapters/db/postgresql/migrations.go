@@ -0,0 +1,19 @@
+package postgresql
+
+import (
+	"embed"
+	"io/fs"
+)
+
+// Directory holding the PostgreSQL migrations, relative to the repository root.
+// Used by `migrate create` to write new migration files.
+const MigrationsDir = "internal/adapters/db/postgresql/migrations"
+
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// Returns the embedded PostgreSQL migrations.
+func Migrations() fs.FS {
+	migrations, _ := fs.Sub(migrationFiles, "migrations")
+	return migrations
+}
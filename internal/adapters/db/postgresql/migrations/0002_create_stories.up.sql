@@ -0,0 +1,29 @@
+CREATE TABLE IF NOT EXISTS stories (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    title VARCHAR(255) NOT NULL,
+    author VARCHAR(255) NOT NULL,
+    content TEXT NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+-- Keeps updated_at current on every update
+CREATE OR REPLACE FUNCTION update_updated_at_column()
+RETURNS TRIGGER AS $$
+BEGIN
+    NEW.updated_at = NOW();
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE TRIGGER update_stories_updated_at
+BEFORE UPDATE ON stories
+FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
+
+-- Back keyset pagination on (sort column, id) and the listing filters
+CREATE INDEX IF NOT EXISTS stories_created_at_id_idx ON stories (created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS stories_updated_at_id_idx ON stories (updated_at, id);
+CREATE INDEX IF NOT EXISTS stories_title_id_idx ON stories (title, id);
+CREATE INDEX IF NOT EXISTS stories_author_id_idx ON stories (author, id);
+CREATE INDEX IF NOT EXISTS stories_lower_author_idx ON stories (lower(author));
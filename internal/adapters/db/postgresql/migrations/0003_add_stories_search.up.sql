@@ -0,0 +1,8 @@
+-- Full-text search over title (weight A) and content (weight B)
+ALTER TABLE stories ADD COLUMN IF NOT EXISTS search_vector tsvector
+    GENERATED ALWAYS AS (
+        setweight(to_tsvector('english', coalesce(title, '')), 'A') ||
+        setweight(to_tsvector('english', coalesce(content, '')), 'B')
+    ) STORED;
+
+CREATE INDEX IF NOT EXISTS stories_search_vector_idx ON stories USING GIN (search_vector);
@@ -0,0 +1,11 @@
+-- Without deleted_at, soft-deleted rows would come back: remove them for good
+DELETE FROM stories WHERE deleted_at IS NOT NULL;
+DELETE FROM users WHERE deleted_at IS NOT NULL;
+
+DROP INDEX IF EXISTS users_deleted_at_idx;
+DROP INDEX IF EXISTS stories_deleted_at_idx;
+DROP INDEX IF EXISTS users_email_active_key;
+ALTER TABLE users ADD CONSTRAINT users_email_key UNIQUE (email);
+
+ALTER TABLE users DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE stories DROP COLUMN IF EXISTS deleted_at;
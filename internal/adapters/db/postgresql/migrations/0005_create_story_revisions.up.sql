@@ -0,0 +1,10 @@
+-- Previous versions of stories, written on every update
+CREATE TABLE IF NOT EXISTS story_revisions (
+    story_id UUID NOT NULL REFERENCES stories (id) ON DELETE CASCADE,
+    revision INTEGER NOT NULL,
+    title VARCHAR(255) NOT NULL,
+    author VARCHAR(255) NOT NULL,
+    content TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (story_id, revision)
+);
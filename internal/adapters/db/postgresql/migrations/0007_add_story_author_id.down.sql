@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS stories_author_ref_idx;
+ALTER TABLE story_revisions DROP COLUMN IF EXISTS author_id;
+ALTER TABLE stories DROP COLUMN IF EXISTS author_id;
@@ -0,0 +1,14 @@
+-- Link stories to the user who wrote them; users with stories cannot be removed
+ALTER TABLE stories ADD COLUMN IF NOT EXISTS author_id UUID REFERENCES users (id) ON DELETE RESTRICT;
+ALTER TABLE story_revisions ADD COLUMN IF NOT EXISTS author_id UUID;
+
+-- Backfill from the free-text author, only where exactly one active user has that name
+UPDATE stories s
+SET author_id = u.id, author = u.name
+FROM users u
+WHERE u.deleted_at IS NULL
+  AND lower(u.name) = lower(s.author)
+  AND s.author_id IS NULL
+  AND (SELECT count(*) FROM users d WHERE d.deleted_at IS NULL AND lower(d.name) = lower(s.author)) = 1;
+
+CREATE INDEX IF NOT EXISTS stories_author_ref_idx ON stories (author_id) WHERE author_id IS NOT NULL;
@@ -0,0 +1,31 @@
+-- External URLs subscribed to domain events
+CREATE TABLE IF NOT EXISTS webhooks (
+    id UUID PRIMARY KEY,
+    url TEXT NOT NULL,
+    events TEXT[] NOT NULL DEFAULT '{}', -- Empty for every event
+    secret TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Events sent, or to be sent, to a webhook, with the outcome of their latest attempt
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id UUID PRIMARY KEY,
+    webhook_id UUID NOT NULL REFERENCES webhooks (id) ON DELETE CASCADE,
+    event_type VARCHAR(50) NOT NULL,
+    payload TEXT NOT NULL, -- Kept as sent, since the signature covers its exact bytes
+    redelivery_of UUID REFERENCES webhook_deliveries (id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    attempts INTEGER NOT NULL DEFAULT 0,
+    status_code INTEGER,
+    last_error TEXT,
+    duration_ms BIGINT,
+    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    delivered_at TIMESTAMPTZ,
+    failed_at TIMESTAMPTZ -- Set once the delivery has run out of attempts
+);
+
+-- Back the claims of the dispatcher, the delivery log of each webhook and the purge of finished deliveries
+CREATE INDEX IF NOT EXISTS webhook_deliveries_pending_idx ON webhook_deliveries (next_attempt_at, created_at) WHERE delivered_at IS NULL AND failed_at IS NULL;
+CREATE INDEX IF NOT EXISTS webhook_deliveries_webhook_id_idx ON webhook_deliveries (webhook_id, created_at, id);
+CREATE INDEX IF NOT EXISTS webhook_deliveries_redelivery_of_idx ON webhook_deliveries (redelivery_of) WHERE redelivery_of IS NOT NULL;
+CREATE INDEX IF NOT EXISTS webhook_deliveries_created_at_idx ON webhook_deliveries (created_at) WHERE delivered_at IS NOT NULL OR failed_at IS NOT NULL;
@@ -0,0 +1,12 @@
+-- Hash chain of the audit log; the events recorded before it have empty hashes
+ALTER TABLE audit_events ADD COLUMN IF NOT EXISTS prev_hash TEXT NOT NULL DEFAULT '';
+ALTER TABLE audit_events ADD COLUMN IF NOT EXISTS hash TEXT NOT NULL DEFAULT '';
+
+-- Signed checkpoints of the chain. No foreign key: a checkpoint must outlive its event to reveal its removal
+CREATE TABLE IF NOT EXISTS audit_checkpoints (
+    id BIGSERIAL PRIMARY KEY,
+    event_id BIGINT NOT NULL,
+    hash TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    signature TEXT NOT NULL
+);
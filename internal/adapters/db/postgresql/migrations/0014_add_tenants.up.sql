@@ -0,0 +1,11 @@
+-- Every user and story belongs to a tenant; the existing ones to the default tenant
+ALTER TABLE users ADD COLUMN IF NOT EXISTS tenant_id VARCHAR(63) NOT NULL DEFAULT 'default';
+ALTER TABLE stories ADD COLUMN IF NOT EXISTS tenant_id VARCHAR(63) NOT NULL DEFAULT 'default';
+
+-- An email is only reserved within its tenant
+DROP INDEX IF EXISTS users_email_active_key;
+CREATE UNIQUE INDEX IF NOT EXISTS users_email_active_key ON users (tenant_id, email) WHERE deleted_at IS NULL;
+
+-- Back the listings, which are always scoped to a tenant
+CREATE INDEX IF NOT EXISTS users_tenant_created_at_id_idx ON users (tenant_id, created_at, id);
+CREATE INDEX IF NOT EXISTS stories_tenant_created_at_id_idx ON stories (tenant_id, created_at, id);
@@ -0,0 +1,6 @@
+-- Outbox entry whose event a delivery sends, NULL for the redeliveries requested through the API.
+-- No foreign key: delivered entries are purged before their deliveries
+ALTER TABLE webhook_deliveries ADD COLUMN IF NOT EXISTS outbox_entry_id BIGINT;
+
+-- An entry delivered again by the outbox relay does not queue a second delivery to the same webhook
+CREATE UNIQUE INDEX IF NOT EXISTS webhook_deliveries_outbox_entry_key ON webhook_deliveries (outbox_entry_id, webhook_id);
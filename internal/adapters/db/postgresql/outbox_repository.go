@@ -0,0 +1,235 @@
+package postgresql
+
+import (
+	"Gin/internal/core/domain"
+	"cmp"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// Implements the ports.OutboxDrivenPort interface for PostgreSQL.
+type OutboxRepository struct {
+	db DBTX
+}
+
+// Creates a new instance of OutboxRepository over a database or a transaction.
+func NewOutboxRepository(db DBTX) *OutboxRepository {
+	return &OutboxRepository{db: db}
+}
+
+// Columns selected for an outbox entry, in the order scanOutboxEntry reads them.
+const outboxColumns = `id, tenant_id, event_type, payload, created_at, attempts, last_error, next_attempt_at, delivered_at, failed_at`
+
+// Reads an outbox entry selected with outboxColumns.
+func scanOutboxEntry(row scanner) (*domain.OutboxEntry, error) {
+	entry := &domain.OutboxEntry{}
+	var payload []byte
+
+	err := row.Scan(&entry.ID, &entry.TenantID, &entry.EventType, &payload, &entry.CreatedAt, &entry.Attempts, &entry.LastError, &entry.NextAttemptAt, &entry.DeliveredAt, &entry.FailedAt)
+	entry.Payload = payload
+
+	return entry, err
+}
+
+// Returns the condition matching the outbox entries with the given status.
+func outboxStatusCondition(status domain.OutboxStatus) string {
+	switch status {
+	case domain.OutboxDelivered:
+		return `delivered_at IS NOT NULL`
+	case domain.OutboxFailed:
+		return `failed_at IS NOT NULL`
+	default:
+		return `delivered_at IS NULL AND failed_at IS NULL`
+	}
+}
+
+// Implements the logic to save outbox entries in PostgreSQL, in the tenant of the context, due immediately, with one multi-row INSERT per chunk.
+// The rows take their IDs from the sequence in the order of the VALUES, so the entries get increasing IDs in their order.
+func (r *OutboxRepository) SaveOutboxEntries(ctx context.Context, entries []*domain.OutboxEntry) error {
+	tenant := domain.TenantFrom(ctx)
+
+	const columns = 3
+	return inChunks(entries, columns, func(chunk []*domain.OutboxEntry) error {
+		args := make([]any, 0, len(chunk)*columns)
+		for _, entry := range chunk {
+			entry.TenantID = tenant
+			// Sent as text, as lib/pq would send bytes as bytea
+			args = append(args, entry.TenantID, entry.EventType, string(entry.Payload))
+		}
+
+		query := `INSERT INTO outbox (tenant_id, event_type, payload) VALUES ` + valuesRows(len(chunk), columns) + ` RETURNING id, created_at, next_attempt_at`
+		rows, err := r.db.QueryContext(ctx, query, args...)
+		if err != nil {
+			return fmt.Errorf("postgresql: failed to insert outbox entries: %w", err)
+		}
+		defer rows.Close()
+
+		inserted := make([]*domain.OutboxEntry, 0, len(chunk))
+		for rows.Next() {
+			entry := &domain.OutboxEntry{}
+			if err := rows.Scan(&entry.ID, &entry.CreatedAt, &entry.NextAttemptAt); err != nil {
+				return fmt.Errorf("postgresql: failed to scan inserted outbox entry: %w", err)
+			}
+			inserted = append(inserted, entry)
+		}
+
+		if err := rows.Err(); err != nil {
+			return fmt.Errorf("postgresql: failed to insert outbox entries: %w", err)
+		}
+
+		// RETURNING does not promise the order of the VALUES, which the IDs follow
+		slices.SortFunc(inserted, func(a, b *domain.OutboxEntry) int { return cmp.Compare(a.ID, b.ID) })
+
+		for i, entry := range chunk {
+			entry.ID, entry.CreatedAt, entry.NextAttemptAt = inserted[i].ID, inserted[i].CreatedAt, inserted[i].NextAttemptAt
+		}
+
+		return nil
+	})
+}
+
+// Implements the logic to claim the due outbox entries of every tenant in PostgreSQL.
+// Rows locked by a concurrent claim are skipped rather than waited for.
+func (r *OutboxRepository) ClaimOutboxEntries(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*domain.OutboxEntry, error) {
+	query := `
+		UPDATE outbox SET next_attempt_at = $2
+		WHERE id IN (
+			SELECT id FROM outbox
+			WHERE delivered_at IS NULL AND failed_at IS NULL AND next_attempt_at <= $1
+			ORDER BY id LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + outboxColumns
+
+	rows, err := r.db.QueryContext(ctx, query, now, now.Add(lease), limit)
+	if err != nil {
+		return nil, fmt.Errorf("postgresql: failed to claim outbox entries: %w", err)
+	}
+	defer rows.Close()
+
+	entries := make([]*domain.OutboxEntry, 0, limit)
+
+	for rows.Next() {
+		entry, err := scanOutboxEntry(rows)
+		if err != nil {
+			return nil, fmt.Errorf("postgresql: failed to scan outbox entry row: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("postgresql: rows iteration error: %w", err)
+	}
+
+	// RETURNING does not keep the order of the subquery
+	slices.SortFunc(entries, func(a, b *domain.OutboxEntry) int { return cmp.Compare(a.ID, b.ID) })
+
+	return entries, nil
+}
+
+// Implements the logic to save the delivery state of an outbox entry of the tenant in PostgreSQL.
+func (r *OutboxRepository) UpdateOutboxEntry(ctx context.Context, entry *domain.OutboxEntry) error {
+	query := `UPDATE outbox SET attempts = $1, last_error = $2, next_attempt_at = $3, delivered_at = $4, failed_at = $5 WHERE id = $6 AND tenant_id = $7`
+	result, err := r.db.ExecContext(ctx, query, entry.Attempts, entry.LastError, entry.NextAttemptAt, entry.DeliveredAt, entry.FailedAt, entry.ID, domain.TenantFrom(ctx))
+
+	if err != nil {
+		return fmt.Errorf("postgresql: failed to update outbox entry: %w", err)
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return domain.ErrOutboxEntryNotFound
+	}
+
+	return nil
+}
+
+// Implements the logic to find an outbox entry of the tenant in PostgreSQL.
+func (r *OutboxRepository) FindOutboxEntry(ctx context.Context, id int64) (*domain.OutboxEntry, error) {
+	query := `SELECT ` + outboxColumns + ` FROM outbox WHERE id = $1 AND tenant_id = $2`
+	entry, err := scanOutboxEntry(r.db.QueryRowContext(ctx, query, id, domain.TenantFrom(ctx)))
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil // Entry not found
+		}
+
+		return nil, fmt.Errorf("postgresql: failed to find outbox entry (scan error): %w", err)
+	}
+
+	return entry, nil
+}
+
+// Implements the logic to find a page of the outbox entries of the tenant with a status in PostgreSQL, oldest first.
+func (r *OutboxRepository) FindOutboxEntries(ctx context.Context, query domain.OutboxQuery) (*domain.Page[domain.OutboxEntry], error) {
+	limit := query.Page.NormalizedLimit()
+
+	statement := `SELECT ` + outboxColumns + ` FROM outbox WHERE tenant_id = $1 AND ` + outboxStatusCondition(query.Status)
+	args := []any{domain.TenantFrom(ctx)}
+
+	if query.Page.Cursor != nil {
+		after, err := query.Page.Cursor.OutboxValue()
+		if err != nil {
+			return nil, fmt.Errorf("postgresql: %w", err)
+		}
+		statement += ` AND id > $2`
+		args = append(args, after)
+	}
+
+	// One extra row tells us whether there is a next page
+	statement += fmt.Sprintf(` ORDER BY id LIMIT $%d`, len(args)+1)
+	args = append(args, limit+1)
+
+	rows, err := r.db.QueryContext(ctx, statement, args...)
+	if err != nil {
+		return nil, fmt.Errorf("postgresql: failed to query outbox entries: %w", err)
+	}
+	defer rows.Close()
+
+	entries := make([]domain.OutboxEntry, 0, limit+1)
+
+	for rows.Next() {
+		entry, err := scanOutboxEntry(rows)
+		if err != nil {
+			return nil, fmt.Errorf("postgresql: failed to scan outbox entry row: %w", err)
+		}
+		entries = append(entries, *entry)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("postgresql: rows iteration error: %w", err)
+	}
+
+	return domain.NewPage(entries, limit, domain.OutboxCursor), nil
+}
+
+// Implements the logic to count the pending and failed outbox entries of the tenant in PostgreSQL.
+func (r *OutboxRepository) CountOutboxEntries(ctx context.Context) (*domain.OutboxCounts, error) {
+	counts := &domain.OutboxCounts{}
+
+	query := `
+		SELECT
+			COUNT(*) FILTER (WHERE ` + outboxStatusCondition(domain.OutboxPending) + `),
+			COUNT(*) FILTER (WHERE ` + outboxStatusCondition(domain.OutboxFailed) + `)
+		FROM outbox WHERE tenant_id = $1`
+	if err := r.db.QueryRowContext(ctx, query, domain.TenantFrom(ctx)).Scan(&counts.Pending, &counts.Failed); err != nil {
+		return nil, fmt.Errorf("postgresql: failed to count outbox entries: %w", err)
+	}
+
+	return counts, nil
+}
+
+// Implements the logic to remove the outbox entries delivered before the given time in PostgreSQL, in every tenant.
+func (r *OutboxRepository) PurgeDeliveredOutboxEntries(ctx context.Context, deliveredBefore time.Time) (int64, error) {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM outbox WHERE delivered_at < $1`, deliveredBefore)
+
+	if err != nil {
+		return 0, fmt.Errorf("postgresql: failed to purge delivered outbox entries: %w", err)
+	}
+
+	return result.RowsAffected()
+}
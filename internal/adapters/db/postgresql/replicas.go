@@ -0,0 +1,192 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// How often the replicas are pinged when no interval is given.
+const DefaultReplicaCheckInterval = 5 * time.Second
+
+// Routes the queries of the repositories between the primary database and its read replicas.
+// Implements DBTX: SELECT statements go round-robin to the replicas whose last ping succeeded,
+// everything else goes to the primary. Once a context has written (see WithReadYourWrites),
+// its reads stay on the primary so they never miss their own writes on a lagging replica.
+type ReplicaRouter struct {
+	primary  *sql.DB
+	replicas []*replica
+	next     atomic.Uint64 // Round-robin position
+	stop     chan struct{}
+	done     sync.WaitGroup
+}
+
+// Represents a read replica and the outcome of its last ping.
+type replica struct {
+	db      *sql.DB
+	name    string // Position in DB_REPLICA_CONNECTION_STRINGS, used in logs
+	healthy atomic.Bool
+}
+
+// Creates a new instance of ReplicaRouter and starts pinging the replicas every checkInterval.
+// Replicas receive no reads until their first ping succeeds. Call Close to stop the checks.
+func NewReplicaRouter(primary *sql.DB, replicas []*sql.DB, checkInterval time.Duration) *ReplicaRouter {
+	if checkInterval <= 0 {
+		checkInterval = DefaultReplicaCheckInterval
+	}
+
+	router := &ReplicaRouter{primary: primary, stop: make(chan struct{})}
+
+	for i, db := range replicas {
+		router.replicas = append(router.replicas, &replica{db: db, name: fmt.Sprintf("replica %d", i+1)})
+	}
+
+	router.done.Add(1)
+	go router.monitor(checkInterval)
+
+	return router
+}
+
+// Implements the logic to run a statement on the primary, marking the context as having written.
+func (r *ReplicaRouter) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	markWritten(ctx)
+	return r.primary.ExecContext(ctx, query, args...)
+}
+
+// Implements the logic to run a query on a replica if it only reads, or on the primary otherwise.
+func (r *ReplicaRouter) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	return r.route(ctx, query).QueryContext(ctx, query, args...)
+}
+
+// Implements the logic to run a single-row query on a replica if it only reads, or on the primary otherwise.
+func (r *ReplicaRouter) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	return r.route(ctx, query).QueryRowContext(ctx, query, args...)
+}
+
+// Picks the database for a query: the next healthy replica for a read by a context that has not written yet,
+// the primary otherwise or when no replica is healthy.
+func (r *ReplicaRouter) route(ctx context.Context, query string) *sql.DB {
+	if !isReadQuery(query) {
+		markWritten(ctx) // e.g. INSERT ... RETURNING
+		return r.primary
+	}
+
+	if hasWritten(ctx) || len(r.replicas) == 0 {
+		return r.primary
+	}
+
+	start := r.next.Add(1)
+	for i := range r.replicas {
+		candidate := r.replicas[(start+uint64(i))%uint64(len(r.replicas))]
+		if candidate.healthy.Load() {
+			return candidate.db
+		}
+	}
+
+	return r.primary
+}
+
+// Reports whether a statement only reads. Anything that is not a plain SELECT counts as a write.
+func isReadQuery(query string) bool {
+	query = strings.TrimSpace(query)
+	return len(query) >= 6 && strings.EqualFold(query[:6], "SELECT")
+}
+
+// Pings every replica each interval until Close is called, logging the state of each replica
+// after the first check and whenever it goes down or comes back.
+func (r *ReplicaRouter) monitor(interval time.Duration) {
+	defer r.done.Done()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for first := true; ; first = false {
+		for _, replica := range r.replicas {
+			ctx, cancel := context.WithTimeout(context.Background(), interval)
+			err := replica.db.PingContext(ctx)
+			cancel()
+
+			healthy := err == nil
+			if replica.healthy.Swap(healthy) == healthy && !first {
+				continue
+			}
+
+			if healthy {
+				log.Printf("Database %s is healthy, routing reads to it", replica.name)
+			} else {
+				log.Printf("Database %s failed its health check, skipping it: %v", replica.name, err)
+			}
+		}
+
+		select {
+		case <-r.stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Represents the state of a read replica.
+type ReplicaStatus struct {
+	Name    string
+	Healthy bool // Outcome of the last health check
+	Stats   sql.DBStats
+}
+
+// Returns the state of every read replica, in configuration order.
+func (r *ReplicaRouter) Replicas() []ReplicaStatus {
+	statuses := make([]ReplicaStatus, 0, len(r.replicas))
+
+	for _, replica := range r.replicas {
+		statuses = append(statuses, ReplicaStatus{Name: replica.name, Healthy: replica.healthy.Load(), Stats: replica.db.Stats()})
+	}
+
+	return statuses
+}
+
+// Stops the health checks and closes the replica connections. The primary is left open.
+func (r *ReplicaRouter) Close() error {
+	close(r.stop)
+	r.done.Wait()
+
+	var firstErr error
+	for _, replica := range r.replicas {
+		if err := replica.db.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return firstErr
+}
+
+// Key of the read-your-writes session in a context.
+type sessionKey struct{}
+
+// Remembers whether the work of a context has written to the primary.
+type session struct {
+	wrote atomic.Bool
+}
+
+// Returns a context whose reads move to the primary after its first write, so a request
+// reads its own writes even while the replicas lag behind. Typically called once per request.
+func WithReadYourWrites(ctx context.Context) context.Context {
+	return context.WithValue(ctx, sessionKey{}, &session{})
+}
+
+// Records that the work of ctx has written, if it carries a session.
+func markWritten(ctx context.Context) {
+	if s, ok := ctx.Value(sessionKey{}).(*session); ok {
+		s.wrote.Store(true)
+	}
+}
+
+// Reports whether the work of ctx has written.
+func hasWritten(ctx context.Context) bool {
+	s, ok := ctx.Value(sessionKey{}).(*session)
+	return ok && s.wrote.Load()
+}
@@ -2,26 +2,29 @@ package postgresql
 
 import (
 	"Gin/internal/core/domain"
+	"context"
 	"database/sql"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
 // Implements the ports.StoryDrivenPort interface for PostgreSQL.
 type StoryRepository struct {
-	db *sql.DB
+	db DBTX
 }
 
-// Creates a new instance of StoryRepository.
-func NewStoryRepository(db *sql.DB) *StoryRepository {
+// Creates a new instance of StoryRepository over a database or a transaction.
+func NewStoryRepository(db DBTX) *StoryRepository {
 	return &StoryRepository{db: db}
 }
 
-// Implements the logic to save a story in PostgreSQL.
-func (r *StoryRepository) SaveStory(story *domain.Story) error {
+// Implements the logic to save a story in PostgreSQL, in the tenant of the context.
+func (r *StoryRepository) SaveStory(ctx context.Context, story *domain.Story) error {
 	// Generate a new UUID if no ID is provided (for new stories)
 	if story.ID == "" {
 		story.ID = uuid.New().String()
@@ -29,24 +32,80 @@ func (r *StoryRepository) SaveStory(story *domain.Story) error {
 
 	story.CreatedAt = time.Now()
 	story.UpdatedAt = time.Now()
+	story.Version = 1
+	story.TenantID = domain.TenantFrom(ctx)
 
-	query := `INSERT INTO stories (id, title, author, content, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6)`
-	_, err := r.db.Exec(query, story.ID, story.Title, story.Author, story.Content, story.CreatedAt, story.UpdatedAt)
+	query := `INSERT INTO stories (id, title, author, author_id, content, created_at, updated_at, version, tenant_id) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+	_, err := r.db.ExecContext(ctx, query, story.ID, story.Title, story.Author, story.AuthorID, story.Content, story.CreatedAt, story.UpdatedAt, story.Version, story.TenantID)
 
 	if err != nil {
 		return fmt.Errorf("postgresql: failed to insert story: %w", err)
 	}
 
-	return nil
+	return saveStoryTags(ctx, r.db, story.ID, story.Tags)
+}
+
+// Implements the logic to save new stories in PostgreSQL, in the tenant of the context.
+// The stories are inserted with one multi-row INSERT per chunk, and their tags with two statements in all.
+func (r *StoryRepository) SaveStories(ctx context.Context, stories []*domain.Story) error {
+	now := time.Now()
+	tenant := domain.TenantFrom(ctx)
+
+	for _, story := range stories {
+		if story.ID == "" {
+			story.ID = uuid.New().String()
+		}
+
+		story.CreatedAt = now
+		story.UpdatedAt = now
+		story.Version = 1
+		story.TenantID = tenant
+	}
+
+	const columns = 9
+	err := inChunks(stories, columns, func(chunk []*domain.Story) error {
+		args := make([]any, 0, len(chunk)*columns)
+		for _, story := range chunk {
+			args = append(args, story.ID, story.Title, story.Author, story.AuthorID, story.Content, story.CreatedAt, story.UpdatedAt, story.Version, story.TenantID)
+		}
+
+		query := `INSERT INTO stories (id, title, author, author_id, content, created_at, updated_at, version, tenant_id) VALUES ` + valuesRows(len(chunk), columns)
+		if _, err := r.db.ExecContext(ctx, query, args...); err != nil {
+			return fmt.Errorf("postgresql: failed to insert stories: %w", err)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return saveNewStoriesTags(ctx, r.db, stories)
 }
 
-// Implements the logic to find a story by ID in PostgreSQL.
-func (r *StoryRepository) FindStoryByID(id string) (*domain.Story, error) {
-	query := `SELECT id, title, author, content, created_at, updated_at FROM stories WHERE id = $1`
-	row := r.db.QueryRow(query, id)
+// Columns selected for a story, in the order scanStory reads them.
+var storyColumns = `id, title, author, author_id, content, created_at, updated_at, deleted_at, version, tenant_id, ` + tagsColumn(`stories.id`)
 
+// Abstracts *sql.Row and *sql.Rows.
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+// Reads a story selected with storyColumns, followed by any extra columns into extra.
+func scanStory(row scanner, extra ...any) (*domain.Story, error) {
 	story := &domain.Story{}
-	err := row.Scan(&story.ID, &story.Title, &story.Author, &story.Content, &story.CreatedAt, &story.UpdatedAt)
+	dest := append([]any{&story.ID, &story.Title, &story.Author, &story.AuthorID, &story.Content, &story.CreatedAt, &story.UpdatedAt, &story.DeletedAt, &story.Version, &story.TenantID, pq.Array(&story.Tags)}, extra...)
+
+	return story, row.Scan(dest...)
+}
+
+// Implements the logic to find a story by ID in PostgreSQL. Soft-deleted stories are not found.
+func (r *StoryRepository) FindStoryByID(ctx context.Context, id string) (*domain.Story, error) {
+	query := `SELECT ` + storyColumns + ` FROM stories WHERE id = $1 AND tenant_id = $2 AND deleted_at IS NULL`
+	row := r.db.QueryRowContext(ctx, query, id, domain.TenantFrom(ctx))
+
+	story, err := scanStory(row)
 
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -59,10 +118,30 @@ func (r *StoryRepository) FindStoryByID(id string) (*domain.Story, error) {
 	return story, nil
 }
 
-// Implements the logic to find all stories in PostgreSQL.
-func (r *StoryRepository) FindAllStories() ([]domain.Story, error) {
-	query := `SELECT id, title, author, content, created_at, updated_at FROM stories ORDER BY created_at DESC`
-	rows, err := r.db.Query(query)
+// Maps each whitelisted sort field to its column. Only these values are ever interpolated into SQL.
+var storySortColumns = map[domain.StorySortField]string{
+	domain.StorySortCreatedAt: "created_at",
+	domain.StorySortUpdatedAt: "updated_at",
+	domain.StorySortTitle:     "title",
+	domain.StorySortAuthor:    "author",
+}
+
+// Escapes the LIKE wildcards so user input only ever matches literally.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// Implements the logic to find a filtered, sorted page of stories in PostgreSQL.
+// Uses keyset pagination on (sort column, id) so deep pages stay as cheap as the first one.
+// Soft-deleted stories are excluded unless the query includes them.
+func (r *StoryRepository) FindAllStories(ctx context.Context, q domain.StoryQuery) (*domain.Page[domain.Story], error) {
+	limit := q.Page.NormalizedLimit()
+
+	// Fetch one extra row to know whether there is a next page
+	query, args, err := selectStories(domain.TenantFrom(ctx), q, limit+1)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
 
 	if err != nil {
 		return nil, fmt.Errorf("postgresql: failed to query all stories: %w", err)
@@ -70,11 +149,10 @@ func (r *StoryRepository) FindAllStories() ([]domain.Story, error) {
 
 	defer rows.Close()
 
-	stories := make([]domain.Story, 0)
+	stories := make([]domain.Story, 0, limit+1)
 
 	for rows.Next() {
-		story := &domain.Story{}
-		err := rows.Scan(&story.ID, &story.Title, &story.Author, &story.Content, &story.CreatedAt, &story.UpdatedAt)
+		story, err := scanStory(rows)
 
 		if err != nil {
 			return nil, fmt.Errorf("postgresql: failed to scan story row: %w", err)
@@ -87,15 +165,177 @@ func (r *StoryRepository) FindAllStories() ([]domain.Story, error) {
 		return nil, fmt.Errorf("postgresql: rows iteration error: %w", err)
 	}
 
-	return stories, nil
+	return domain.NewPage(stories, limit, q.Sort.CursorFor), nil
+}
+
+// Implements the logic to stream the stories of a listing in PostgreSQL, with the filters and sort of FindAllStories.
+// Rows are handed to fn one by one as they are read from the cursor; the pagination of the query is ignored.
+// Stops at the first error returned by fn and returns it unwrapped.
+func (r *StoryRepository) StreamStories(ctx context.Context, q domain.StoryQuery, fn func(*domain.Story) error) error {
+	q.Page.Cursor = nil
+
+	query, args, err := selectStories(domain.TenantFrom(ctx), q, 0)
+	if err != nil {
+		return err
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+
+	if err != nil {
+		return fmt.Errorf("postgresql: failed to query stories to stream: %w", err)
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		story, err := scanStory(rows)
+
+		if err != nil {
+			return fmt.Errorf("postgresql: failed to scan story row: %w", err)
+		}
+
+		if err := fn(story); err != nil {
+			return err
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("postgresql: rows iteration error: %w", err)
+	}
+
+	return nil
+}
+
+// Builds the SELECT of a story listing of a tenant: its filters, its sort and, when limit is positive, its page.
+func selectStories(tenant string, q domain.StoryQuery, limit int) (string, []any, error) {
+	column, ok := storySortColumns[q.Sort.Field]
+	if !ok {
+		return "", nil, fmt.Errorf("postgresql: unsupported story sort field %q", q.Sort.Field)
+	}
+
+	conditions := make([]string, 0, 8)
+	args := make([]any, 0, 9)
+
+	// Appends a condition, replacing each ? with the next positional placeholder
+	where := func(condition string, values ...any) {
+		for _, value := range values {
+			args = append(args, value)
+			condition = strings.Replace(condition, "?", fmt.Sprintf("$%d", len(args)), 1)
+		}
+		conditions = append(conditions, condition)
+	}
+
+	where("tenant_id = ?", tenant)
+
+	if !q.IncludeDeleted {
+		where("deleted_at IS NULL")
+	}
+
+	if q.Author != "" {
+		where("lower(author) = lower(?)", q.Author)
+	}
+
+	if q.AuthorID != "" {
+		where("author_id = ?", q.AuthorID)
+	}
+
+	if len(q.Tags) > 0 {
+		condition, values := tagsCondition(q)
+		where(condition, values...)
+	}
+
+	if q.Title != "" {
+		where("title ILIKE '%' || ? || '%'", likeEscaper.Replace(q.Title))
+	}
+
+	if q.CreatedAfter != nil {
+		where("created_at >= ?", *q.CreatedAfter)
+	}
+
+	if q.CreatedBefore != nil {
+		where("created_at < ?", *q.CreatedBefore)
+	}
+
+	if q.UpdatedAfter != nil {
+		where("updated_at >= ?", *q.UpdatedAfter)
+	}
+
+	if q.UpdatedBefore != nil {
+		where("updated_at < ?", *q.UpdatedBefore)
+	}
+
+	direction, comparison := "ASC", ">"
+	if q.Sort.Desc {
+		direction, comparison = "DESC", "<"
+	}
+
+	if q.Page.Cursor != nil {
+		value, err := q.Sort.CursorValue(*q.Page.Cursor)
+		if err != nil {
+			return "", nil, fmt.Errorf("postgresql: %w", err)
+		}
+		where(fmt.Sprintf("(%s, id) %s (?, ?)", column, comparison), value, q.Page.Cursor.ID)
+	}
+
+	query := `SELECT ` + storyColumns + ` FROM stories WHERE ` + strings.Join(conditions, " AND ")
+
+	query += fmt.Sprintf(" ORDER BY %s %s, id %s", column, direction, direction)
+
+	if limit > 0 {
+		args = append(args, limit)
+		query += fmt.Sprintf(" LIMIT $%d", len(args))
+	}
+
+	return query, args, nil
+}
+
+// Implements the full-text search over story titles and content in PostgreSQL.
+// Matches against the generated search_vector column (GIN indexed), where title terms weigh more than content terms.
+func (r *StoryRepository) SearchStories(ctx context.Context, q domain.StorySearchQuery) ([]domain.StorySearchResult, error) {
+	query := `
+		SELECT ` + storyColumns + `,
+			ts_rank(search_vector, query) AS rank,
+			ts_headline('english', content, query, 'StartSel=<mark>, StopSel=</mark>, MaxFragments=2, MaxWords=30, MinWords=10') AS snippet
+		FROM stories, websearch_to_tsquery('english', $1) AS query
+		WHERE search_vector @@ query AND tenant_id = $4 AND deleted_at IS NULL
+		ORDER BY rank DESC, created_at DESC, id DESC
+		LIMIT $2 OFFSET $3`
+	rows, err := r.db.QueryContext(ctx, query, q.Text, q.Limit, q.Offset, domain.TenantFrom(ctx))
+
+	if err != nil {
+		return nil, fmt.Errorf("postgresql: failed to search stories: %w", err)
+	}
+
+	defer rows.Close()
+
+	results := make([]domain.StorySearchResult, 0)
+
+	for rows.Next() {
+		result := domain.StorySearchResult{}
+		story, err := scanStory(rows, &result.Rank, &result.Snippet)
+
+		if err != nil {
+			return nil, fmt.Errorf("postgresql: failed to scan story search row: %w", err)
+		}
+
+		result.Story = *story
+		results = append(results, result)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("postgresql: rows iteration error: %w", err)
+	}
+
+	return results, nil
 }
 
-// Implements the logic to update a story in PostgreSQL.
-func (r *StoryRepository) UpdateStory(story *domain.Story) error {
+// Implements the logic to update a story in PostgreSQL. Soft-deleted stories cannot be updated.
+// The update only applies if the stored version still equals story.Version, which is then incremented.
+func (r *StoryRepository) UpdateStory(ctx context.Context, story *domain.Story) error {
 	story.UpdatedAt = time.Now() // Update the updated_at column
 
-	query := `UPDATE stories SET title = $1, author = $2, content = $3, updated_at = $4 WHERE id = $5`
-	result, err := r.db.Exec(query, story.Title, story.Author, story.Content, story.UpdatedAt, story.ID)
+	query := `UPDATE stories SET title = $1, author = $2, author_id = $3, content = $4, updated_at = $5, version = version + 1 WHERE id = $6 AND version = $7 AND tenant_id = $8 AND deleted_at IS NULL`
+	result, err := r.db.ExecContext(ctx, query, story.Title, story.Author, story.AuthorID, story.Content, story.UpdatedAt, story.ID, story.Version, domain.TenantFrom(ctx))
 
 	if err != nil {
 		return fmt.Errorf("postgresql: failed to update story: %w", err)
@@ -103,16 +343,17 @@ func (r *StoryRepository) UpdateStory(story *domain.Story) error {
 
 	rowsAffected, _ := result.RowsAffected()
 	if rowsAffected == 0 {
-		return errors.New("story not found or no changes made")
+		return versionMiss(ctx, r.db, "stories", story.ID, domain.ErrStoryNotFound)
 	}
 
-	return nil
+	story.Version++
+	return saveStoryTags(ctx, r.db, story.ID, story.Tags)
 }
 
-// Implements the logic to delete a story in PostgreSQL.
-func (r *StoryRepository) DeleteStory(id string) error {
-	query := `DELETE FROM stories WHERE id = $1`
-	result, err := r.db.Exec(query, id)
+// Implements the logic to soft-delete a story in PostgreSQL.
+func (r *StoryRepository) DeleteStory(ctx context.Context, id string) error {
+	query := `UPDATE stories SET deleted_at = NOW() WHERE id = $1 AND tenant_id = $2 AND deleted_at IS NULL`
+	result, err := r.db.ExecContext(ctx, query, id, domain.TenantFrom(ctx))
 
 	if err != nil {
 		return fmt.Errorf("postgresql: failed to delete story: %w", err)
@@ -120,8 +361,38 @@ func (r *StoryRepository) DeleteStory(id string) error {
 
 	rowsAffected, _ := result.RowsAffected()
 	if rowsAffected == 0 {
-		return errors.New("story not found for deletion")
+		return domain.ErrStoryNotFound
+	}
+
+	return nil
+}
+
+// Implements the logic to restore a soft-deleted story in PostgreSQL.
+func (r *StoryRepository) RestoreStory(ctx context.Context, id string) error {
+	query := `UPDATE stories SET deleted_at = NULL WHERE id = $1 AND tenant_id = $2 AND deleted_at IS NOT NULL`
+	result, err := r.db.ExecContext(ctx, query, id, domain.TenantFrom(ctx))
+
+	if err != nil {
+		return fmt.Errorf("postgresql: failed to restore story: %w", err)
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return domain.ErrStoryNotFound
 	}
 
 	return nil
 }
+
+// Implements the logic to permanently remove the stories soft-deleted before the given time in PostgreSQL.
+// The purge is maintenance of the whole deployment, so it covers every tenant.
+func (r *StoryRepository) PurgeDeletedStories(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	query := `DELETE FROM stories WHERE deleted_at < $1`
+	result, err := r.db.ExecContext(ctx, query, deletedBefore)
+
+	if err != nil {
+		return 0, fmt.Errorf("postgresql: failed to purge deleted stories: %w", err)
+	}
+
+	return result.RowsAffected()
+}
@@ -0,0 +1,128 @@
+package postgresql
+
+import (
+	"Gin/internal/core/domain"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// Implements the ports.StoryRevisionDrivenPort interface for PostgreSQL.
+type StoryRevisionRepository struct {
+	db DBTX
+}
+
+// Creates a new instance of StoryRevisionRepository over a database or a transaction.
+func NewStoryRevisionRepository(db DBTX) *StoryRevisionRepository {
+	return &StoryRevisionRepository{db: db}
+}
+
+// Columns selected for a story revision, in the order scanStoryRevision reads them.
+const storyRevisionColumns = `story_id, revision, title, author, author_id, content, created_at`
+
+// Reads a story revision selected with storyRevisionColumns.
+func scanStoryRevision(row scanner) (*domain.StoryRevision, error) {
+	revision := &domain.StoryRevision{}
+	err := row.Scan(&revision.StoryID, &revision.Revision, &revision.Title, &revision.Author, &revision.AuthorID, &revision.Content, &revision.CreatedAt)
+
+	return revision, err
+}
+
+// Implements the logic to save a story revision in PostgreSQL, numbered after the latest revision of the story.
+// Run it in a transaction with the story update: concurrent saves for the same story conflict on the primary key.
+// Returns domain.ErrStoryNotFound if the story is not one of the tenant.
+func (r *StoryRevisionRepository) SaveRevision(ctx context.Context, revision *domain.StoryRevision) error {
+	query := `
+		INSERT INTO story_revisions (story_id, revision, title, author, author_id, content)
+		SELECT $1, COALESCE(MAX(revision), 0) + 1, $2, $3, $4, $5 FROM story_revisions WHERE story_id = $1
+		HAVING EXISTS (SELECT 1 FROM stories WHERE id = $1 AND tenant_id = $6)
+		RETURNING revision, created_at`
+	err := r.db.QueryRowContext(ctx, query, revision.StoryID, revision.Title, revision.Author, revision.AuthorID, revision.Content, domain.TenantFrom(ctx)).
+		Scan(&revision.Revision, &revision.CreatedAt)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.ErrStoryNotFound
+	}
+
+	if err != nil {
+		return fmt.Errorf("postgresql: failed to insert story revision: %w", err)
+	}
+
+	return nil
+}
+
+// Implements the logic to find a revision of a story in PostgreSQL.
+func (r *StoryRevisionRepository) FindRevision(ctx context.Context, storyID string, revision int) (*domain.StoryRevision, error) {
+	query := `SELECT ` + storyRevisionColumns + ` FROM story_revisions WHERE story_id = $1 AND revision = $2 AND ` + storyTenantCondition("story_revisions", 3)
+	found, err := scanStoryRevision(r.db.QueryRowContext(ctx, query, storyID, revision, domain.TenantFrom(ctx)))
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil // Revision not found
+		}
+
+		return nil, fmt.Errorf("postgresql: failed to find story revision (scan error): %w", err)
+	}
+
+	return found, nil
+}
+
+// Implements the logic to find a page of the revisions of a story in PostgreSQL, newest first.
+func (r *StoryRevisionRepository) FindRevisions(ctx context.Context, storyID string, page domain.PageRequest) (*domain.Page[domain.StoryRevision], error) {
+	limit := page.NormalizedLimit()
+
+	query := `SELECT ` + storyRevisionColumns + ` FROM story_revisions WHERE story_id = $1 AND ` + storyTenantCondition("story_revisions", 2)
+	args := []any{storyID, domain.TenantFrom(ctx)}
+
+	if page.Cursor != nil {
+		before, err := page.Cursor.RevisionValue(storyID)
+		if err != nil {
+			return nil, fmt.Errorf("postgresql: %w", err)
+		}
+		query += ` AND revision < $3`
+		args = append(args, before)
+	}
+
+	// One extra row tells us whether there is a next page
+	query += fmt.Sprintf(` ORDER BY revision DESC LIMIT $%d`, len(args)+1)
+	args = append(args, limit+1)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+
+	if err != nil {
+		return nil, fmt.Errorf("postgresql: failed to query story revisions: %w", err)
+	}
+
+	defer rows.Close()
+
+	revisions := make([]domain.StoryRevision, 0, limit+1)
+
+	for rows.Next() {
+		revision, err := scanStoryRevision(rows)
+
+		if err != nil {
+			return nil, fmt.Errorf("postgresql: failed to scan story revision row: %w", err)
+		}
+
+		revisions = append(revisions, *revision)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("postgresql: rows iteration error: %w", err)
+	}
+
+	return domain.NewPage(revisions, limit, domain.StoryRevisionCursor), nil
+}
+
+// Implements the logic to find the number of the latest revision of a story in PostgreSQL.
+func (r *StoryRevisionRepository) LatestRevision(ctx context.Context, storyID string) (int, error) {
+	var latest int
+
+	query := `SELECT COALESCE(MAX(revision), 0) FROM story_revisions WHERE story_id = $1 AND ` + storyTenantCondition("story_revisions", 2)
+	if err := r.db.QueryRowContext(ctx, query, storyID, domain.TenantFrom(ctx)).Scan(&latest); err != nil {
+		return 0, fmt.Errorf("postgresql: failed to find latest story revision: %w", err)
+	}
+
+	return latest, nil
+}
@@ -0,0 +1,116 @@
+package postgresql
+
+import (
+	"Gin/internal/core/domain"
+	"context"
+	"fmt"
+
+	"github.com/lib/pq"
+)
+
+// Selects the tag names of the story whose ID is in the given column as an array, sorted by name.
+func tagsColumn(storyID string) string {
+	return `ARRAY(SELECT t.name FROM story_tags st JOIN tags t ON t.id = st.tag_id WHERE st.story_id = ` + storyID + ` ORDER BY t.name) AS tags`
+}
+
+// Replaces the tags of a story, creating the tags that do not exist yet.
+// Run it in the same transaction as the write of the story.
+func saveStoryTags(ctx context.Context, db DBTX, storyID string, tags []string) error {
+	if _, err := db.ExecContext(ctx, `DELETE FROM story_tags WHERE story_id = $1`, storyID); err != nil {
+		return fmt.Errorf("postgresql: failed to clear story tags: %w", err)
+	}
+
+	if len(tags) == 0 {
+		return nil
+	}
+
+	if _, err := db.ExecContext(ctx, `INSERT INTO tags (name) SELECT unnest($1::text[]) ON CONFLICT (name) DO NOTHING`, pq.Array(tags)); err != nil {
+		return fmt.Errorf("postgresql: failed to insert tags: %w", err)
+	}
+
+	if _, err := db.ExecContext(ctx, `INSERT INTO story_tags (story_id, tag_id) SELECT $1, id FROM tags WHERE name = ANY($2)`, storyID, pq.Array(tags)); err != nil {
+		return fmt.Errorf("postgresql: failed to insert story tags: %w", err)
+	}
+
+	return nil
+}
+
+// Sets the tags of stories that have none yet, creating the tags that do not exist yet.
+// Run it in the same transaction as the insert of the stories.
+func saveNewStoriesTags(ctx context.Context, db DBTX, stories []*domain.Story) error {
+	var storyIDs, names []string
+	for _, story := range stories {
+		for _, tag := range story.Tags {
+			storyIDs, names = append(storyIDs, story.ID), append(names, tag)
+		}
+	}
+
+	if len(names) == 0 {
+		return nil
+	}
+
+	if _, err := db.ExecContext(ctx, `INSERT INTO tags (name) SELECT DISTINCT unnest($1::text[]) ON CONFLICT (name) DO NOTHING`, pq.Array(names)); err != nil {
+		return fmt.Errorf("postgresql: failed to insert tags: %w", err)
+	}
+
+	query := `
+		INSERT INTO story_tags (story_id, tag_id)
+		SELECT s.story_id, t.id FROM unnest($1::uuid[], $2::text[]) AS s (story_id, name) JOIN tags t ON t.name = s.name`
+	if _, err := db.ExecContext(ctx, query, pq.Array(storyIDs), pq.Array(names)); err != nil {
+		return fmt.Errorf("postgresql: failed to insert story tags: %w", err)
+	}
+
+	return nil
+}
+
+// Returns the condition keeping the stories with any or all of the tags of the query, and its arguments.
+// Placeholders are written as ? for the where helper of FindAllStories.
+func tagsCondition(q domain.StoryQuery) (string, []any) {
+	condition := `id IN (SELECT st.story_id FROM story_tags st JOIN tags t ON t.id = st.tag_id WHERE t.name = ANY(?)`
+	args := []any{pq.Array(q.Tags)}
+
+	if q.TagMatch == domain.TagMatchAll {
+		condition += ` GROUP BY st.story_id HAVING count(*) = ?`
+		args = append(args, len(q.Tags))
+	}
+
+	return condition + `)`, args
+}
+
+// Implements the logic to find the tags used by the stories of the tenant in PostgreSQL, with the number of stories using each one.
+// Deleted stories are not counted, and tags without stories are left out. Most used tags first.
+func (r *StoryRepository) FindTags(ctx context.Context) ([]domain.TagUsage, error) {
+	query := `
+		SELECT t.name, count(*) AS stories
+		FROM tags t
+		JOIN story_tags st ON st.tag_id = t.id
+		JOIN stories s ON s.id = st.story_id
+		WHERE s.tenant_id = $1 AND s.deleted_at IS NULL
+		GROUP BY t.name
+		ORDER BY stories DESC, t.name ASC`
+	rows, err := r.db.QueryContext(ctx, query, domain.TenantFrom(ctx))
+
+	if err != nil {
+		return nil, fmt.Errorf("postgresql: failed to query tags: %w", err)
+	}
+
+	defer rows.Close()
+
+	tags := make([]domain.TagUsage, 0)
+
+	for rows.Next() {
+		var tag domain.TagUsage
+
+		if err := rows.Scan(&tag.Name, &tag.Stories); err != nil {
+			return nil, fmt.Errorf("postgresql: failed to scan tag row: %w", err)
+		}
+
+		tags = append(tags, tag)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("postgresql: rows iteration error: %w", err)
+	}
+
+	return tags, nil
+}
@@ -0,0 +1,10 @@
+package postgresql
+
+import "fmt"
+
+// Returns the condition keeping the rows of table whose story belongs to the tenant bound to placeholder $n.
+// Story revisions and comments have no tenant of their own: they belong to the tenant of their story.
+// table is always a constant, never user input.
+func storyTenantCondition(table string, n int) string {
+	return fmt.Sprintf(`EXISTS (SELECT 1 FROM stories WHERE stories.id = %s.story_id AND stories.tenant_id = $%d)`, table, n)
+}
@@ -0,0 +1,514 @@
+package postgresql
+
+import (
+	"Gin/internal/core/domain"
+	"Gin/internal/core/ports"
+	"Gin/internal/platform/migrate"
+	"context"
+	"crypto/ed25519"
+	"database/sql"
+	"errors"
+	"os"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// Connection string of the database the tests run against. The tests are skipped when it is not set.
+// They only add rows to tenants of their own, but the purges cover every tenant: do not point it at a database worth keeping.
+const testDatabaseEnv = "TEST_DATABASE_URL"
+
+// Opens the test database with every migration applied, closed when the test ends.
+func openTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+
+	dsn := os.Getenv(testDatabaseEnv)
+	if dsn == "" {
+		t.Skipf("%s is not set", testDatabaseEnv)
+	}
+
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		t.Fatalf("failed to open the test database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	migrator, err := migrate.New(db, Migrations(), migrate.Postgres)
+	if err != nil {
+		t.Fatalf("failed to load the migrations: %v", err)
+	}
+	if _, err := migrator.Up(context.Background()); err != nil {
+		t.Fatalf("failed to migrate the test database: %v", err)
+	}
+
+	return db
+}
+
+// Returns the contexts of two tenants of the test alone, so the rows of earlier runs stay out of the way.
+func testTenants() (acme, globex context.Context) {
+	suffix := uuid.New().String()[:8]
+
+	return domain.WithTenant(context.Background(), "acme-"+suffix), domain.WithTenant(context.Background(), "globex-"+suffix)
+}
+
+// Saves a user in the tenant of ctx.
+func saveTestUser(t *testing.T, repo *UserRepository, ctx context.Context, email string) *domain.User {
+	t.Helper()
+
+	user, err := domain.NewUser(email, "Jane")
+	if err != nil {
+		t.Fatalf("NewUser failed: %v", err)
+	}
+	user.ID = uuid.New().String()
+
+	if err := repo.SaveUser(ctx, user); err != nil {
+		t.Fatalf("SaveUser failed: %v", err)
+	}
+
+	return user
+}
+
+// Saves a story in the tenant of ctx with the given tags.
+func saveTestStory(t *testing.T, repo *StoryRepository, ctx context.Context, tags ...string) *domain.Story {
+	t.Helper()
+
+	story := &domain.Story{Title: "Tenant isolation", Author: "Jane", Content: "Kept apart from the other tenants", Tags: tags}
+	if err := repo.SaveStory(ctx, story); err != nil {
+		t.Fatalf("SaveStory failed: %v", err)
+	}
+
+	return story
+}
+
+// Reports how many of the rows of table with the given IDs are left, in every tenant.
+func countRows(t *testing.T, db *sql.DB, table string, ids ...string) int {
+	t.Helper()
+
+	var count int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM `+table+` WHERE id = ANY($1)`, pq.Array(ids)).Scan(&count); err != nil {
+		t.Fatalf("failed to count %s: %v", table, err)
+	}
+
+	return count
+}
+
+func TestStoriesAreScopedToTheTenant(t *testing.T) {
+	db := openTestDB(t)
+	acme, globex := testTenants()
+	repo := NewStoryRepository(db)
+
+	story := saveTestStory(t, repo, acme)
+
+	if found, err := repo.FindStoryByID(globex, story.ID); err != nil || found != nil {
+		t.Errorf("FindStoryByID in globex = %+v, %v, want not found", found, err)
+	}
+	if found, err := repo.FindStoryByID(acme, story.ID); err != nil || found == nil || found.TenantID != domain.TenantFrom(acme) {
+		t.Errorf("FindStoryByID in acme = %+v, %v, want the story", found, err)
+	}
+
+	page, err := repo.FindAllStories(globex, domain.StoryQuery{IncludeDeleted: true})
+	if err != nil {
+		t.Fatalf("FindAllStories failed: %v", err)
+	}
+	if len(page.Items) != 0 {
+		t.Errorf("globex lists %d stories, want none", len(page.Items))
+	}
+
+	streamed := 0
+	if err := repo.StreamStories(globex, domain.StoryQuery{}, func(*domain.Story) error { streamed++; return nil }); err != nil {
+		t.Fatalf("StreamStories failed: %v", err)
+	}
+	if streamed != 0 {
+		t.Errorf("globex streams %d stories, want none", streamed)
+	}
+
+	results, err := repo.SearchStories(globex, domain.StorySearchQuery{Text: "isolation", Limit: 10})
+	if err != nil {
+		t.Fatalf("SearchStories failed: %v", err)
+	}
+	if len(results) != 0 {
+		t.Errorf("globex finds %d stories, want none", len(results))
+	}
+
+	update := *story
+	update.Title = "Changed"
+	if err := repo.UpdateStory(globex, &update); !errors.Is(err, domain.ErrStoryNotFound) {
+		t.Errorf("UpdateStory in globex = %v, want %v", err, domain.ErrStoryNotFound)
+	}
+	if err := repo.DeleteStory(globex, story.ID); !errors.Is(err, domain.ErrStoryNotFound) {
+		t.Errorf("DeleteStory in globex = %v, want %v", err, domain.ErrStoryNotFound)
+	}
+
+	if err := repo.DeleteStory(acme, story.ID); err != nil {
+		t.Fatalf("DeleteStory failed: %v", err)
+	}
+	if err := repo.RestoreStory(globex, story.ID); !errors.Is(err, domain.ErrStoryNotFound) {
+		t.Errorf("RestoreStory in globex = %v, want %v", err, domain.ErrStoryNotFound)
+	}
+	if err := repo.RestoreStory(acme, story.ID); err != nil {
+		t.Errorf("RestoreStory in acme failed: %v", err)
+	}
+
+	found, err := repo.FindStoryByID(acme, story.ID)
+	if err != nil || found == nil || found.Title != story.Title || found.Version != story.Version {
+		t.Errorf("story = %+v, %v, want it unchanged", found, err)
+	}
+}
+
+func TestFindTagsIsScopedToTheTenant(t *testing.T) {
+	db := openTestDB(t)
+	acme, globex := testTenants()
+	repo := NewStoryRepository(db)
+
+	saveTestStory(t, repo, acme, "go")
+	saveTestStory(t, repo, globex, "go", "rust")
+
+	tags, err := repo.FindTags(acme)
+	if err != nil {
+		t.Fatalf("FindTags failed: %v", err)
+	}
+	if len(tags) != 1 || tags[0] != (domain.TagUsage{Name: "go", Stories: 1}) {
+		t.Errorf("acme tags = %+v, want go used once", tags)
+	}
+}
+
+func TestUsersAreScopedToTheTenant(t *testing.T) {
+	db := openTestDB(t)
+	acme, globex := testTenants()
+	repo := NewUserRepository(db)
+
+	user := saveTestUser(t, repo, acme, "jane@example.com")
+
+	// Emails are unique within a tenant only
+	saveTestUser(t, repo, globex, "jane@example.com")
+	other, _ := domain.NewUser("jane@example.com", "Other")
+	other.ID = uuid.New().String()
+	if err := repo.SaveUser(acme, other); err == nil {
+		t.Error("SaveUser of a taken email in acme succeeded")
+	}
+
+	if found, err := repo.FindUserByID(globex, user.ID); err != nil || found != nil {
+		t.Errorf("FindUserByID in globex = %+v, %v, want not found", found, err)
+	}
+
+	page, err := repo.FindAllUsers(globex, domain.UserQuery{IncludeDeleted: true})
+	if err != nil {
+		t.Fatalf("FindAllUsers failed: %v", err)
+	}
+	if len(page.Items) != 1 || page.Items[0].ID == user.ID {
+		t.Errorf("globex lists %+v, want its own user only", page.Items)
+	}
+
+	update := *user
+	update.Name = "Changed"
+	if err := repo.UpdateUser(globex, &update); !errors.Is(err, domain.ErrUserNotFound) {
+		t.Errorf("UpdateUser in globex = %v, want %v", err, domain.ErrUserNotFound)
+	}
+	if err := repo.DeleteUser(globex, user.ID); !errors.Is(err, domain.ErrUserNotFound) {
+		t.Errorf("DeleteUser in globex = %v, want %v", err, domain.ErrUserNotFound)
+	}
+
+	if err := repo.DeleteUser(acme, user.ID); err != nil {
+		t.Fatalf("DeleteUser failed: %v", err)
+	}
+	if err := repo.RestoreUser(globex, user.ID); !errors.Is(err, domain.ErrUserNotFound) {
+		t.Errorf("RestoreUser in globex = %v, want %v", err, domain.ErrUserNotFound)
+	}
+}
+
+func TestRevisionsAreScopedToTheTenantOfTheirStory(t *testing.T) {
+	db := openTestDB(t)
+	acme, globex := testTenants()
+	stories, repo := NewStoryRepository(db), NewStoryRevisionRepository(db)
+
+	story := saveTestStory(t, stories, acme)
+
+	if err := repo.SaveRevision(globex, domain.NewStoryRevision(story)); !errors.Is(err, domain.ErrStoryNotFound) {
+		t.Errorf("SaveRevision in globex = %v, want %v", err, domain.ErrStoryNotFound)
+	}
+	if err := repo.SaveRevision(acme, domain.NewStoryRevision(story)); err != nil {
+		t.Fatalf("SaveRevision failed: %v", err)
+	}
+
+	if found, err := repo.FindRevision(globex, story.ID, 1); err != nil || found != nil {
+		t.Errorf("FindRevision in globex = %+v, %v, want not found", found, err)
+	}
+
+	page, err := repo.FindRevisions(globex, story.ID, domain.PageRequest{})
+	if err != nil {
+		t.Fatalf("FindRevisions failed: %v", err)
+	}
+	if len(page.Items) != 0 {
+		t.Errorf("globex lists %d revisions, want none", len(page.Items))
+	}
+
+	if latest, err := repo.LatestRevision(globex, story.ID); err != nil || latest != 0 {
+		t.Errorf("LatestRevision in globex = %d, %v, want 0", latest, err)
+	}
+	if latest, err := repo.LatestRevision(acme, story.ID); err != nil || latest != 1 {
+		t.Errorf("LatestRevision in acme = %d, %v, want 1", latest, err)
+	}
+}
+
+func TestCommentsAreScopedToTheTenantOfTheirStory(t *testing.T) {
+	db := openTestDB(t)
+	acme, globex := testTenants()
+	stories, repo := NewStoryRepository(db), NewCommentRepository(db)
+
+	story := saveTestStory(t, stories, acme)
+	user := saveTestUser(t, NewUserRepository(db), acme, "jane@example.com")
+
+	if err := repo.SaveComment(globex, &domain.Comment{StoryID: story.ID, AuthorID: user.ID, Body: "Body"}); !errors.Is(err, domain.ErrStoryNotFound) {
+		t.Errorf("SaveComment in globex = %v, want %v", err, domain.ErrStoryNotFound)
+	}
+
+	comment := &domain.Comment{StoryID: story.ID, AuthorID: user.ID, Body: "Body"}
+	if err := repo.SaveComment(acme, comment); err != nil {
+		t.Fatalf("SaveComment failed: %v", err)
+	}
+
+	if found, err := repo.FindCommentByID(globex, comment.ID); err != nil || found != nil {
+		t.Errorf("FindCommentByID in globex = %+v, %v, want not found", found, err)
+	}
+
+	page, err := repo.FindComments(globex, domain.CommentQuery{StoryID: story.ID})
+	if err != nil {
+		t.Fatalf("FindComments failed: %v", err)
+	}
+	if len(page.Items) != 0 {
+		t.Errorf("globex lists %d comments, want none", len(page.Items))
+	}
+
+	update := *comment
+	update.Body = "Changed"
+	if err := repo.UpdateComment(globex, &update); !errors.Is(err, domain.ErrCommentNotFound) {
+		t.Errorf("UpdateComment in globex = %v, want %v", err, domain.ErrCommentNotFound)
+	}
+	if err := repo.DeleteComment(globex, comment.ID); !errors.Is(err, domain.ErrCommentNotFound) {
+		t.Errorf("DeleteComment in globex = %v, want %v", err, domain.ErrCommentNotFound)
+	}
+
+	if found, err := repo.FindCommentByID(acme, comment.ID); err != nil || found == nil || found.Body != comment.Body {
+		t.Errorf("comment of acme = %+v, %v, want it unchanged", found, err)
+	}
+}
+
+func TestPurgesCoverEveryTenant(t *testing.T) {
+	db := openTestDB(t)
+	acme, globex := testTenants()
+	stories, users := NewStoryRepository(db), NewUserRepository(db)
+
+	deletedStories := []string{saveTestStory(t, stories, acme).ID, saveTestStory(t, stories, globex).ID}
+	activeStory := saveTestStory(t, stories, globex)
+	deletedUsers := []string{saveTestUser(t, users, acme, "jane@example.com").ID, saveTestUser(t, users, globex, "jane@example.com").ID}
+
+	for i, ctx := range []context.Context{acme, globex} {
+		if err := stories.DeleteStory(ctx, deletedStories[i]); err != nil {
+			t.Fatalf("DeleteStory failed: %v", err)
+		}
+		if err := users.DeleteUser(ctx, deletedUsers[i]); err != nil {
+			t.Fatalf("DeleteUser failed: %v", err)
+		}
+	}
+
+	// The purge runs outside of any request, in the default tenant
+	before := time.Now().Add(time.Minute)
+	if _, err := stories.PurgeDeletedStories(context.Background(), before); err != nil {
+		t.Fatalf("PurgeDeletedStories failed: %v", err)
+	}
+	if _, err := users.PurgeDeletedUsers(context.Background(), before); err != nil {
+		t.Fatalf("PurgeDeletedUsers failed: %v", err)
+	}
+
+	if left := countRows(t, db, "stories", deletedStories...); left != 0 {
+		t.Errorf("%d deleted stories are left, want none", left)
+	}
+	if left := countRows(t, db, "users", deletedUsers...); left != 0 {
+		t.Errorf("%d deleted users are left, want none", left)
+	}
+	if left := countRows(t, db, "stories", activeStory.ID); left != 1 {
+		t.Error("the purge removed an active story")
+	}
+}
+
+func TestOutboxEntriesAreScopedToTheTenant(t *testing.T) {
+	db := openTestDB(t)
+	acme, globex := testTenants()
+	repo := NewOutboxRepository(db)
+
+	entry := &domain.OutboxEntry{EventType: domain.EventStoryDeleted, Payload: []byte(`{}`)}
+	if err := repo.SaveOutboxEntries(acme, []*domain.OutboxEntry{entry}); err != nil {
+		t.Fatalf("SaveOutboxEntries failed: %v", err)
+	}
+
+	if found, err := repo.FindOutboxEntry(globex, entry.ID); err != nil || found != nil {
+		t.Errorf("FindOutboxEntry in globex = %+v, %v, want not found", found, err)
+	}
+
+	page, err := repo.FindOutboxEntries(globex, domain.OutboxQuery{Status: domain.OutboxPending})
+	if err != nil {
+		t.Fatalf("FindOutboxEntries failed: %v", err)
+	}
+	if len(page.Items) != 0 {
+		t.Errorf("globex lists %d outbox entries, want none", len(page.Items))
+	}
+
+	if counts, err := repo.CountOutboxEntries(globex); err != nil || *counts != (domain.OutboxCounts{}) {
+		t.Errorf("CountOutboxEntries in globex = %+v, %v, want none", counts, err)
+	}
+	if counts, err := repo.CountOutboxEntries(acme); err != nil || counts.Pending != 1 {
+		t.Errorf("CountOutboxEntries in acme = %+v, %v, want one pending", counts, err)
+	}
+
+	if err := repo.UpdateOutboxEntry(globex, entry); !errors.Is(err, domain.ErrOutboxEntryNotFound) {
+		t.Errorf("UpdateOutboxEntry in globex = %v, want %v", err, domain.ErrOutboxEntryNotFound)
+	}
+
+	// The relay claims the entries of every tenant, each with the tenant it is delivered in
+	claimed, err := repo.ClaimOutboxEntries(context.Background(), time.Now().Add(time.Minute), time.Minute, 1000)
+	if err != nil {
+		t.Fatalf("ClaimOutboxEntries failed: %v", err)
+	}
+	i := slices.IndexFunc(claimed, func(c *domain.OutboxEntry) bool { return c.ID == entry.ID })
+	if i < 0 || claimed[i].TenantID != domain.TenantFrom(acme) {
+		t.Errorf("claimed %+v, want the entry of acme", claimed)
+	}
+}
+
+func TestWebhooksAreScopedToTheTenant(t *testing.T) {
+	db := openTestDB(t)
+	acme, globex := testTenants()
+	repo := NewWebhookRepository(db)
+
+	webhook := &domain.Webhook{URL: "https://example.com", Secret: "0123456789abcdef"}
+	if err := repo.SaveWebhook(acme, webhook); err != nil {
+		t.Fatalf("SaveWebhook failed: %v", err)
+	}
+
+	if found, err := repo.FindWebhookByID(globex, webhook.ID); err != nil || found != nil {
+		t.Errorf("FindWebhookByID in globex = %+v, %v, want not found", found, err)
+	}
+	if webhooks, err := repo.FindWebhooks(globex); err != nil || len(webhooks) != 0 {
+		t.Errorf("FindWebhooks in globex = %+v, %v, want none", webhooks, err)
+	}
+	if err := repo.DeleteWebhook(globex, webhook.ID); !errors.Is(err, domain.ErrWebhookNotFound) {
+		t.Errorf("DeleteWebhook in globex = %v, want %v", err, domain.ErrWebhookNotFound)
+	}
+
+	// A delivery can only be queued in the tenant of its webhook
+	delivery, err := domain.NewWebhookDelivery(webhook, 1, domain.StoryDeleted{StoryID: uuid.New().String()}, time.Now())
+	if err != nil {
+		t.Fatalf("NewWebhookDelivery failed: %v", err)
+	}
+	if err := repo.SaveWebhookDeliveries(globex, []*domain.WebhookDelivery{delivery}); !errors.Is(err, domain.ErrWebhookNotFound) {
+		t.Errorf("SaveWebhookDeliveries in globex = %v, want %v", err, domain.ErrWebhookNotFound)
+	}
+	if err := repo.SaveWebhookDeliveries(acme, []*domain.WebhookDelivery{delivery}); err != nil {
+		t.Fatalf("SaveWebhookDeliveries failed: %v", err)
+	}
+
+	if found, err := repo.FindWebhookDelivery(globex, webhook.ID, delivery.ID); err != nil || found != nil {
+		t.Errorf("FindWebhookDelivery in globex = %+v, %v, want not found", found, err)
+	}
+
+	page, err := repo.FindWebhookDeliveries(globex, webhook.ID, domain.PageRequest{})
+	if err != nil {
+		t.Fatalf("FindWebhookDeliveries failed: %v", err)
+	}
+	if len(page.Items) != 0 {
+		t.Errorf("globex lists %d deliveries, want none", len(page.Items))
+	}
+}
+
+// Appends an audit event to the chain of the tenant of ctx, the way the services do.
+func appendTestAuditEvent(t *testing.T, uow *UnitOfWork, ctx context.Context) *domain.AuditEvent {
+	t.Helper()
+
+	event, err := domain.NewAuditEvent(ctx, domain.AuditCreate, domain.AuditUser, uuid.New().String(), nil, map[string]any{"name": "Jane"})
+	if err != nil {
+		t.Fatalf("NewAuditEvent failed: %v", err)
+	}
+
+	err = uow.Execute(ctx, func(ctx context.Context, repos ports.Repositories) error {
+		prevHash, err := repos.Audit.LockAuditTail(ctx)
+		if err != nil {
+			return err
+		}
+
+		event.Chain(prevHash)
+		return repos.Audit.SaveAuditEvents(ctx, []*domain.AuditEvent{event})
+	})
+	if err != nil {
+		t.Fatalf("failed to append the audit event: %v", err)
+	}
+
+	return event
+}
+
+func TestEachTenantHasItsOwnAuditChain(t *testing.T) {
+	db := openTestDB(t)
+	acme, globex := testTenants()
+	repo, uow := NewAuditRepository(db), NewUnitOfWork(db)
+
+	first := appendTestAuditEvent(t, uow, acme)
+	second := appendTestAuditEvent(t, uow, acme)
+	other := appendTestAuditEvent(t, uow, globex)
+
+	if first.PrevHash != "" || second.PrevHash != first.Hash {
+		t.Errorf("chain of acme = %+v, %+v, want the second event linked to the first", first, second)
+	}
+	if other.PrevHash != "" {
+		t.Errorf("prev_hash of the first event of globex = %q, want it to start its own chain", other.PrevHash)
+	}
+
+	if last, err := repo.FindLastAuditEvent(acme); err != nil || last == nil || last.ID != second.ID {
+		t.Errorf("FindLastAuditEvent in acme = %+v, %v, want event %d", last, err, second.ID)
+	}
+
+	var streamed []int64
+	err := repo.StreamAuditEvents(globex, func(event *domain.AuditEvent) error {
+		streamed = append(streamed, event.ID)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("StreamAuditEvents failed: %v", err)
+	}
+	if !slices.Equal(streamed, []int64{other.ID}) {
+		t.Errorf("globex streams events %v, want %d only", streamed, other.ID)
+	}
+
+	page, err := repo.FindAuditEvents(globex, domain.AuditQuery{})
+	if err != nil {
+		t.Fatalf("FindAuditEvents failed: %v", err)
+	}
+	if len(page.Items) != 1 || page.Items[0].ID != other.ID {
+		t.Errorf("globex lists %+v, want its own event only", page.Items)
+	}
+
+	key := ed25519.NewKeyFromSeed(make([]byte, ed25519.SeedSize))
+	if err := repo.SaveAuditCheckpoint(acme, domain.NewAuditCheckpoint(second, key)); err != nil {
+		t.Fatalf("SaveAuditCheckpoint failed: %v", err)
+	}
+
+	if checkpoints, err := repo.FindAuditCheckpoints(globex); err != nil || len(checkpoints) != 0 {
+		t.Errorf("FindAuditCheckpoints in globex = %+v, %v, want none", checkpoints, err)
+	}
+	checkpoints, err := repo.FindAuditCheckpoints(acme)
+	if err != nil {
+		t.Fatalf("FindAuditCheckpoints failed: %v", err)
+	}
+	if len(checkpoints) != 1 || checkpoints[0].Tenant() != domain.TenantFrom(acme) || !checkpoints[0].VerifySignature(key.Public().(ed25519.PublicKey)) {
+		t.Errorf("checkpoints of acme = %+v, want the signed one", checkpoints)
+	}
+
+	tenants, err := repo.FindAuditTenants(context.Background())
+	if err != nil {
+		t.Fatalf("FindAuditTenants failed: %v", err)
+	}
+	if !slices.Contains(tenants, domain.TenantFrom(acme)) || !slices.Contains(tenants, domain.TenantFrom(globex)) {
+		t.Errorf("tenants = %v, want acme and globex among them", tenants)
+	}
+}
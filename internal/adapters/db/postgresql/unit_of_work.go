@@ -0,0 +1,99 @@
+package postgresql
+
+import (
+	"Gin/internal/core/ports"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// Number of attempts made for a transaction that keeps failing to serialize.
+const maxTxAttempts = 3
+
+// Abstracts *sql.DB and *sql.Tx so repositories can run inside or outside a transaction.
+type DBTX interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// Implements the ports.UnitOfWork interface with serializable PostgreSQL transactions.
+type UnitOfWork struct {
+	db *sql.DB
+}
+
+// Creates a new instance of UnitOfWork.
+func NewUnitOfWork(db *sql.DB) *UnitOfWork {
+	return &UnitOfWork{db: db}
+}
+
+// Implements the logic to run fn in a serializable transaction, retrying on serialization failures and deadlocks.
+func (u *UnitOfWork) Execute(ctx context.Context, fn func(ctx context.Context, repos ports.Repositories) error) error {
+	var err error
+
+	for attempt := 1; attempt <= maxTxAttempts; attempt++ {
+		if err = u.executeOnce(ctx, fn); err == nil || !isRetryable(err) {
+			return err
+		}
+
+		// Back off a little before retrying so the conflicting transaction can finish
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Duration(attempt*attempt) * 10 * time.Millisecond):
+		}
+	}
+
+	return err
+}
+
+// Runs fn in a single transaction attempt.
+func (u *UnitOfWork) executeOnce(ctx context.Context, fn func(ctx context.Context, repos ports.Repositories) error) error {
+	tx, err := u.db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelSerializable})
+	if err != nil {
+		return fmt.Errorf("postgresql: failed to begin transaction: %w", err)
+	}
+
+	repos := ports.Repositories{
+		Stories:        NewStoryRepository(tx),
+		StoryRevisions: NewStoryRevisionRepository(tx),
+		Users:          NewUserRepository(tx),
+		Comments:       NewCommentRepository(tx),
+		Outbox:         NewOutboxRepository(tx),
+		Audit:          NewAuditRepository(tx),
+	}
+
+	if err := fn(ctx, repos); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("postgresql: failed to commit transaction: %w", err)
+	}
+
+	// Transactions run on the primary, so later reads must not go to a replica that may lag behind
+	markWritten(ctx)
+
+	return nil
+}
+
+// Reports whether the error is a serialization failure or a deadlock, after which the transaction can be retried.
+func isRetryable(err error) bool {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		return pqErr.Code == "40001" || pqErr.Code == "40P01"
+	}
+
+	return false
+}
+
+// Reports whether the error is a unique constraint violation.
+func isUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23505"
+}
@@ -2,48 +2,63 @@ package postgresql
 
 import (
 	"Gin/internal/core/domain"
+	"context"
 	"database/sql"
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	_ "github.com/lib/pq"
 )
 
 // Implements the ports.UserDrivenPort interface for PostgreSQL.
 type UserRepository struct {
-	db *sql.DB
+	db DBTX
 }
 
-// Creates a new instance of UserRepository.
-func NewUserRepository(db *sql.DB) *UserRepository {
+// Creates a new instance of UserRepository over a database or a transaction.
+func NewUserRepository(db DBTX) *UserRepository {
 	return &UserRepository{db: db}
 }
 
-// Implements the logic to save a user to PostgreSQL.
-func (r *UserRepository) SaveUser(user *domain.User) error {
+// Columns selected for a user, in the order scanUser reads them.
+const userColumns = `id, email, name, created_at, updated_at, deleted_at, version, tenant_id`
+
+// Reads a user selected with userColumns.
+func scanUser(row scanner) (*domain.User, error) {
+	user := &domain.User{}
+	// Direct scan into time.Time for TIMESTAMP WITH TIME ZONE columns
+	err := row.Scan(&user.ID, &user.Email, &user.Name, &user.CreatedAt, &user.UpdatedAt, &user.DeletedAt, &user.Version, &user.TenantID)
+
+	return user, err
+}
+
+// Implements the logic to save a user to PostgreSQL, in the tenant of the context.
+func (r *UserRepository) SaveUser(ctx context.Context, user *domain.User) error {
+	user.TenantID = domain.TenantFrom(ctx)
+
 	// PostgreSQL uses $1, $2, etc., for placeholders instead of ?.
 	// Also, TIMESTAMPTZ (with timezone) is a common type.
-	query := `INSERT INTO users (id, email, name, created_at, updated_at) VALUES ($1, $2, $3, $4, $5)`
+	query := `INSERT INTO users (id, email, name, created_at, updated_at, version, tenant_id) VALUES ($1, $2, $3, $4, $5, $6, $7)`
 
 	// PostgreSQL's `pq` driver and `database/sql` can often handle `time.Time` directly
 	// without needing to convert to string first, assuming your DB column is `TIMESTAMP WITH TIME ZONE`.
 	// However, if using `TEXT` columns for timestamps, you'd still need util.FormatTimeToString.
 	// For standard TIMESTAMP WITH TIME ZONE in Postgres, direct time.Time is preferred.
-	_, err := r.db.Exec(query, user.ID, user.Email, user.Name, user.CreatedAt, user.UpdatedAt)
+	_, err := r.db.ExecContext(ctx, query, user.ID, user.Email, user.Name, user.CreatedAt, user.UpdatedAt, user.Version, user.TenantID)
 	if err != nil {
 		return fmt.Errorf("postgresql: failed to insert user: %w", err)
 	}
 	return nil
 }
 
-// Implements the logic to find a user by ID in PostgreSQL.
-func (r *UserRepository) FindUserByID(id string) (*domain.User, error) {
-	query := `SELECT id, email, name, created_at, updated_at FROM users WHERE id = $1` // Placeholder $1
-	row := r.db.QueryRow(query, id)
+// Implements the logic to find a user by ID in PostgreSQL. Soft-deleted users are not found.
+func (r *UserRepository) FindUserByID(ctx context.Context, id string) (*domain.User, error) {
+	query := `SELECT ` + userColumns + ` FROM users WHERE id = $1 AND tenant_id = $2 AND deleted_at IS NULL` // Placeholders $1 and $2
+	row := r.db.QueryRowContext(ctx, query, id, domain.TenantFrom(ctx))
 
-	user := &domain.User{}
-	// Direct scan into time.Time for TIMESTAMP WITH TIME ZONE columns
-	err := row.Scan(&user.ID, &user.Email, &user.Name, &user.CreatedAt, &user.UpdatedAt)
+	user, err := scanUser(row)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, nil // User not found
@@ -53,21 +68,28 @@ func (r *UserRepository) FindUserByID(id string) (*domain.User, error) {
 	return user, nil
 }
 
-// Implements the logic to find all users in PostgreSQL.
-func (r *UserRepository) FindAllUsers() ([]domain.User, error) {
-	query := `SELECT id, email, name, created_at, updated_at FROM users`
-	rows, err := r.db.Query(query)
+// Implements the logic to find a page of users in PostgreSQL.
+// Uses keyset pagination on (created_at, id), newest users first.
+// Soft-deleted users are left out unless the query includes them.
+func (r *UserRepository) FindAllUsers(ctx context.Context, q domain.UserQuery) (*domain.Page[domain.User], error) {
+	limit := q.Page.NormalizedLimit()
+
+	// One extra row tells us whether there is a next page
+	query, args, err := selectUsers(domain.TenantFrom(ctx), q, limit+1)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("postgresql: failed to query all users: %w", err)
 	}
 	defer rows.Close()
 
-	users := make([]domain.User, 0)
+	users := make([]domain.User, 0, limit+1)
 
 	for rows.Next() {
-		user := &domain.User{}
-		// Direct scan into time.Time
-		err := rows.Scan(&user.ID, &user.Email, &user.Name, &user.CreatedAt, &user.UpdatedAt)
+		user, err := scanUser(rows)
 		if err != nil {
 			return nil, fmt.Errorf("postgresql: failed to scan user row: %w", err)
 		}
@@ -77,35 +99,136 @@ func (r *UserRepository) FindAllUsers() ([]domain.User, error) {
 	if err = rows.Err(); err != nil {
 		return nil, fmt.Errorf("postgresql: rows iteration error: %w", err)
 	}
-	return users, nil
+	return domain.NewPage(users, limit, userCursor), nil
 }
 
-// Implements the logic to update an existing user in PostgreSQL.
-func (r *UserRepository) UpdateUser(user *domain.User) error {
-	query := `UPDATE users SET email = $1, name = $2, updated_at = $3 WHERE id = $4` // Placeholders $1, $2, $3, $4
-	result, err := r.db.Exec(query, user.Email, user.Name, user.UpdatedAt, user.ID)  // Direct time.Time
+// Implements the logic to stream users in PostgreSQL, newest first like FindAllUsers.
+// Rows are handed to fn one by one as they are read from the cursor; the pagination of the query is ignored.
+// Stops at the first error returned by fn and returns it unwrapped.
+func (r *UserRepository) StreamUsers(ctx context.Context, q domain.UserQuery, fn func(*domain.User) error) error {
+	q.Page.Cursor = nil
+
+	query, args, err := selectUsers(domain.TenantFrom(ctx), q, 0)
+	if err != nil {
+		return err
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("postgresql: failed to query users to stream: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		user, err := scanUser(rows)
+		if err != nil {
+			return fmt.Errorf("postgresql: failed to scan user row: %w", err)
+		}
+
+		if err := fn(user); err != nil {
+			return err
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("postgresql: rows iteration error: %w", err)
+	}
+	return nil
+}
+
+// Builds the SELECT of a user listing of a tenant: its filters, its order and, when limit is positive, its page.
+func selectUsers(tenant string, q domain.UserQuery, limit int) (string, []any, error) {
+	conditions := []string{`tenant_id = $1`}
+	args := []any{tenant}
+
+	if !q.IncludeDeleted {
+		conditions = append(conditions, `deleted_at IS NULL`)
+	}
+
+	if q.Page.Cursor != nil {
+		createdAt, err := q.Page.Cursor.TimeValue()
+		if err != nil {
+			return "", nil, fmt.Errorf("postgresql: %w", err)
+		}
+		conditions = append(conditions, `(created_at, id) < ($2, $3)`)
+		args = append(args, createdAt, q.Page.Cursor.ID)
+	}
+
+	query := `SELECT ` + userColumns + ` FROM users WHERE ` + strings.Join(conditions, ` AND `) + ` ORDER BY created_at DESC, id DESC`
+
+	if limit > 0 {
+		args = append(args, limit)
+		query += fmt.Sprintf(` LIMIT $%d`, len(args))
+	}
+
+	return query, args, nil
+}
+
+// Returns the pagination cursor pointing at the given user.
+func userCursor(user domain.User) domain.Cursor {
+	return domain.NewTimeCursor("", user.CreatedAt, user.ID)
+}
+
+// Implements the logic to update an existing user in PostgreSQL. Soft-deleted users cannot be updated.
+// The update only applies if the stored version still equals user.Version, which is then incremented.
+func (r *UserRepository) UpdateUser(ctx context.Context, user *domain.User) error {
+	query := `UPDATE users SET email = $1, name = $2, updated_at = $3, version = version + 1 WHERE id = $4 AND version = $5 AND tenant_id = $6 AND deleted_at IS NULL` // Placeholders $1 to $6
+	result, err := r.db.ExecContext(ctx, query, user.Email, user.Name, user.UpdatedAt, user.ID, user.Version, domain.TenantFrom(ctx))                                  // Direct time.Time
 	if err != nil {
 		return fmt.Errorf("postgresql: failed to update user: %w", err)
 	}
 
 	rowsAffected, _ := result.RowsAffected()
 	if rowsAffected == 0 {
-		return errors.New("user not found or no changes made")
+		return versionMiss(ctx, r.db, "users", user.ID, domain.ErrUserNotFound)
 	}
+
+	user.Version++
 	return nil
 }
 
-// Implements the logic to delete a user from PostgreSQL.
-func (r *UserRepository) DeleteUser(id string) error {
-	query := `DELETE FROM users WHERE id = $1` // Placeholder $1
-	result, err := r.db.Exec(query, id)
+// Implements the logic to soft-delete a user in PostgreSQL.
+func (r *UserRepository) DeleteUser(ctx context.Context, id string) error {
+	query := `UPDATE users SET deleted_at = NOW() WHERE id = $1 AND tenant_id = $2 AND deleted_at IS NULL` // Placeholders $1 and $2
+	result, err := r.db.ExecContext(ctx, query, id, domain.TenantFrom(ctx))
 	if err != nil {
 		return fmt.Errorf("postgresql: failed to delete user: %w", err)
 	}
 
 	rowsAffected, _ := result.RowsAffected()
 	if rowsAffected == 0 {
-		return errors.New("user not found for deletion")
+		return domain.ErrUserNotFound
 	}
 	return nil
 }
+
+// Implements the logic to restore a soft-deleted user in PostgreSQL.
+// Returns domain.ErrEmailInUse if an active user took the email in the meantime.
+func (r *UserRepository) RestoreUser(ctx context.Context, id string) error {
+	query := `UPDATE users SET deleted_at = NULL WHERE id = $1 AND tenant_id = $2 AND deleted_at IS NOT NULL`
+	result, err := r.db.ExecContext(ctx, query, id, domain.TenantFrom(ctx))
+	if err != nil {
+		if isUniqueViolation(err) {
+			return domain.ErrEmailInUse
+		}
+		return fmt.Errorf("postgresql: failed to restore user: %w", err)
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return domain.ErrUserNotFound
+	}
+	return nil
+}
+
+// Implements the logic to permanently remove the users soft-deleted before the given time in PostgreSQL.
+// The purge is maintenance of the whole deployment, so it covers every tenant.
+func (r *UserRepository) PurgeDeletedUsers(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	query := `DELETE FROM users WHERE deleted_at < $1 AND NOT EXISTS (SELECT 1 FROM stories WHERE stories.author_id = users.id)`
+	result, err := r.db.ExecContext(ctx, query, deletedBefore)
+	if err != nil {
+		return 0, fmt.Errorf("postgresql: failed to purge deleted users: %w", err)
+	}
+
+	return result.RowsAffected()
+}
@@ -0,0 +1,331 @@
+package postgresql
+
+import (
+	"Gin/internal/core/domain"
+	"cmp"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// Implements the ports.WebhookDrivenPort interface for PostgreSQL.
+type WebhookRepository struct {
+	db DBTX
+}
+
+// Creates a new instance of WebhookRepository over a database or a transaction.
+func NewWebhookRepository(db DBTX) *WebhookRepository {
+	return &WebhookRepository{db: db}
+}
+
+// Columns selected for a webhook, in the order scanWebhook reads them.
+const webhookColumns = `id, tenant_id, url, events, secret, created_at`
+
+// Columns selected for a webhook delivery, in the order scanWebhookDelivery reads them.
+const webhookDeliveryColumns = `id, tenant_id, webhook_id, outbox_entry_id, event_type, payload, redelivery_of, created_at, attempts, status_code, last_error, duration_ms, next_attempt_at, delivered_at, failed_at`
+
+// Reads a webhook selected with webhookColumns.
+func scanWebhook(row scanner) (*domain.Webhook, error) {
+	webhook := &domain.Webhook{}
+	var events []string
+
+	if err := row.Scan(&webhook.ID, &webhook.TenantID, &webhook.URL, pq.Array(&events), &webhook.Secret, &webhook.CreatedAt); err != nil {
+		return nil, err
+	}
+
+	webhook.Events = make([]domain.EventType, 0, len(events))
+	for _, event := range events {
+		webhook.Events = append(webhook.Events, domain.EventType(event))
+	}
+
+	return webhook, nil
+}
+
+// Reads a webhook delivery selected with webhookDeliveryColumns.
+func scanWebhookDelivery(row scanner) (*domain.WebhookDelivery, error) {
+	delivery := &domain.WebhookDelivery{}
+	var payload string
+
+	err := row.Scan(&delivery.ID, &delivery.TenantID, &delivery.WebhookID, &delivery.OutboxEntryID, &delivery.EventType, &payload, &delivery.RedeliveryOf, &delivery.CreatedAt, &delivery.Attempts,
+		&delivery.StatusCode, &delivery.LastError, &delivery.DurationMs, &delivery.NextAttemptAt, &delivery.DeliveredAt, &delivery.FailedAt)
+	delivery.Payload = []byte(payload)
+
+	return delivery, err
+}
+
+// Implements the logic to save a webhook in PostgreSQL, in the tenant of the context.
+func (r *WebhookRepository) SaveWebhook(ctx context.Context, webhook *domain.Webhook) error {
+	if webhook.ID == "" {
+		webhook.ID = uuid.New().String()
+	}
+
+	webhook.TenantID = domain.TenantFrom(ctx)
+	webhook.CreatedAt = time.Now()
+
+	events := make([]string, 0, len(webhook.Events))
+	for _, event := range webhook.Events {
+		events = append(events, string(event))
+	}
+
+	query := `INSERT INTO webhooks (id, tenant_id, url, events, secret, created_at) VALUES ($1, $2, $3, $4, $5, $6)`
+	_, err := r.db.ExecContext(ctx, query, webhook.ID, webhook.TenantID, webhook.URL, pq.Array(events), webhook.Secret, webhook.CreatedAt)
+
+	if err != nil {
+		return fmt.Errorf("postgresql: failed to insert webhook: %w", err)
+	}
+
+	return nil
+}
+
+// Implements the logic to find a webhook of the tenant by ID in PostgreSQL.
+func (r *WebhookRepository) FindWebhookByID(ctx context.Context, id string) (*domain.Webhook, error) {
+	query := `SELECT ` + webhookColumns + ` FROM webhooks WHERE id = $1 AND tenant_id = $2`
+	webhook, err := scanWebhook(r.db.QueryRowContext(ctx, query, id, domain.TenantFrom(ctx)))
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil // Webhook not found
+		}
+
+		return nil, fmt.Errorf("postgresql: failed to find webhook by ID (scan error): %w", err)
+	}
+
+	return webhook, nil
+}
+
+// Implements the logic to find every webhook of the tenant in PostgreSQL, oldest first.
+func (r *WebhookRepository) FindWebhooks(ctx context.Context) ([]domain.Webhook, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT `+webhookColumns+` FROM webhooks WHERE tenant_id = $1 ORDER BY created_at, id`, domain.TenantFrom(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("postgresql: failed to query webhooks: %w", err)
+	}
+	defer rows.Close()
+
+	webhooks := make([]domain.Webhook, 0)
+
+	for rows.Next() {
+		webhook, err := scanWebhook(rows)
+		if err != nil {
+			return nil, fmt.Errorf("postgresql: failed to scan webhook row: %w", err)
+		}
+		webhooks = append(webhooks, *webhook)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("postgresql: rows iteration error: %w", err)
+	}
+
+	return webhooks, nil
+}
+
+// Implements the logic to delete a webhook of the tenant in PostgreSQL. Its deliveries are removed by the foreign key.
+func (r *WebhookRepository) DeleteWebhook(ctx context.Context, id string) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM webhooks WHERE id = $1 AND tenant_id = $2`, id, domain.TenantFrom(ctx))
+
+	if err != nil {
+		return fmt.Errorf("postgresql: failed to delete webhook: %w", err)
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return domain.ErrWebhookNotFound
+	}
+
+	return nil
+}
+
+// Implements the logic to save webhook deliveries in PostgreSQL, in the tenant of the context, due immediately, skipping those already queued for their outbox entry.
+func (r *WebhookRepository) SaveWebhookDeliveries(ctx context.Context, deliveries []*domain.WebhookDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	// Deliveries are only queued for the webhooks of the tenant
+	checked := make(map[string]bool)
+	for _, delivery := range deliveries {
+		if checked[delivery.WebhookID] {
+			continue
+		}
+
+		webhook, err := r.FindWebhookByID(ctx, delivery.WebhookID)
+		if err != nil {
+			return err
+		}
+		if webhook == nil {
+			return fmt.Errorf("postgresql: webhook %s of delivery: %w", delivery.WebhookID, domain.ErrWebhookNotFound)
+		}
+		checked[delivery.WebhookID] = true
+	}
+
+	// A single statement saves every delivery or none
+	query := `INSERT INTO webhook_deliveries (id, tenant_id, webhook_id, outbox_entry_id, event_type, payload, redelivery_of, created_at, next_attempt_at) VALUES `
+	args := make([]any, 0, len(deliveries)*8)
+	tenant := domain.TenantFrom(ctx)
+	createdAt := time.Now()
+
+	for i, delivery := range deliveries {
+		delivery.ID = uuid.New().String()
+		delivery.TenantID = tenant
+		delivery.CreatedAt = createdAt
+		delivery.NextAttemptAt = createdAt
+
+		if i > 0 {
+			query += `, `
+		}
+		n := len(args)
+		query += fmt.Sprintf(`($%d, $%d, $%d, $%d, $%d, $%d, $%d, $%d, $%d)`, n+1, n+2, n+3, n+4, n+5, n+6, n+7, n+8, n+8)
+		args = append(args, delivery.ID, delivery.TenantID, delivery.WebhookID, delivery.OutboxEntryID, delivery.EventType, string(delivery.Payload), delivery.RedeliveryOf, createdAt)
+	}
+
+	// Deliveries already queued for their outbox entry are left as they are
+	query += ` ON CONFLICT (outbox_entry_id, webhook_id) DO NOTHING`
+
+	if _, err := r.db.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("postgresql: failed to insert webhook deliveries: %w", err)
+	}
+
+	return nil
+}
+
+// Implements the logic to claim the due webhook deliveries of every tenant in PostgreSQL.
+// Rows locked by a concurrent claim are skipped rather than waited for.
+func (r *WebhookRepository) ClaimWebhookDeliveries(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*domain.WebhookDelivery, error) {
+	query := `
+		UPDATE webhook_deliveries SET next_attempt_at = $2
+		WHERE id IN (
+			SELECT id FROM webhook_deliveries
+			WHERE delivered_at IS NULL AND failed_at IS NULL AND next_attempt_at <= $1
+			ORDER BY next_attempt_at, created_at LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + webhookDeliveryColumns
+
+	rows, err := r.db.QueryContext(ctx, query, now, now.Add(lease), limit)
+	if err != nil {
+		return nil, fmt.Errorf("postgresql: failed to claim webhook deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	deliveries := make([]*domain.WebhookDelivery, 0, limit)
+
+	for rows.Next() {
+		delivery, err := scanWebhookDelivery(rows)
+		if err != nil {
+			return nil, fmt.Errorf("postgresql: failed to scan webhook delivery row: %w", err)
+		}
+		deliveries = append(deliveries, delivery)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("postgresql: rows iteration error: %w", err)
+	}
+
+	// RETURNING does not keep the order of the subquery
+	slices.SortFunc(deliveries, compareDeliveries)
+
+	return deliveries, nil
+}
+
+// Orders webhook deliveries oldest first.
+func compareDeliveries(a, b *domain.WebhookDelivery) int {
+	return cmp.Or(a.CreatedAt.Compare(b.CreatedAt), cmp.Compare(a.ID, b.ID))
+}
+
+// Implements the logic to save the outcome of the latest attempt of a webhook delivery of the tenant in PostgreSQL.
+func (r *WebhookRepository) UpdateWebhookDelivery(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	query := `
+		UPDATE webhook_deliveries
+		SET attempts = $1, status_code = $2, last_error = $3, duration_ms = $4, next_attempt_at = $5, delivered_at = $6, failed_at = $7
+		WHERE id = $8 AND tenant_id = $9`
+	result, err := r.db.ExecContext(ctx, query, delivery.Attempts, delivery.StatusCode, delivery.LastError, delivery.DurationMs,
+		delivery.NextAttemptAt, delivery.DeliveredAt, delivery.FailedAt, delivery.ID, domain.TenantFrom(ctx))
+
+	if err != nil {
+		return fmt.Errorf("postgresql: failed to update webhook delivery: %w", err)
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return domain.ErrDeliveryNotFound
+	}
+
+	return nil
+}
+
+// Implements the logic to find a delivery of a webhook of the tenant in PostgreSQL.
+func (r *WebhookRepository) FindWebhookDelivery(ctx context.Context, webhookID, id string) (*domain.WebhookDelivery, error) {
+	query := `SELECT ` + webhookDeliveryColumns + ` FROM webhook_deliveries WHERE id = $1 AND webhook_id = $2 AND tenant_id = $3`
+	delivery, err := scanWebhookDelivery(r.db.QueryRowContext(ctx, query, id, webhookID, domain.TenantFrom(ctx)))
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil // Delivery not found
+		}
+
+		return nil, fmt.Errorf("postgresql: failed to find webhook delivery (scan error): %w", err)
+	}
+
+	return delivery, nil
+}
+
+// Implements the logic to find a page of the deliveries of a webhook of the tenant in PostgreSQL.
+// Uses keyset pagination on (created_at, id), newest deliveries first.
+func (r *WebhookRepository) FindWebhookDeliveries(ctx context.Context, webhookID string, page domain.PageRequest) (*domain.Page[domain.WebhookDelivery], error) {
+	limit := page.NormalizedLimit()
+
+	query := `SELECT ` + webhookDeliveryColumns + ` FROM webhook_deliveries WHERE webhook_id = $1 AND tenant_id = $2`
+	args := []any{webhookID, domain.TenantFrom(ctx)}
+
+	if page.Cursor != nil {
+		createdAt, err := page.Cursor.TimeValue()
+		if err != nil {
+			return nil, fmt.Errorf("postgresql: %w", err)
+		}
+		query += ` AND (created_at, id) < ($3, $4)`
+		args = append(args, createdAt, page.Cursor.ID)
+	}
+
+	// One extra row tells us whether there is a next page
+	query += fmt.Sprintf(` ORDER BY created_at DESC, id DESC LIMIT $%d`, len(args)+1)
+	args = append(args, limit+1)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("postgresql: failed to query webhook deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	deliveries := make([]domain.WebhookDelivery, 0, limit+1)
+
+	for rows.Next() {
+		delivery, err := scanWebhookDelivery(rows)
+		if err != nil {
+			return nil, fmt.Errorf("postgresql: failed to scan webhook delivery row: %w", err)
+		}
+		deliveries = append(deliveries, *delivery)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("postgresql: rows iteration error: %w", err)
+	}
+
+	return domain.NewPage(deliveries, limit, domain.WebhookDeliveryCursor), nil
+}
+
+// Implements the logic to remove the delivered and failed webhook deliveries created before the given time in PostgreSQL, in every tenant.
+func (r *WebhookRepository) PurgeWebhookDeliveries(ctx context.Context, createdBefore time.Time) (int64, error) {
+	query := `DELETE FROM webhook_deliveries WHERE created_at < $1 AND (delivered_at IS NOT NULL OR failed_at IS NOT NULL)`
+	result, err := r.db.ExecContext(ctx, query, createdBefore)
+
+	if err != nil {
+		return 0, fmt.Errorf("postgresql: failed to purge webhook deliveries: %w", err)
+	}
+
+	return result.RowsAffected()
+}
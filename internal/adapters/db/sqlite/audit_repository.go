@@ -0,0 +1,244 @@
+package sqlite
+
+import (
+	"Gin/internal/core/domain"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Implements the ports.AuditDrivenPort interface for SQLite.
+type AuditRepository struct {
+	db DBTX
+}
+
+// Creates a new instance of AuditRepository over a database or a transaction.
+func NewAuditRepository(db DBTX) *AuditRepository {
+	return &AuditRepository{db: db}
+}
+
+// Columns selected for an audit event, in the order scanAuditEvent reads them.
+const auditColumns = `id, tenant_id, actor, action, entity_type, entity_id, occurred_at, request_id, client_ip, diff, prev_hash, hash`
+
+// Reads an audit event selected with auditColumns.
+func scanAuditEvent(row scanner) (*domain.AuditEvent, error) {
+	event := &domain.AuditEvent{}
+	var diff string
+
+	err := row.Scan(&event.ID, &event.TenantID, &event.Actor, &event.Action, &event.EntityType, &event.EntityID, scanTime(&event.OccurredAt), &event.RequestID, &event.ClientIP, &diff, &event.PrevHash, &event.Hash)
+	event.Diff = []byte(diff)
+
+	return event, err
+}
+
+// Implements the logic to find the hash of the latest audit event of the tenant in SQLite.
+// There is nothing to lock: the write transactions of SQLite already run one at a time.
+func (r *AuditRepository) LockAuditTail(ctx context.Context) (string, error) {
+	var hash string
+	err := r.db.QueryRowContext(ctx, `SELECT hash FROM audit_events WHERE tenant_id = ? ORDER BY id DESC LIMIT 1`, domain.TenantFrom(ctx)).Scan(&hash)
+
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return "", fmt.Errorf("sqlite: failed to query the latest audit hash: %w", err)
+	}
+
+	return hash, nil
+}
+
+// Implements the logic to save audit events in SQLite.
+func (r *AuditRepository) SaveAuditEvents(ctx context.Context, events []*domain.AuditEvent) error {
+	query := `
+		INSERT INTO audit_events (tenant_id, actor, action, entity_type, entity_id, occurred_at, request_id, client_ip, diff, prev_hash, hash)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		RETURNING id`
+
+	for _, event := range events {
+		err := r.db.QueryRowContext(ctx, query, event.TenantID, event.Actor, event.Action, event.EntityType, event.EntityID, formatTime(event.OccurredAt),
+			event.RequestID, event.ClientIP, string(event.Diff), event.PrevHash, event.Hash).
+			Scan(&event.ID)
+
+		if err != nil {
+			return fmt.Errorf("sqlite: failed to insert audit event: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Implements the logic to find a page of the audit events of the tenant matching a query in SQLite, newest first.
+func (r *AuditRepository) FindAuditEvents(ctx context.Context, query domain.AuditQuery) (*domain.Page[domain.AuditEvent], error) {
+	limit := query.Page.NormalizedLimit()
+
+	conditions := []string{`tenant_id = ?`}
+	args := []any{domain.TenantFrom(ctx)}
+
+	condition := func(clause string, value any) {
+		conditions = append(conditions, clause)
+		args = append(args, value)
+	}
+
+	if query.EntityType != "" {
+		condition(`entity_type = ?`, query.EntityType)
+	}
+
+	if query.EntityID != "" {
+		condition(`entity_id = ?`, query.EntityID)
+	}
+
+	if query.Actor != "" {
+		condition(`actor = ?`, query.Actor)
+	}
+
+	if query.From != nil {
+		condition(`occurred_at >= ?`, formatTime(*query.From))
+	}
+
+	if query.To != nil {
+		condition(`occurred_at < ?`, formatTime(*query.To))
+	}
+
+	if query.Page.Cursor != nil {
+		before, err := query.Page.Cursor.AuditValue()
+		if err != nil {
+			return nil, fmt.Errorf("sqlite: %w", err)
+		}
+		condition(`id < ?`, before)
+	}
+
+	// One extra row tells us whether there is a next page
+	statement := `SELECT ` + auditColumns + ` FROM audit_events WHERE ` + strings.Join(conditions, ` AND `) +
+		` ORDER BY id DESC LIMIT ?`
+	args = append(args, limit+1)
+
+	rows, err := r.db.QueryContext(ctx, statement, args...)
+	if err != nil {
+		return nil, fmt.Errorf("sqlite: failed to query audit events: %w", err)
+	}
+	defer rows.Close()
+
+	events := make([]domain.AuditEvent, 0, limit+1)
+
+	for rows.Next() {
+		event, err := scanAuditEvent(rows)
+		if err != nil {
+			return nil, fmt.Errorf("sqlite: failed to scan audit event row: %w", err)
+		}
+		events = append(events, *event)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("sqlite: rows iteration error: %w", err)
+	}
+
+	return domain.NewPage(events, limit, domain.AuditCursor), nil
+}
+
+// Implements the logic to find the latest audit event of the tenant in SQLite.
+func (r *AuditRepository) FindLastAuditEvent(ctx context.Context) (*domain.AuditEvent, error) {
+	event, err := scanAuditEvent(r.db.QueryRowContext(ctx, `SELECT `+auditColumns+` FROM audit_events WHERE tenant_id = ? ORDER BY id DESC LIMIT 1`, domain.TenantFrom(ctx)))
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil // The chain is empty
+		}
+		return nil, fmt.Errorf("sqlite: failed to query the latest audit event: %w", err)
+	}
+
+	return event, nil
+}
+
+// Implements the logic to call fn with every audit event of the tenant in SQLite, oldest first.
+func (r *AuditRepository) StreamAuditEvents(ctx context.Context, fn func(*domain.AuditEvent) error) error {
+	rows, err := r.db.QueryContext(ctx, `SELECT `+auditColumns+` FROM audit_events WHERE tenant_id = ? ORDER BY id`, domain.TenantFrom(ctx))
+
+	if err != nil {
+		return fmt.Errorf("sqlite: failed to query audit events to stream: %w", err)
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		event, err := scanAuditEvent(rows)
+
+		if err != nil {
+			return fmt.Errorf("sqlite: failed to scan audit event row: %w", err)
+		}
+
+		if err := fn(event); err != nil {
+			return err
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("sqlite: rows iteration error: %w", err)
+	}
+
+	return nil
+}
+
+// Implements the logic to save an audit checkpoint of the tenant in SQLite.
+func (r *AuditRepository) SaveAuditCheckpoint(ctx context.Context, checkpoint *domain.AuditCheckpoint) error {
+	query := `
+		INSERT INTO audit_checkpoints (tenant_id, event_id, hash, created_at, signature)
+		VALUES (?, ?, ?, ?, ?)
+		RETURNING id`
+
+	err := r.db.QueryRowContext(ctx, query, domain.TenantFrom(ctx), checkpoint.EventID, checkpoint.Hash, formatTime(checkpoint.CreatedAt), checkpoint.Signature).Scan(&checkpoint.ID)
+
+	if err != nil {
+		return fmt.Errorf("sqlite: failed to insert audit checkpoint: %w", err)
+	}
+
+	return nil
+}
+
+// Implements the logic to find every audit checkpoint of the tenant in SQLite, oldest first.
+func (r *AuditRepository) FindAuditCheckpoints(ctx context.Context) ([]domain.AuditCheckpoint, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT id, tenant_id, event_id, hash, created_at, signature FROM audit_checkpoints WHERE tenant_id = ? ORDER BY id`, domain.TenantFrom(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("sqlite: failed to query audit checkpoints: %w", err)
+	}
+	defer rows.Close()
+
+	checkpoints := make([]domain.AuditCheckpoint, 0)
+
+	for rows.Next() {
+		var checkpoint domain.AuditCheckpoint
+		if err := rows.Scan(&checkpoint.ID, &checkpoint.TenantID, &checkpoint.EventID, &checkpoint.Hash, scanTime(&checkpoint.CreatedAt), &checkpoint.Signature); err != nil {
+			return nil, fmt.Errorf("sqlite: failed to scan audit checkpoint row: %w", err)
+		}
+		checkpoints = append(checkpoints, checkpoint)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("sqlite: rows iteration error: %w", err)
+	}
+
+	return checkpoints, nil
+}
+
+// Implements the logic to find the tenants with audit events in SQLite, in every tenant.
+func (r *AuditRepository) FindAuditTenants(ctx context.Context) ([]string, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT DISTINCT tenant_id FROM audit_events ORDER BY tenant_id`)
+	if err != nil {
+		return nil, fmt.Errorf("sqlite: failed to query audit tenants: %w", err)
+	}
+	defer rows.Close()
+
+	tenants := make([]string, 0)
+
+	for rows.Next() {
+		var tenant string
+		if err := rows.Scan(&tenant); err != nil {
+			return nil, fmt.Errorf("sqlite: failed to scan audit tenant row: %w", err)
+		}
+		tenants = append(tenants, tenant)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("sqlite: rows iteration error: %w", err)
+	}
+
+	return tenants, nil
+}
@@ -0,0 +1,163 @@
+package sqlite
+
+import (
+	"Gin/internal/core/domain"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// Implements the ports.CommentDrivenPort interface for SQLite.
+type CommentRepository struct {
+	db DBTX
+}
+
+// Creates a new instance of CommentRepository over a database or a transaction.
+func NewCommentRepository(db DBTX) *CommentRepository {
+	return &CommentRepository{db: db}
+}
+
+// Columns selected for a comment, in the order scanComment reads them.
+const commentColumns = `id, story_id, parent_id, author_id, body, created_at, updated_at,
+	(SELECT count(*) FROM comments r WHERE r.parent_id = comments.id) AS reply_count`
+
+// Reads a comment selected with commentColumns.
+func scanComment(row scanner) (*domain.Comment, error) {
+	comment := &domain.Comment{}
+	err := row.Scan(&comment.ID, &comment.StoryID, &comment.ParentID, &comment.AuthorID, &comment.Body, scanTime(&comment.CreatedAt), scanTime(&comment.UpdatedAt), &comment.ReplyCount)
+
+	return comment, err
+}
+
+// Implements the logic to save a comment in SQLite.
+func (r *CommentRepository) SaveComment(ctx context.Context, comment *domain.Comment) error {
+	if comment.ID == "" {
+		comment.ID = uuid.New().String()
+	}
+
+	comment.CreatedAt = now()
+	comment.UpdatedAt = comment.CreatedAt
+
+	query := `
+		INSERT INTO comments (id, story_id, parent_id, author_id, body, created_at, updated_at)
+		SELECT ?1, ?2, ?3, ?4, ?5, ?6, ?7 WHERE EXISTS (SELECT 1 FROM stories WHERE id = ?2 AND tenant_id = ?8)`
+	result, err := r.db.ExecContext(ctx, query, comment.ID, comment.StoryID, comment.ParentID, comment.AuthorID, comment.Body, formatTime(comment.CreatedAt), formatTime(comment.UpdatedAt), domain.TenantFrom(ctx))
+
+	if err != nil {
+		return fmt.Errorf("sqlite: failed to insert comment: %w", err)
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return domain.ErrStoryNotFound
+	}
+
+	return nil
+}
+
+// Implements the logic to find a comment by ID in SQLite.
+func (r *CommentRepository) FindCommentByID(ctx context.Context, id string) (*domain.Comment, error) {
+	query := `SELECT ` + commentColumns + ` FROM comments WHERE id = ? AND ` + storyTenantCondition
+	comment, err := scanComment(r.db.QueryRowContext(ctx, query, id, domain.TenantFrom(ctx)))
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil // Comment not found
+		}
+
+		return nil, fmt.Errorf("sqlite: failed to find comment by ID (scan error): %w", err)
+	}
+
+	return comment, nil
+}
+
+// Implements the logic to find a page of the top-level comments of a story, or of the replies to a comment, in SQLite.
+// Uses keyset pagination on (created_at, id), oldest comments first.
+func (r *CommentRepository) FindComments(ctx context.Context, q domain.CommentQuery) (*domain.Page[domain.Comment], error) {
+	limit := q.Page.NormalizedLimit()
+
+	conditions := []string{`story_id = ?`, storyTenantCondition}
+	args := []any{q.StoryID, domain.TenantFrom(ctx)}
+
+	if q.ParentID == "" {
+		conditions = append(conditions, `parent_id IS NULL`)
+	} else {
+		conditions = append(conditions, `parent_id = ?`)
+		args = append(args, q.ParentID)
+	}
+
+	if q.Page.Cursor != nil {
+		createdAt, err := q.Page.Cursor.TimeValue()
+		if err != nil {
+			return nil, fmt.Errorf("sqlite: %w", err)
+		}
+		conditions = append(conditions, `(created_at, id) > (?, ?)`)
+		args = append(args, formatTime(createdAt), q.Page.Cursor.ID)
+	}
+
+	// One extra row tells us whether there is a next page
+	query := `SELECT ` + commentColumns + ` FROM comments WHERE ` + strings.Join(conditions, ` AND `) +
+		` ORDER BY created_at ASC, id ASC LIMIT ?`
+	args = append(args, limit+1)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("sqlite: failed to query comments: %w", err)
+	}
+	defer rows.Close()
+
+	comments := make([]domain.Comment, 0, limit+1)
+
+	for rows.Next() {
+		comment, err := scanComment(rows)
+		if err != nil {
+			return nil, fmt.Errorf("sqlite: failed to scan comment row: %w", err)
+		}
+		comments = append(comments, *comment)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("sqlite: rows iteration error: %w", err)
+	}
+
+	return domain.NewPage(comments, limit, domain.CommentCursor), nil
+}
+
+// Implements the logic to update the body of a comment in SQLite.
+func (r *CommentRepository) UpdateComment(ctx context.Context, comment *domain.Comment) error {
+	comment.UpdatedAt = now()
+
+	query := `UPDATE comments SET body = ?, updated_at = ? WHERE id = ? AND ` + storyTenantCondition
+	result, err := r.db.ExecContext(ctx, query, comment.Body, formatTime(comment.UpdatedAt), comment.ID, domain.TenantFrom(ctx))
+
+	if err != nil {
+		return fmt.Errorf("sqlite: failed to update comment: %w", err)
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return domain.ErrCommentNotFound
+	}
+
+	return nil
+}
+
+// Implements the logic to delete a comment in SQLite. Its replies are deleted by the foreign key cascade.
+func (r *CommentRepository) DeleteComment(ctx context.Context, id string) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM comments WHERE id = ? AND `+storyTenantCondition, id, domain.TenantFrom(ctx))
+
+	if err != nil {
+		return fmt.Errorf("sqlite: failed to delete comment: %w", err)
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return domain.ErrCommentNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,19 @@
+package sqlite
+
+import (
+	"embed"
+	"io/fs"
+)
+
+// Directory holding the SQLite migrations, relative to the repository root.
+// Used by `migrate create` to write new migration files.
+const MigrationsDir = "internal/adapters/db/sqlite/migrations"
+
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// Returns the embedded SQLite migrations.
+func Migrations() fs.FS {
+	migrations, _ := fs.Sub(migrationFiles, "migrations")
+	return migrations
+}
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS users (
+    id TEXT PRIMARY KEY,
+    email TEXT NOT NULL UNIQUE,
+    name TEXT NOT NULL,
+    created_at TEXT NOT NULL,
+    updated_at TEXT NOT NULL
+);
+
+-- Backs keyset pagination on (created_at, id)
+CREATE INDEX IF NOT EXISTS users_created_at_id_idx ON users (created_at, id);
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS stories (
+    id TEXT PRIMARY KEY,
+    title TEXT NOT NULL,
+    author TEXT NOT NULL,
+    content TEXT NOT NULL,
+    created_at TEXT NOT NULL,
+    updated_at TEXT NOT NULL
+);
+
+-- Back keyset pagination on (sort column, id) and the listing filters
+CREATE INDEX IF NOT EXISTS stories_created_at_id_idx ON stories (created_at, id);
+CREATE INDEX IF NOT EXISTS stories_updated_at_id_idx ON stories (updated_at, id);
+CREATE INDEX IF NOT EXISTS stories_title_id_idx ON stories (title, id);
+CREATE INDEX IF NOT EXISTS stories_author_id_idx ON stories (author, id);
+CREATE INDEX IF NOT EXISTS stories_lower_author_idx ON stories (lower(author));
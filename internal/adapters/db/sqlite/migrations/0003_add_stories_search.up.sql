@@ -0,0 +1,16 @@
+-- Full-text search index over title and content, kept in sync by triggers
+CREATE VIRTUAL TABLE IF NOT EXISTS stories_fts USING fts5(id UNINDEXED, title, content);
+
+INSERT INTO stories_fts (id, title, content) SELECT id, title, content FROM stories;
+
+CREATE TRIGGER IF NOT EXISTS stories_fts_insert AFTER INSERT ON stories BEGIN
+    INSERT INTO stories_fts (id, title, content) VALUES (new.id, new.title, new.content);
+END;
+
+CREATE TRIGGER IF NOT EXISTS stories_fts_update AFTER UPDATE OF title, content ON stories BEGIN
+    UPDATE stories_fts SET title = new.title, content = new.content WHERE id = old.id;
+END;
+
+CREATE TRIGGER IF NOT EXISTS stories_fts_delete AFTER DELETE ON stories BEGIN
+    DELETE FROM stories_fts WHERE id = old.id;
+END;
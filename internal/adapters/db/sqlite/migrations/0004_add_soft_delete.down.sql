@@ -0,0 +1,22 @@
+-- Without deleted_at, soft-deleted rows would come back: remove them for good
+DELETE FROM stories WHERE deleted_at IS NOT NULL;
+DELETE FROM users WHERE deleted_at IS NOT NULL;
+
+DROP INDEX IF EXISTS stories_deleted_at_idx;
+ALTER TABLE stories DROP COLUMN deleted_at;
+
+CREATE TABLE users_old (
+    id TEXT PRIMARY KEY,
+    email TEXT NOT NULL UNIQUE,
+    name TEXT NOT NULL,
+    created_at TEXT NOT NULL,
+    updated_at TEXT NOT NULL
+);
+
+INSERT INTO users_old (id, email, name, created_at, updated_at)
+SELECT id, email, name, created_at, updated_at FROM users;
+
+DROP TABLE users;
+ALTER TABLE users_old RENAME TO users;
+
+CREATE INDEX users_created_at_id_idx ON users (created_at, id);
@@ -0,0 +1,25 @@
+ALTER TABLE stories ADD COLUMN deleted_at TEXT;
+
+-- SQLite cannot drop the inline UNIQUE constraint on email, so the users table is rebuilt
+-- with a partial unique index instead: deleted users no longer reserve their email
+CREATE TABLE users_new (
+    id TEXT PRIMARY KEY,
+    email TEXT NOT NULL,
+    name TEXT NOT NULL,
+    created_at TEXT NOT NULL,
+    updated_at TEXT NOT NULL,
+    deleted_at TEXT
+);
+
+INSERT INTO users_new (id, email, name, created_at, updated_at)
+SELECT id, email, name, created_at, updated_at FROM users;
+
+DROP TABLE users;
+ALTER TABLE users_new RENAME TO users;
+
+CREATE INDEX users_created_at_id_idx ON users (created_at, id);
+CREATE UNIQUE INDEX users_email_active_key ON users (email) WHERE deleted_at IS NULL;
+
+-- Back the purge of rows deleted before the retention window
+CREATE INDEX stories_deleted_at_idx ON stories (deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX users_deleted_at_idx ON users (deleted_at) WHERE deleted_at IS NOT NULL;
@@ -0,0 +1,10 @@
+-- Previous versions of stories, written on every update
+CREATE TABLE IF NOT EXISTS story_revisions (
+    story_id TEXT NOT NULL REFERENCES stories (id) ON DELETE CASCADE,
+    revision INTEGER NOT NULL,
+    title TEXT NOT NULL,
+    author TEXT NOT NULL,
+    content TEXT NOT NULL,
+    created_at TEXT NOT NULL,
+    PRIMARY KEY (story_id, revision)
+);
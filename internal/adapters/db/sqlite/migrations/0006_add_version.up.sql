@@ -0,0 +1,3 @@
+-- Incremented on every update for optimistic concurrency control
+ALTER TABLE stories ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE users ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
@@ -0,0 +1,15 @@
+-- Link stories to the user who wrote them; users with stories cannot be removed
+ALTER TABLE stories ADD COLUMN author_id TEXT REFERENCES users (id) ON DELETE RESTRICT;
+ALTER TABLE story_revisions ADD COLUMN author_id TEXT;
+
+-- Backfill from the free-text author, only where exactly one active user has that name
+UPDATE stories
+SET author_id = (SELECT u.id FROM users u WHERE u.deleted_at IS NULL AND lower(u.name) = lower(stories.author))
+WHERE author_id IS NULL
+  AND (SELECT count(*) FROM users u WHERE u.deleted_at IS NULL AND lower(u.name) = lower(stories.author)) = 1;
+
+UPDATE stories
+SET author = (SELECT u.name FROM users u WHERE u.id = stories.author_id)
+WHERE author_id IS NOT NULL;
+
+CREATE INDEX IF NOT EXISTS stories_author_ref_idx ON stories (author_id) WHERE author_id IS NOT NULL;
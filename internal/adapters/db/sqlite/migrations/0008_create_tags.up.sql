@@ -0,0 +1,15 @@
+-- Tags and the stories they are assigned to
+CREATE TABLE IF NOT EXISTS tags (
+    id INTEGER PRIMARY KEY,
+    name TEXT NOT NULL UNIQUE, -- Normalized slug
+    created_at TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS story_tags (
+    story_id TEXT NOT NULL REFERENCES stories (id) ON DELETE CASCADE,
+    tag_id INTEGER NOT NULL REFERENCES tags (id) ON DELETE CASCADE,
+    PRIMARY KEY (story_id, tag_id)
+);
+
+-- Back the tag filters and usage counts
+CREATE INDEX IF NOT EXISTS story_tags_tag_id_idx ON story_tags (tag_id, story_id);
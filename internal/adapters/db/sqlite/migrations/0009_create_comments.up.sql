@@ -0,0 +1,15 @@
+-- Comments on stories, with one level of replies
+CREATE TABLE IF NOT EXISTS comments (
+    id TEXT PRIMARY KEY,
+    story_id TEXT NOT NULL REFERENCES stories (id) ON DELETE CASCADE,
+    parent_id TEXT REFERENCES comments (id) ON DELETE CASCADE,
+    author_id TEXT NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    body TEXT NOT NULL,
+    created_at TEXT NOT NULL,
+    updated_at TEXT NOT NULL
+);
+
+-- Back the keyset pagination of top-level comments and of replies, and the reply counts
+CREATE INDEX IF NOT EXISTS comments_story_id_idx ON comments (story_id, created_at, id) WHERE parent_id IS NULL;
+CREATE INDEX IF NOT EXISTS comments_parent_id_idx ON comments (parent_id, created_at, id) WHERE parent_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS comments_author_id_idx ON comments (author_id);
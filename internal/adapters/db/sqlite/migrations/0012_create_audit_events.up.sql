@@ -0,0 +1,17 @@
+-- Append-only log of the changes made to stories and users
+CREATE TABLE IF NOT EXISTS audit_events (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    actor TEXT NOT NULL,
+    action TEXT NOT NULL,
+    entity_type TEXT NOT NULL,
+    entity_id TEXT NOT NULL DEFAULT '', -- Empty for purges
+    occurred_at TEXT NOT NULL,
+    request_id TEXT NOT NULL DEFAULT '',
+    client_ip TEXT NOT NULL DEFAULT '',
+    diff TEXT NOT NULL
+);
+
+-- Back the listings by entity, by actor and by time range
+CREATE INDEX IF NOT EXISTS audit_events_entity_idx ON audit_events (entity_type, entity_id, id);
+CREATE INDEX IF NOT EXISTS audit_events_actor_idx ON audit_events (actor, id);
+CREATE INDEX IF NOT EXISTS audit_events_occurred_at_idx ON audit_events (occurred_at);
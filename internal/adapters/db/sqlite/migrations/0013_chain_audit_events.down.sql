@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS audit_checkpoints;
+ALTER TABLE audit_events DROP COLUMN hash;
+ALTER TABLE audit_events DROP COLUMN prev_hash;
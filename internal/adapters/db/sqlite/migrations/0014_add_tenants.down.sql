@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS stories_tenant_created_at_id_idx;
+DROP INDEX IF EXISTS users_tenant_created_at_id_idx;
+
+DROP INDEX IF EXISTS users_email_active_key;
+CREATE UNIQUE INDEX users_email_active_key ON users (email) WHERE deleted_at IS NULL;
+
+ALTER TABLE stories DROP COLUMN tenant_id;
+ALTER TABLE users DROP COLUMN tenant_id;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS webhook_deliveries_outbox_entry_key;
+ALTER TABLE webhook_deliveries DROP COLUMN outbox_entry_id;
@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS webhooks_tenant_created_at_id_idx;
+DROP INDEX IF EXISTS outbox_tenant_id_idx;
+DROP INDEX IF EXISTS audit_events_tenant_id_idx;
+
+ALTER TABLE webhook_deliveries DROP COLUMN tenant_id;
+ALTER TABLE webhooks DROP COLUMN tenant_id;
+ALTER TABLE outbox DROP COLUMN tenant_id;
+ALTER TABLE audit_events DROP COLUMN tenant_id;
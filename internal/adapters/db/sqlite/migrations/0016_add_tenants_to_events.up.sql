@@ -0,0 +1,11 @@
+-- Audit events, outbox entries, webhooks and their deliveries belong to the tenant of the request that made them;
+-- the existing ones to the default tenant
+ALTER TABLE audit_events ADD COLUMN tenant_id TEXT NOT NULL DEFAULT 'default';
+ALTER TABLE outbox ADD COLUMN tenant_id TEXT NOT NULL DEFAULT 'default';
+ALTER TABLE webhooks ADD COLUMN tenant_id TEXT NOT NULL DEFAULT 'default';
+ALTER TABLE webhook_deliveries ADD COLUMN tenant_id TEXT NOT NULL DEFAULT 'default';
+
+-- Back the listings, which are always scoped to a tenant
+CREATE INDEX IF NOT EXISTS audit_events_tenant_id_idx ON audit_events (tenant_id, id);
+CREATE INDEX IF NOT EXISTS outbox_tenant_id_idx ON outbox (tenant_id, id);
+CREATE INDEX IF NOT EXISTS webhooks_tenant_created_at_id_idx ON webhooks (tenant_id, created_at, id);
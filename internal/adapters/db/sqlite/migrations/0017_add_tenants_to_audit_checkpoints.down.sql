@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS audit_checkpoints_tenant_id_idx;
+
+ALTER TABLE audit_checkpoints DROP COLUMN tenant_id;
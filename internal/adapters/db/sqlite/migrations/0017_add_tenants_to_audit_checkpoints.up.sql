@@ -0,0 +1,5 @@
+-- Each tenant has its own audit chain, so its checkpoints sign the end of that chain;
+-- the existing ones signed the chain of the default tenant
+ALTER TABLE audit_checkpoints ADD COLUMN tenant_id TEXT NOT NULL DEFAULT 'default';
+
+CREATE INDEX IF NOT EXISTS audit_checkpoints_tenant_id_idx ON audit_checkpoints (tenant_id, id);
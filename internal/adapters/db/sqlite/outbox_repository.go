@@ -0,0 +1,208 @@
+package sqlite
+
+import (
+	"Gin/internal/core/domain"
+	"cmp"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// Implements the ports.OutboxDrivenPort interface for SQLite.
+type OutboxRepository struct {
+	db DBTX
+}
+
+// Creates a new instance of OutboxRepository over a database or a transaction.
+func NewOutboxRepository(db DBTX) *OutboxRepository {
+	return &OutboxRepository{db: db}
+}
+
+// Columns selected for an outbox entry, in the order scanOutboxEntry reads them.
+const outboxColumns = `id, tenant_id, event_type, payload, created_at, attempts, last_error, next_attempt_at, delivered_at, failed_at`
+
+// Reads an outbox entry selected with outboxColumns.
+func scanOutboxEntry(row scanner) (*domain.OutboxEntry, error) {
+	entry := &domain.OutboxEntry{}
+	var payload string
+
+	err := row.Scan(&entry.ID, &entry.TenantID, &entry.EventType, &payload, scanTime(&entry.CreatedAt), &entry.Attempts, &entry.LastError, scanTime(&entry.NextAttemptAt), scanNullTime(&entry.DeliveredAt), scanNullTime(&entry.FailedAt))
+	entry.Payload = []byte(payload)
+
+	return entry, err
+}
+
+// Returns the condition matching the outbox entries with the given status.
+func outboxStatusCondition(status domain.OutboxStatus) string {
+	switch status {
+	case domain.OutboxDelivered:
+		return `delivered_at IS NOT NULL`
+	case domain.OutboxFailed:
+		return `failed_at IS NOT NULL`
+	default:
+		return `delivered_at IS NULL AND failed_at IS NULL`
+	}
+}
+
+// Implements the logic to save outbox entries in SQLite, in the tenant of the context, due immediately.
+func (r *OutboxRepository) SaveOutboxEntries(ctx context.Context, entries []*domain.OutboxEntry) error {
+	query := `INSERT INTO outbox (tenant_id, event_type, payload, created_at, next_attempt_at) VALUES (?1, ?2, ?3, ?4, ?4) RETURNING id`
+
+	for _, entry := range entries {
+		entry.TenantID = domain.TenantFrom(ctx)
+		entry.CreatedAt = now()
+		entry.NextAttemptAt = entry.CreatedAt
+
+		err := r.db.QueryRowContext(ctx, query, entry.TenantID, entry.EventType, string(entry.Payload), formatTime(entry.CreatedAt)).Scan(&entry.ID)
+
+		if err != nil {
+			return fmt.Errorf("sqlite: failed to insert outbox entry: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Implements the logic to claim the due outbox entries of every tenant in SQLite.
+// SQLite has a single writer, so the update alone keeps concurrent claims apart.
+func (r *OutboxRepository) ClaimOutboxEntries(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*domain.OutboxEntry, error) {
+	query := `
+		UPDATE outbox SET next_attempt_at = ?2
+		WHERE id IN (
+			SELECT id FROM outbox
+			WHERE delivered_at IS NULL AND failed_at IS NULL AND next_attempt_at <= ?1
+			ORDER BY id LIMIT ?3
+		)
+		RETURNING ` + outboxColumns
+
+	rows, err := r.db.QueryContext(ctx, query, formatTime(now), formatTime(now.Add(lease)), limit)
+	if err != nil {
+		return nil, fmt.Errorf("sqlite: failed to claim outbox entries: %w", err)
+	}
+	defer rows.Close()
+
+	entries := make([]*domain.OutboxEntry, 0, limit)
+
+	for rows.Next() {
+		entry, err := scanOutboxEntry(rows)
+		if err != nil {
+			return nil, fmt.Errorf("sqlite: failed to scan outbox entry row: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("sqlite: rows iteration error: %w", err)
+	}
+
+	// RETURNING does not keep the order of the subquery
+	slices.SortFunc(entries, func(a, b *domain.OutboxEntry) int { return cmp.Compare(a.ID, b.ID) })
+
+	return entries, nil
+}
+
+// Implements the logic to save the delivery state of an outbox entry of the tenant in SQLite.
+func (r *OutboxRepository) UpdateOutboxEntry(ctx context.Context, entry *domain.OutboxEntry) error {
+	query := `UPDATE outbox SET attempts = ?, last_error = ?, next_attempt_at = ?, delivered_at = ?, failed_at = ? WHERE id = ? AND tenant_id = ?`
+	result, err := r.db.ExecContext(ctx, query, entry.Attempts, entry.LastError, formatTime(entry.NextAttemptAt), formatNullTime(entry.DeliveredAt), formatNullTime(entry.FailedAt), entry.ID, domain.TenantFrom(ctx))
+
+	if err != nil {
+		return fmt.Errorf("sqlite: failed to update outbox entry: %w", err)
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return domain.ErrOutboxEntryNotFound
+	}
+
+	return nil
+}
+
+// Implements the logic to find an outbox entry of the tenant in SQLite.
+func (r *OutboxRepository) FindOutboxEntry(ctx context.Context, id int64) (*domain.OutboxEntry, error) {
+	query := `SELECT ` + outboxColumns + ` FROM outbox WHERE id = ? AND tenant_id = ?`
+	entry, err := scanOutboxEntry(r.db.QueryRowContext(ctx, query, id, domain.TenantFrom(ctx)))
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil // Entry not found
+		}
+
+		return nil, fmt.Errorf("sqlite: failed to find outbox entry (scan error): %w", err)
+	}
+
+	return entry, nil
+}
+
+// Implements the logic to find a page of the outbox entries of the tenant with a status in SQLite, oldest first.
+func (r *OutboxRepository) FindOutboxEntries(ctx context.Context, query domain.OutboxQuery) (*domain.Page[domain.OutboxEntry], error) {
+	limit := query.Page.NormalizedLimit()
+
+	statement := `SELECT ` + outboxColumns + ` FROM outbox WHERE tenant_id = ? AND ` + outboxStatusCondition(query.Status)
+	args := []any{domain.TenantFrom(ctx)}
+
+	if query.Page.Cursor != nil {
+		after, err := query.Page.Cursor.OutboxValue()
+		if err != nil {
+			return nil, fmt.Errorf("sqlite: %w", err)
+		}
+		statement += ` AND id > ?`
+		args = append(args, after)
+	}
+
+	// One extra row tells us whether there is a next page
+	statement += ` ORDER BY id LIMIT ?`
+	args = append(args, limit+1)
+
+	rows, err := r.db.QueryContext(ctx, statement, args...)
+	if err != nil {
+		return nil, fmt.Errorf("sqlite: failed to query outbox entries: %w", err)
+	}
+	defer rows.Close()
+
+	entries := make([]domain.OutboxEntry, 0, limit+1)
+
+	for rows.Next() {
+		entry, err := scanOutboxEntry(rows)
+		if err != nil {
+			return nil, fmt.Errorf("sqlite: failed to scan outbox entry row: %w", err)
+		}
+		entries = append(entries, *entry)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("sqlite: rows iteration error: %w", err)
+	}
+
+	return domain.NewPage(entries, limit, domain.OutboxCursor), nil
+}
+
+// Implements the logic to count the pending and failed outbox entries of the tenant in SQLite.
+func (r *OutboxRepository) CountOutboxEntries(ctx context.Context) (*domain.OutboxCounts, error) {
+	counts := &domain.OutboxCounts{}
+
+	query := `
+		SELECT
+			COUNT(*) FILTER (WHERE ` + outboxStatusCondition(domain.OutboxPending) + `),
+			COUNT(*) FILTER (WHERE ` + outboxStatusCondition(domain.OutboxFailed) + `)
+		FROM outbox WHERE tenant_id = ?`
+	if err := r.db.QueryRowContext(ctx, query, domain.TenantFrom(ctx)).Scan(&counts.Pending, &counts.Failed); err != nil {
+		return nil, fmt.Errorf("sqlite: failed to count outbox entries: %w", err)
+	}
+
+	return counts, nil
+}
+
+// Implements the logic to remove the outbox entries delivered before the given time in SQLite, in every tenant.
+func (r *OutboxRepository) PurgeDeliveredOutboxEntries(ctx context.Context, deliveredBefore time.Time) (int64, error) {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM outbox WHERE delivered_at < ?`, formatTime(deliveredBefore))
+
+	if err != nil {
+		return 0, fmt.Errorf("sqlite: failed to purge delivered outbox entries: %w", err)
+	}
+
+	return result.RowsAffected()
+}
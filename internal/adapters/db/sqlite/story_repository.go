@@ -0,0 +1,410 @@
+package sqlite
+
+import (
+	"Gin/internal/core/domain"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	_ "modernc.org/sqlite"
+)
+
+// Implements the ports.StoryDrivenPort interface for SQLite.
+type StoryRepository struct {
+	db DBTX
+}
+
+// Creates a new instance of StoryRepository over a database or a transaction.
+func NewStoryRepository(db DBTX) *StoryRepository {
+	return &StoryRepository{db: db}
+}
+
+// Implements the logic to save a story in SQLite, in the tenant of the context.
+func (r *StoryRepository) SaveStory(ctx context.Context, story *domain.Story) error {
+	// Generate a new UUID if no ID is provided (for new stories)
+	if story.ID == "" {
+		story.ID = uuid.New().String()
+	}
+
+	story.CreatedAt = now()
+	story.UpdatedAt = story.CreatedAt
+	story.Version = 1
+	story.TenantID = domain.TenantFrom(ctx)
+
+	query := `INSERT INTO stories (id, title, author, author_id, content, created_at, updated_at, version, tenant_id) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`
+	_, err := r.db.ExecContext(ctx, query, story.ID, story.Title, story.Author, story.AuthorID, story.Content, formatTime(story.CreatedAt), formatTime(story.UpdatedAt), story.Version, story.TenantID)
+
+	if err != nil {
+		return fmt.Errorf("sqlite: failed to insert story: %w", err)
+	}
+
+	return saveStoryTags(ctx, r.db, story.ID, story.Tags)
+}
+
+// Implements the logic to save new stories in SQLite, in the tenant of the context.
+// SQLite runs in process, so a statement per story costs no round trip.
+func (r *StoryRepository) SaveStories(ctx context.Context, stories []*domain.Story) error {
+	for _, story := range stories {
+		if err := r.SaveStory(ctx, story); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Columns selected for a story, in the order scanStory reads them.
+var storyColumns = `id, title, author, author_id, content, created_at, updated_at, deleted_at, version, tenant_id, ` + tagsColumn(`stories.id`)
+
+// Abstracts *sql.Row and *sql.Rows.
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+// Reads a story selected with storyColumns, followed by any extra columns into extra.
+func scanStory(row scanner, extra ...any) (*domain.Story, error) {
+	story := &domain.Story{}
+	dest := append([]any{&story.ID, &story.Title, &story.Author, &story.AuthorID, &story.Content, scanTime(&story.CreatedAt), scanTime(&story.UpdatedAt), scanNullTime(&story.DeletedAt), &story.Version, &story.TenantID, scanTags(&story.Tags)}, extra...)
+
+	return story, row.Scan(dest...)
+}
+
+// Implements the logic to find a story by ID in SQLite. Soft-deleted stories are not found.
+func (r *StoryRepository) FindStoryByID(ctx context.Context, id string) (*domain.Story, error) {
+	query := `SELECT ` + storyColumns + ` FROM stories WHERE id = ? AND tenant_id = ? AND deleted_at IS NULL`
+	row := r.db.QueryRowContext(ctx, query, id, domain.TenantFrom(ctx))
+
+	story, err := scanStory(row)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil // Story not found
+		}
+
+		return nil, fmt.Errorf("sqlite: failed to find story by ID (scan error): %w", err)
+	}
+
+	return story, nil
+}
+
+// Maps each whitelisted sort field to its column. Only these values are ever interpolated into SQL.
+var storySortColumns = map[domain.StorySortField]string{
+	domain.StorySortCreatedAt: "created_at",
+	domain.StorySortUpdatedAt: "updated_at",
+	domain.StorySortTitle:     "title",
+	domain.StorySortAuthor:    "author",
+}
+
+// Escapes the LIKE wildcards so user input only ever matches literally.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// Implements the logic to find a filtered, sorted page of stories in SQLite.
+// Uses keyset pagination on (sort column, id), like the PostgreSQL adapter.
+// Soft-deleted stories are left out unless the query includes them.
+func (r *StoryRepository) FindAllStories(ctx context.Context, q domain.StoryQuery) (*domain.Page[domain.Story], error) {
+	limit := q.Page.NormalizedLimit()
+
+	// Fetch one extra row to know whether there is a next page
+	query, args, err := selectStories(domain.TenantFrom(ctx), q, limit+1)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+
+	if err != nil {
+		return nil, fmt.Errorf("sqlite: failed to query all stories: %w", err)
+	}
+
+	defer rows.Close()
+
+	stories := make([]domain.Story, 0, limit+1)
+
+	for rows.Next() {
+		story, err := scanStory(rows)
+
+		if err != nil {
+			return nil, fmt.Errorf("sqlite: failed to scan story row: %w", err)
+		}
+
+		stories = append(stories, *story)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("sqlite: rows iteration error: %w", err)
+	}
+
+	return domain.NewPage(stories, limit, q.Sort.CursorFor), nil
+}
+
+// Implements the logic to stream the stories of a listing in SQLite, with the filters and sort of FindAllStories.
+// Rows are handed to fn one by one as they are read from the cursor; the pagination of the query is ignored.
+// Stops at the first error returned by fn and returns it unwrapped.
+func (r *StoryRepository) StreamStories(ctx context.Context, q domain.StoryQuery, fn func(*domain.Story) error) error {
+	q.Page.Cursor = nil
+
+	query, args, err := selectStories(domain.TenantFrom(ctx), q, 0)
+	if err != nil {
+		return err
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+
+	if err != nil {
+		return fmt.Errorf("sqlite: failed to query stories to stream: %w", err)
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		story, err := scanStory(rows)
+
+		if err != nil {
+			return fmt.Errorf("sqlite: failed to scan story row: %w", err)
+		}
+
+		if err := fn(story); err != nil {
+			return err
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("sqlite: rows iteration error: %w", err)
+	}
+
+	return nil
+}
+
+// Builds the SELECT of a story listing: its filters, its sort and, when limit is positive, its page.
+func selectStories(tenant string, q domain.StoryQuery, limit int) (string, []any, error) {
+	column, ok := storySortColumns[q.Sort.Field]
+	if !ok {
+		return "", nil, fmt.Errorf("sqlite: unsupported story sort field %q", q.Sort.Field)
+	}
+
+	conditions := make([]string, 0, 8)
+	args := make([]any, 0, 9)
+
+	where := func(condition string, values ...any) {
+		conditions = append(conditions, condition)
+		args = append(args, values...)
+	}
+
+	where("tenant_id = ?", tenant)
+
+	if !q.IncludeDeleted {
+		where("deleted_at IS NULL")
+	}
+
+	if q.Author != "" {
+		where("lower(author) = lower(?)", q.Author)
+	}
+
+	if q.AuthorID != "" {
+		where("author_id = ?", q.AuthorID)
+	}
+
+	if len(q.Tags) > 0 {
+		condition, values := tagsCondition(q)
+		where(condition, values...)
+	}
+
+	if q.Title != "" {
+		// LIKE is case-insensitive for ASCII in SQLite
+		where(`title LIKE '%' || ? || '%' ESCAPE '\'`, likeEscaper.Replace(q.Title))
+	}
+
+	if q.CreatedAfter != nil {
+		where("created_at >= ?", formatTime(*q.CreatedAfter))
+	}
+
+	if q.CreatedBefore != nil {
+		where("created_at < ?", formatTime(*q.CreatedBefore))
+	}
+
+	if q.UpdatedAfter != nil {
+		where("updated_at >= ?", formatTime(*q.UpdatedAfter))
+	}
+
+	if q.UpdatedBefore != nil {
+		where("updated_at < ?", formatTime(*q.UpdatedBefore))
+	}
+
+	direction, comparison := "ASC", ">"
+	if q.Sort.Desc {
+		direction, comparison = "DESC", "<"
+	}
+
+	if q.Page.Cursor != nil {
+		value, err := q.Sort.CursorValue(*q.Page.Cursor)
+		if err != nil {
+			return "", nil, fmt.Errorf("sqlite: %w", err)
+		}
+
+		if t, ok := value.(time.Time); ok {
+			value = formatTime(t)
+		}
+
+		where(fmt.Sprintf("(%s, id) %s (?, ?)", column, comparison), value, q.Page.Cursor.ID)
+	}
+
+	query := `SELECT ` + storyColumns + ` FROM stories WHERE ` + strings.Join(conditions, " AND ")
+
+	query += fmt.Sprintf(" ORDER BY %s %s, id %s", column, direction, direction)
+
+	if limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, limit)
+	}
+
+	return query, args, nil
+}
+
+// Implements the full-text search over story titles and content with the FTS5 stories_fts table.
+// Ranked by bm25 with title matches weighing more than content matches.
+func (r *StoryRepository) SearchStories(ctx context.Context, q domain.StorySearchQuery) ([]domain.StorySearchResult, error) {
+	match := ftsMatchExpression(q)
+	if match == "" {
+		return make([]domain.StorySearchResult, 0), nil
+	}
+
+	query := `
+		SELECT s.id, s.title, s.author, s.author_id, s.content, s.created_at, s.updated_at, s.deleted_at, s.version, s.tenant_id, ` + tagsColumn(`s.id`) + `,
+			-bm25(stories_fts, 0.0, 10.0, 4.0) AS rank,
+			snippet(stories_fts, 2, '<mark>', '</mark>', '…', 30) AS snippet
+		FROM stories_fts
+		JOIN stories s ON s.id = stories_fts.id
+		WHERE stories_fts MATCH ? AND s.tenant_id = ? AND s.deleted_at IS NULL
+		ORDER BY rank DESC, s.created_at DESC, s.id DESC
+		LIMIT ? OFFSET ?`
+	rows, err := r.db.QueryContext(ctx, query, match, domain.TenantFrom(ctx), q.Limit, q.Offset)
+
+	if err != nil {
+		return nil, fmt.Errorf("sqlite: failed to search stories: %w", err)
+	}
+
+	defer rows.Close()
+
+	results := make([]domain.StorySearchResult, 0)
+
+	for rows.Next() {
+		result := domain.StorySearchResult{}
+		story, err := scanStory(rows, &result.Rank, &result.Snippet)
+
+		if err != nil {
+			return nil, fmt.Errorf("sqlite: failed to scan story search row: %w", err)
+		}
+
+		result.Story = *story
+
+		results = append(results, result)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("sqlite: rows iteration error: %w", err)
+	}
+
+	return results, nil
+}
+
+// Builds an FTS5 MATCH expression from the search terms, quoting each one so user input is never parsed as syntax.
+// Returns an empty string when there is no term to include.
+func ftsMatchExpression(q domain.StorySearchQuery) string {
+	include, exclude := q.Terms()
+	if len(include) == 0 {
+		return ""
+	}
+
+	quote := func(term string) string {
+		return `"` + strings.ReplaceAll(term, `"`, `""`) + `"`
+	}
+
+	parts := make([]string, 0, len(include))
+	for _, term := range include {
+		parts = append(parts, quote(term))
+	}
+
+	expression := strings.Join(parts, " AND ")
+	for _, term := range exclude {
+		expression += " NOT " + quote(term)
+	}
+
+	return expression
+}
+
+// Implements the logic to update a story in SQLite. Soft-deleted stories cannot be updated.
+// The update only applies if the stored version still equals story.Version, which is then incremented.
+func (r *StoryRepository) UpdateStory(ctx context.Context, story *domain.Story) error {
+	story.UpdatedAt = now() // Update the updated_at column
+
+	query := `UPDATE stories SET title = ?, author = ?, author_id = ?, content = ?, updated_at = ?, version = version + 1 WHERE id = ? AND version = ? AND tenant_id = ? AND deleted_at IS NULL`
+	result, err := r.db.ExecContext(ctx, query, story.Title, story.Author, story.AuthorID, story.Content, formatTime(story.UpdatedAt), story.ID, story.Version, domain.TenantFrom(ctx))
+
+	if err != nil {
+		return fmt.Errorf("sqlite: failed to update story: %w", err)
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return versionMiss(ctx, r.db, "stories", story.ID, domain.ErrStoryNotFound)
+	}
+
+	story.Version++
+	return saveStoryTags(ctx, r.db, story.ID, story.Tags)
+}
+
+// Implements the logic to soft-delete a story in SQLite.
+func (r *StoryRepository) DeleteStory(ctx context.Context, id string) error {
+	query := `UPDATE stories SET deleted_at = ? WHERE id = ? AND tenant_id = ? AND deleted_at IS NULL`
+	result, err := r.db.ExecContext(ctx, query, formatTime(now()), id, domain.TenantFrom(ctx))
+
+	if err != nil {
+		return fmt.Errorf("sqlite: failed to delete story: %w", err)
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return domain.ErrStoryNotFound
+	}
+
+	return nil
+}
+
+// Implements the logic to restore a soft-deleted story in SQLite.
+func (r *StoryRepository) RestoreStory(ctx context.Context, id string) error {
+	query := `UPDATE stories SET deleted_at = NULL WHERE id = ? AND tenant_id = ? AND deleted_at IS NOT NULL`
+	result, err := r.db.ExecContext(ctx, query, id, domain.TenantFrom(ctx))
+
+	if err != nil {
+		return fmt.Errorf("sqlite: failed to restore story: %w", err)
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return domain.ErrStoryNotFound
+	}
+
+	return nil
+}
+
+// Implements the logic to permanently remove the stories soft-deleted before the given time in SQLite.
+// The purge is maintenance of the whole deployment, so it covers every tenant.
+func (r *StoryRepository) PurgeDeletedStories(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	query := `DELETE FROM stories WHERE deleted_at < ?`
+	result, err := r.db.ExecContext(ctx, query, formatTime(deletedBefore))
+
+	if err != nil {
+		return 0, fmt.Errorf("sqlite: failed to purge deleted stories: %w", err)
+	}
+
+	return result.RowsAffected()
+}
+
+// Returns the current time at the precision stored by timeLayout.
+func now() time.Time {
+	return time.Now().UTC().Truncate(time.Microsecond)
+}
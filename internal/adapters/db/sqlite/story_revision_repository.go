@@ -0,0 +1,130 @@
+package sqlite
+
+import (
+	"Gin/internal/core/domain"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// Implements the ports.StoryRevisionDrivenPort interface for SQLite.
+type StoryRevisionRepository struct {
+	db DBTX
+}
+
+// Creates a new instance of StoryRevisionRepository over a database or a transaction.
+func NewStoryRevisionRepository(db DBTX) *StoryRevisionRepository {
+	return &StoryRevisionRepository{db: db}
+}
+
+// Columns selected for a story revision, in the order scanStoryRevision reads them.
+const storyRevisionColumns = `story_id, revision, title, author, author_id, content, created_at`
+
+// Reads a story revision selected with storyRevisionColumns.
+func scanStoryRevision(row scanner) (*domain.StoryRevision, error) {
+	revision := &domain.StoryRevision{}
+	err := row.Scan(&revision.StoryID, &revision.Revision, &revision.Title, &revision.Author, &revision.AuthorID, &revision.Content, scanTime(&revision.CreatedAt))
+
+	return revision, err
+}
+
+// Implements the logic to save a story revision in SQLite, numbered after the latest revision of the story.
+// Run it in a transaction with the story update, which holds the database write lock.
+// Returns domain.ErrStoryNotFound if the story is not one of the tenant.
+func (r *StoryRevisionRepository) SaveRevision(ctx context.Context, revision *domain.StoryRevision) error {
+	revision.CreatedAt = now()
+
+	query := `
+		INSERT INTO story_revisions (story_id, revision, title, author, author_id, content, created_at)
+		SELECT ?1, COALESCE(MAX(revision), 0) + 1, ?2, ?3, ?4, ?5, ?6 FROM story_revisions WHERE story_id = ?1
+		HAVING EXISTS (SELECT 1 FROM stories WHERE id = ?1 AND tenant_id = ?7)
+		RETURNING revision`
+	err := r.db.QueryRowContext(ctx, query, revision.StoryID, revision.Title, revision.Author, revision.AuthorID, revision.Content, formatTime(revision.CreatedAt), domain.TenantFrom(ctx)).
+		Scan(&revision.Revision)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.ErrStoryNotFound
+	}
+
+	if err != nil {
+		return fmt.Errorf("sqlite: failed to insert story revision: %w", err)
+	}
+
+	return nil
+}
+
+// Implements the logic to find a revision of a story in SQLite.
+func (r *StoryRevisionRepository) FindRevision(ctx context.Context, storyID string, revision int) (*domain.StoryRevision, error) {
+	query := `SELECT ` + storyRevisionColumns + ` FROM story_revisions WHERE story_id = ? AND revision = ? AND ` + storyTenantCondition
+	found, err := scanStoryRevision(r.db.QueryRowContext(ctx, query, storyID, revision, domain.TenantFrom(ctx)))
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil // Revision not found
+		}
+
+		return nil, fmt.Errorf("sqlite: failed to find story revision (scan error): %w", err)
+	}
+
+	return found, nil
+}
+
+// Implements the logic to find a page of the revisions of a story in SQLite, newest first.
+func (r *StoryRevisionRepository) FindRevisions(ctx context.Context, storyID string, page domain.PageRequest) (*domain.Page[domain.StoryRevision], error) {
+	limit := page.NormalizedLimit()
+
+	query := `SELECT ` + storyRevisionColumns + ` FROM story_revisions WHERE story_id = ? AND ` + storyTenantCondition
+	args := []any{storyID, domain.TenantFrom(ctx)}
+
+	if page.Cursor != nil {
+		before, err := page.Cursor.RevisionValue(storyID)
+		if err != nil {
+			return nil, fmt.Errorf("sqlite: %w", err)
+		}
+		query += ` AND revision < ?`
+		args = append(args, before)
+	}
+
+	// One extra row tells us whether there is a next page
+	query += ` ORDER BY revision DESC LIMIT ?`
+	args = append(args, limit+1)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+
+	if err != nil {
+		return nil, fmt.Errorf("sqlite: failed to query story revisions: %w", err)
+	}
+
+	defer rows.Close()
+
+	revisions := make([]domain.StoryRevision, 0, limit+1)
+
+	for rows.Next() {
+		revision, err := scanStoryRevision(rows)
+
+		if err != nil {
+			return nil, fmt.Errorf("sqlite: failed to scan story revision row: %w", err)
+		}
+
+		revisions = append(revisions, *revision)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("sqlite: rows iteration error: %w", err)
+	}
+
+	return domain.NewPage(revisions, limit, domain.StoryRevisionCursor), nil
+}
+
+// Implements the logic to find the number of the latest revision of a story in SQLite.
+func (r *StoryRevisionRepository) LatestRevision(ctx context.Context, storyID string) (int, error) {
+	var latest int
+
+	query := `SELECT COALESCE(MAX(revision), 0) FROM story_revisions WHERE story_id = ? AND ` + storyTenantCondition
+	if err := r.db.QueryRowContext(ctx, query, storyID, domain.TenantFrom(ctx)).Scan(&latest); err != nil {
+		return 0, fmt.Errorf("sqlite: failed to find latest story revision: %w", err)
+	}
+
+	return latest, nil
+}
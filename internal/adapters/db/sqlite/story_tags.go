@@ -0,0 +1,123 @@
+package sqlite
+
+import (
+	"Gin/internal/core/domain"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Selects the comma-separated tag names of the story whose ID is in the given column, read by scanTags.
+func tagsColumn(storyID string) string {
+	return `(SELECT group_concat(t.name, ',') FROM story_tags st JOIN tags t ON t.id = st.tag_id WHERE st.story_id = ` + storyID + `) AS tags`
+}
+
+// Wraps a []string so it can be scanned from the comma-separated tag names selected by tagsColumn.
+type tagList struct {
+	tags *[]string
+}
+
+// Returns a scanner reading the tag names, or NULL for a story without tags, into tags.
+func scanTags(tags *[]string) tagList {
+	return tagList{tags: tags}
+}
+
+// Implements sql.Scanner. Tag names never contain commas, as they are normalized slugs.
+func (l tagList) Scan(src any) error {
+	var value string
+
+	switch src := src.(type) {
+	case nil:
+		*l.tags = make([]string, 0)
+		return nil
+	case string:
+		value = src
+	case []byte:
+		value = string(src)
+	default:
+		return fmt.Errorf("sqlite: cannot scan %T into tags", src)
+	}
+
+	tags := strings.Split(value, ",")
+	slices.Sort(tags) // group_concat does not guarantee an order
+
+	*l.tags = tags
+	return nil
+}
+
+// Replaces the tags of a story, creating the tags that do not exist yet.
+// Run it in the same transaction as the write of the story.
+func saveStoryTags(ctx context.Context, db DBTX, storyID string, tags []string) error {
+	if _, err := db.ExecContext(ctx, `DELETE FROM story_tags WHERE story_id = ?`, storyID); err != nil {
+		return fmt.Errorf("sqlite: failed to clear story tags: %w", err)
+	}
+
+	for _, tag := range tags {
+		if _, err := db.ExecContext(ctx, `INSERT INTO tags (name, created_at) VALUES (?, ?) ON CONFLICT (name) DO NOTHING`, tag, formatTime(now())); err != nil {
+			return fmt.Errorf("sqlite: failed to insert tag: %w", err)
+		}
+
+		if _, err := db.ExecContext(ctx, `INSERT INTO story_tags (story_id, tag_id) SELECT ?, id FROM tags WHERE name = ?`, storyID, tag); err != nil {
+			return fmt.Errorf("sqlite: failed to insert story tag: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Returns the condition keeping the stories with any or all of the tags of the query, and its arguments.
+func tagsCondition(q domain.StoryQuery) (string, []any) {
+	args := make([]any, 0, len(q.Tags)+1)
+	for _, tag := range q.Tags {
+		args = append(args, tag)
+	}
+
+	condition := `id IN (SELECT st.story_id FROM story_tags st JOIN tags t ON t.id = st.tag_id WHERE t.name IN (` +
+		strings.TrimSuffix(strings.Repeat("?, ", len(q.Tags)), ", ") + `)`
+
+	if q.TagMatch == domain.TagMatchAll {
+		condition += ` GROUP BY st.story_id HAVING count(*) = ?`
+		args = append(args, len(q.Tags))
+	}
+
+	return condition + `)`, args
+}
+
+// Implements the logic to find the tags used by stories in SQLite, with the number of stories using each one.
+// Deleted stories are not counted, and tags without stories are left out. Most used tags first.
+func (r *StoryRepository) FindTags(ctx context.Context) ([]domain.TagUsage, error) {
+	query := `
+		SELECT t.name, count(*) AS stories
+		FROM tags t
+		JOIN story_tags st ON st.tag_id = t.id
+		JOIN stories s ON s.id = st.story_id
+		WHERE s.tenant_id = ? AND s.deleted_at IS NULL
+		GROUP BY t.name
+		ORDER BY stories DESC, t.name ASC`
+	rows, err := r.db.QueryContext(ctx, query, domain.TenantFrom(ctx))
+
+	if err != nil {
+		return nil, fmt.Errorf("sqlite: failed to query tags: %w", err)
+	}
+
+	defer rows.Close()
+
+	tags := make([]domain.TagUsage, 0)
+
+	for rows.Next() {
+		var tag domain.TagUsage
+
+		if err := rows.Scan(&tag.Name, &tag.Stories); err != nil {
+			return nil, fmt.Errorf("sqlite: failed to scan tag row: %w", err)
+		}
+
+		tags = append(tags, tag)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("sqlite: rows iteration error: %w", err)
+	}
+
+	return tags, nil
+}
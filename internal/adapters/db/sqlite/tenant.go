@@ -0,0 +1,5 @@
+package sqlite
+
+// Condition keeping the rows of a table with a story_id column whose story belongs to the tenant bound to the next ?.
+// Story revisions and comments have no tenant of their own: they belong to the tenant of their story.
+const storyTenantCondition = `story_id IN (SELECT id FROM stories WHERE tenant_id = ?)`
@@ -0,0 +1,84 @@
+package sqlite
+
+import (
+	"fmt"
+	"time"
+)
+
+// Timestamps are stored as fixed-width UTC text so that they sort chronologically as strings.
+const timeLayout = "2006-01-02T15:04:05.000000Z"
+
+// Wraps a time.Time so it can be scanned from a timestamp stored in timeLayout.
+type timestamp struct {
+	t *time.Time
+}
+
+// Returns the argument storing t as a sortable timestamp.
+func formatTime(t time.Time) string {
+	return t.UTC().Format(timeLayout)
+}
+
+// Returns the argument storing t as a sortable timestamp, or NULL when t is nil.
+func formatNullTime(t *time.Time) any {
+	if t == nil {
+		return nil
+	}
+
+	return formatTime(*t)
+}
+
+// Returns a scanner reading a stored timestamp into t.
+func scanTime(t *time.Time) timestamp {
+	return timestamp{t: t}
+}
+
+// Implements sql.Scanner.
+func (ts timestamp) Scan(src any) error {
+	switch value := src.(type) {
+	case string:
+		return ts.parse(value)
+	case []byte:
+		return ts.parse(string(value))
+	case time.Time:
+		*ts.t = value.UTC()
+		return nil
+	}
+
+	return fmt.Errorf("sqlite: cannot scan %T into a timestamp", src)
+}
+
+func (ts timestamp) parse(value string) error {
+	t, err := time.Parse(timeLayout, value)
+	if err != nil {
+		return fmt.Errorf("sqlite: invalid timestamp %q: %w", value, err)
+	}
+
+	*ts.t = t
+	return nil
+}
+
+// Wraps a *time.Time so it can be scanned from a nullable timestamp stored in timeLayout.
+type nullTimestamp struct {
+	t **time.Time
+}
+
+// Returns a scanner reading a stored timestamp, or NULL, into t.
+func scanNullTime(t **time.Time) nullTimestamp {
+	return nullTimestamp{t: t}
+}
+
+// Implements sql.Scanner.
+func (ts nullTimestamp) Scan(src any) error {
+	if src == nil {
+		*ts.t = nil
+		return nil
+	}
+
+	t := new(time.Time)
+	if err := scanTime(t).Scan(src); err != nil {
+		return err
+	}
+
+	*ts.t = t
+	return nil
+}
@@ -0,0 +1,98 @@
+package sqlite
+
+import (
+	"Gin/internal/core/ports"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+// Number of attempts made for a transaction that keeps finding the database busy.
+const maxTxAttempts = 3
+
+// Abstracts *sql.DB and *sql.Tx so repositories can run inside or outside a transaction.
+type DBTX interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// Implements the ports.UnitOfWork interface with SQLite transactions.
+// SQLite allows a single writer at a time, so transactions are always serializable.
+type UnitOfWork struct {
+	db *sql.DB
+}
+
+// Creates a new instance of UnitOfWork.
+func NewUnitOfWork(db *sql.DB) *UnitOfWork {
+	return &UnitOfWork{db: db}
+}
+
+// Implements the logic to run fn in a transaction, retrying while the database is busy.
+func (u *UnitOfWork) Execute(ctx context.Context, fn func(ctx context.Context, repos ports.Repositories) error) error {
+	var err error
+
+	for attempt := 1; attempt <= maxTxAttempts; attempt++ {
+		if err = u.executeOnce(ctx, fn); err == nil || !isRetryable(err) {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Duration(attempt*attempt) * 10 * time.Millisecond):
+		}
+	}
+
+	return err
+}
+
+// Runs fn in a single transaction attempt.
+func (u *UnitOfWork) executeOnce(ctx context.Context, fn func(ctx context.Context, repos ports.Repositories) error) error {
+	tx, err := u.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("sqlite: failed to begin transaction: %w", err)
+	}
+
+	repos := ports.Repositories{
+		Stories:        NewStoryRepository(tx),
+		StoryRevisions: NewStoryRevisionRepository(tx),
+		Users:          NewUserRepository(tx),
+		Comments:       NewCommentRepository(tx),
+		Outbox:         NewOutboxRepository(tx),
+		Audit:          NewAuditRepository(tx),
+	}
+
+	if err := fn(ctx, repos); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("sqlite: failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// Reports whether the error means the database was busy or locked, after which the transaction can be retried.
+func isRetryable(err error) bool {
+	var sqliteErr *sqlite.Error
+	if errors.As(err, &sqliteErr) {
+		code := sqliteErr.Code() & 0xff // Strip the extended result code
+		return code == sqlite3.SQLITE_BUSY || code == sqlite3.SQLITE_LOCKED
+	}
+
+	return false
+}
+
+// Reports whether the error is a unique constraint violation.
+func isUniqueViolation(err error) bool {
+	var sqliteErr *sqlite.Error
+	return errors.As(err, &sqliteErr) && sqliteErr.Code() == sqlite3.SQLITE_CONSTRAINT_UNIQUE
+}
@@ -0,0 +1,225 @@
+package sqlite
+
+import (
+	"Gin/internal/core/domain"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Implements the ports.UserDrivenPort interface for SQLite.
+type UserRepository struct {
+	db DBTX
+}
+
+// Creates a new instance of UserRepository over a database or a transaction.
+func NewUserRepository(db DBTX) *UserRepository {
+	return &UserRepository{db: db}
+}
+
+// Columns selected for a user, in the order scanUser reads them.
+const userColumns = `id, email, name, created_at, updated_at, deleted_at, version, tenant_id`
+
+// Reads a user selected with userColumns.
+func scanUser(row scanner) (*domain.User, error) {
+	user := &domain.User{}
+	err := row.Scan(&user.ID, &user.Email, &user.Name, scanTime(&user.CreatedAt), scanTime(&user.UpdatedAt), scanNullTime(&user.DeletedAt), &user.Version, &user.TenantID)
+
+	return user, err
+}
+
+// Implements the logic to save a user to SQLite, in the tenant of the context.
+func (r *UserRepository) SaveUser(ctx context.Context, user *domain.User) error {
+	user.TenantID = domain.TenantFrom(ctx)
+
+	query := `INSERT INTO users (id, email, name, created_at, updated_at, version, tenant_id) VALUES (?, ?, ?, ?, ?, ?, ?)`
+
+	_, err := r.db.ExecContext(ctx, query, user.ID, user.Email, user.Name, formatTime(user.CreatedAt), formatTime(user.UpdatedAt), user.Version, user.TenantID)
+	if err != nil {
+		return fmt.Errorf("sqlite: failed to insert user: %w", err)
+	}
+	return nil
+}
+
+// Implements the logic to find a user by ID in SQLite. Soft-deleted users are not found.
+func (r *UserRepository) FindUserByID(ctx context.Context, id string) (*domain.User, error) {
+	query := `SELECT ` + userColumns + ` FROM users WHERE id = ? AND tenant_id = ? AND deleted_at IS NULL`
+	row := r.db.QueryRowContext(ctx, query, id, domain.TenantFrom(ctx))
+
+	user, err := scanUser(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil // User not found
+		}
+		return nil, fmt.Errorf("sqlite: failed to find user by ID (scan error): %w", err)
+	}
+	return user, nil
+}
+
+// Implements the logic to find a page of users in SQLite.
+// Uses keyset pagination on (created_at, id), newest users first.
+// Soft-deleted users are left out unless the query includes them.
+func (r *UserRepository) FindAllUsers(ctx context.Context, q domain.UserQuery) (*domain.Page[domain.User], error) {
+	limit := q.Page.NormalizedLimit()
+
+	// One extra row tells us whether there is a next page
+	query, args, err := selectUsers(domain.TenantFrom(ctx), q, limit+1)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("sqlite: failed to query all users: %w", err)
+	}
+	defer rows.Close()
+
+	users := make([]domain.User, 0, limit+1)
+
+	for rows.Next() {
+		user, err := scanUser(rows)
+		if err != nil {
+			return nil, fmt.Errorf("sqlite: failed to scan user row: %w", err)
+		}
+		users = append(users, *user)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("sqlite: rows iteration error: %w", err)
+	}
+	return domain.NewPage(users, limit, userCursor), nil
+}
+
+// Implements the logic to stream users in SQLite, newest first like FindAllUsers.
+// Rows are handed to fn one by one as they are read from the cursor; the pagination of the query is ignored.
+// Stops at the first error returned by fn and returns it unwrapped.
+func (r *UserRepository) StreamUsers(ctx context.Context, q domain.UserQuery, fn func(*domain.User) error) error {
+	q.Page.Cursor = nil
+
+	query, args, err := selectUsers(domain.TenantFrom(ctx), q, 0)
+	if err != nil {
+		return err
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("sqlite: failed to query users to stream: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		user, err := scanUser(rows)
+		if err != nil {
+			return fmt.Errorf("sqlite: failed to scan user row: %w", err)
+		}
+
+		if err := fn(user); err != nil {
+			return err
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("sqlite: rows iteration error: %w", err)
+	}
+	return nil
+}
+
+// Builds the SELECT of a user listing of a tenant: its filters, its order and, when limit is positive, its page.
+func selectUsers(tenant string, q domain.UserQuery, limit int) (string, []any, error) {
+	conditions := []string{`tenant_id = ?`}
+	args := []any{tenant}
+
+	if !q.IncludeDeleted {
+		conditions = append(conditions, `deleted_at IS NULL`)
+	}
+
+	if q.Page.Cursor != nil {
+		createdAt, err := q.Page.Cursor.TimeValue()
+		if err != nil {
+			return "", nil, fmt.Errorf("sqlite: %w", err)
+		}
+		conditions = append(conditions, `(created_at, id) < (?, ?)`)
+		args = append(args, formatTime(createdAt), q.Page.Cursor.ID)
+	}
+
+	query := `SELECT ` + userColumns + ` FROM users WHERE ` + strings.Join(conditions, ` AND `) + ` ORDER BY created_at DESC, id DESC`
+
+	if limit > 0 {
+		query += ` LIMIT ?`
+		args = append(args, limit)
+	}
+
+	return query, args, nil
+}
+
+// Returns the pagination cursor pointing at the given user.
+func userCursor(user domain.User) domain.Cursor {
+	return domain.NewTimeCursor("", user.CreatedAt, user.ID)
+}
+
+// Implements the logic to update an existing user in SQLite. Soft-deleted users cannot be updated.
+// The update only applies if the stored version still equals user.Version, which is then incremented.
+func (r *UserRepository) UpdateUser(ctx context.Context, user *domain.User) error {
+	query := `UPDATE users SET email = ?, name = ?, updated_at = ?, version = version + 1 WHERE id = ? AND version = ? AND tenant_id = ? AND deleted_at IS NULL`
+	result, err := r.db.ExecContext(ctx, query, user.Email, user.Name, formatTime(user.UpdatedAt), user.ID, user.Version, domain.TenantFrom(ctx))
+	if err != nil {
+		return fmt.Errorf("sqlite: failed to update user: %w", err)
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return versionMiss(ctx, r.db, "users", user.ID, domain.ErrUserNotFound)
+	}
+
+	user.Version++
+	return nil
+}
+
+// Implements the logic to soft-delete a user in SQLite.
+func (r *UserRepository) DeleteUser(ctx context.Context, id string) error {
+	query := `UPDATE users SET deleted_at = ? WHERE id = ? AND tenant_id = ? AND deleted_at IS NULL`
+	result, err := r.db.ExecContext(ctx, query, formatTime(now()), id, domain.TenantFrom(ctx))
+	if err != nil {
+		return fmt.Errorf("sqlite: failed to delete user: %w", err)
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return domain.ErrUserNotFound
+	}
+	return nil
+}
+
+// Implements the logic to restore a soft-deleted user in SQLite.
+// Returns domain.ErrEmailInUse if an active user took the email in the meantime.
+func (r *UserRepository) RestoreUser(ctx context.Context, id string) error {
+	query := `UPDATE users SET deleted_at = NULL WHERE id = ? AND tenant_id = ? AND deleted_at IS NOT NULL`
+	result, err := r.db.ExecContext(ctx, query, id, domain.TenantFrom(ctx))
+	if err != nil {
+		if isUniqueViolation(err) {
+			return domain.ErrEmailInUse
+		}
+		return fmt.Errorf("sqlite: failed to restore user: %w", err)
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return domain.ErrUserNotFound
+	}
+	return nil
+}
+
+// Implements the logic to permanently remove the users soft-deleted before the given time in SQLite.
+// The purge is maintenance of the whole deployment, so it covers every tenant.
+func (r *UserRepository) PurgeDeletedUsers(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	query := `DELETE FROM users WHERE deleted_at < ? AND NOT EXISTS (SELECT 1 FROM stories WHERE stories.author_id = users.id)`
+	result, err := r.db.ExecContext(ctx, query, formatTime(deletedBefore))
+	if err != nil {
+		return 0, fmt.Errorf("sqlite: failed to purge deleted users: %w", err)
+	}
+
+	return result.RowsAffected()
+}
@@ -0,0 +1,25 @@
+package sqlite
+
+import (
+	"Gin/internal/core/domain"
+	"context"
+	"fmt"
+)
+
+// Explains why a versioned update of a row in table matched nothing: the row is gone (notFound)
+// or it has been changed since it was read (domain.ErrVersionConflict).
+// table is always a constant, never user input, and has a tenant_id column: rows of other tenants are gone.
+func versionMiss(ctx context.Context, db DBTX, table, id string, notFound error) error {
+	var exists bool
+
+	query := fmt.Sprintf(`SELECT EXISTS (SELECT 1 FROM %s WHERE id = ? AND tenant_id = ? AND deleted_at IS NULL)`, table)
+	if err := db.QueryRowContext(ctx, query, id, domain.TenantFrom(ctx)).Scan(&exists); err != nil {
+		return fmt.Errorf("sqlite: failed to check %s row: %w", table, err)
+	}
+
+	if !exists {
+		return notFound
+	}
+
+	return domain.ErrVersionConflict
+}
@@ -0,0 +1,140 @@
+package events
+
+import (
+	"Gin/internal/core/domain"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"sync"
+)
+
+// Number of events an asynchronous subscriber can fall behind before Deliver waits for it.
+const asyncQueueSize = 1024
+
+// Reacts to a domain event.
+type Handler func(ctx context.Context, event domain.Event) error
+
+// Implements the ports.EventSink interface with in-process subscribers.
+// Synchronous subscribers run in the delivering goroutine, before Deliver returns; when one fails,
+// Deliver fails and the outbox delivers the event again later, so handlers must tolerate duplicates.
+// Asynchronous subscribers each get their own goroutine and receive the events in delivery order;
+// their errors are only logged.
+type Bus struct {
+	mu          sync.RWMutex
+	subscribers []*subscriber
+	closed      bool
+	workers     sync.WaitGroup
+}
+
+// Represents a subscriber and the event types it listens to.
+type subscriber struct {
+	name    string // Used in logs
+	types   []domain.EventType
+	handler Handler
+	queue   chan delivery // nil for synchronous subscribers
+}
+
+// Represents an event waiting for an asynchronous subscriber.
+type delivery struct {
+	ctx   context.Context
+	event domain.Event
+}
+
+// Creates a new instance of Bus without subscribers.
+func NewBus() *Bus {
+	return &Bus{}
+}
+
+// Registers a handler run synchronously for the given event types, or for every event when none is given.
+func (b *Bus) Subscribe(name string, handler Handler, types ...domain.EventType) {
+	b.add(&subscriber{name: name, types: types, handler: handler})
+}
+
+// Registers a handler run in the background for the given event types, or for every event when none is given.
+func (b *Bus) SubscribeAsync(name string, handler Handler, types ...domain.EventType) {
+	sub := &subscriber{name: name, types: types, handler: handler, queue: make(chan delivery, asyncQueueSize)}
+
+	b.workers.Add(1)
+	go func() {
+		defer b.workers.Done()
+
+		for d := range sub.queue {
+			if err := sub.handle(d.ctx, d.event); err != nil {
+				log.Printf("Event subscriber %s failed on %s: %v", sub.name, d.event.EventType(), err)
+			}
+		}
+	}()
+
+	b.add(sub)
+}
+
+// Appends a subscriber.
+func (b *Bus) add(sub *subscriber) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.subscribers = append(b.subscribers, sub)
+}
+
+// Implements the logic to hand an event to its subscribers, returning the errors of the synchronous ones.
+// Asynchronous subscribers get a context that outlives the caller, so they are not cancelled with it.
+func (b *Bus) Deliver(ctx context.Context, event domain.Event) error {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	var errs []error
+
+	for _, sub := range b.subscribers {
+		if len(sub.types) > 0 && !slices.Contains(sub.types, event.EventType()) {
+			continue
+		}
+
+		switch {
+		case sub.queue == nil:
+			if err := sub.handle(ctx, event); err != nil {
+				errs = append(errs, fmt.Errorf("event subscriber %s: %w", sub.name, err))
+			}
+		case b.closed:
+			log.Printf("Event %s not delivered to %s: the event bus is closed", event.EventType(), sub.name)
+		default:
+			sub.queue <- delivery{ctx: context.WithoutCancel(ctx), event: event}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Stops the asynchronous subscribers once they have handled the events already delivered.
+func (b *Bus) Close() {
+	b.mu.Lock()
+	if !b.closed {
+		b.closed = true
+		for _, sub := range b.subscribers {
+			if sub.queue != nil {
+				close(sub.queue)
+			}
+		}
+	}
+	b.mu.Unlock()
+
+	b.workers.Wait()
+}
+
+// Runs the handler, turning a panic into an error so one subscriber cannot break the others or the relay.
+func (s *subscriber) handle(ctx context.Context, event domain.Event) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panicked: %v", r)
+		}
+	}()
+
+	return s.handler(ctx, event)
+}
+
+// Logs every event it receives, for debugging.
+func LogHandler(ctx context.Context, event domain.Event) error {
+	log.Printf("Event %s: %+v", event.EventType(), event)
+	return nil
+}
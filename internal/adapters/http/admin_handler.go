@@ -0,0 +1,156 @@
+package http
+
+import (
+	"Gin/internal/core/domain"
+	"Gin/internal/core/ports"
+	"Gin/pkg/util"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Handles the operational endpoints intended for administrators.
+type AdminHandler struct {
+	dbStats    ports.DatabaseStatsPort // nil when the storage driver has no database
+	cacheStats ports.CacheStatsPort    // nil when caching is disabled
+	outbox     ports.OutboxDrivingPort
+}
+
+// Creates a new instance of AdminHandler. The stats ports may be nil when the feature they report on is off.
+func NewAdminHandler(dbStats ports.DatabaseStatsPort, cacheStats ports.CacheStatsPort, outbox ports.OutboxDrivingPort) *AdminHandler {
+	return &AdminHandler{dbStats: dbStats, cacheStats: cacheStats, outbox: outbox}
+}
+
+// GetDatabaseStats godoc
+// @Summary Get database connection pool statistics
+// @Description Reports the connection pool of the primary database and of each read replica:
+// @Description open, in-use and idle connections, how often and how long requests waited for a connection,
+// @Description and, for replicas, the outcome of the last health check.
+// @Tags admin
+// @Produce json
+// @Success 200 {object} map[string][]domain.DBPoolStats
+// @Failure 404 {object} map[string]string "The storage driver has no database"
+// @Router /admin/db/stats [get]
+func (h *AdminHandler) GetDatabaseStats(c *gin.Context) {
+	if h.dbStats == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "The storage driver has no database connection pool"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"pools": h.dbStats.DatabaseStats()})
+}
+
+// GetCacheStats godoc
+// @Summary Get cache statistics
+// @Description Reports the size, hits, misses and evictions of the in-process story caches.
+// @Tags admin
+// @Produce json
+// @Success 200 {object} map[string][]domain.CacheStats
+// @Failure 404 {object} map[string]string "Caching is disabled"
+// @Router /admin/cache/stats [get]
+func (h *AdminHandler) GetCacheStats(c *gin.Context) {
+	if h.cacheStats == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Caching is disabled"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"caches": h.cacheStats.CacheStats()})
+}
+
+// GetOutboxEntries godoc
+// @Summary List outbox entries
+// @Description Retrieves a page of the domain events of the outbox with the given status, oldest first,
+// @Description together with the number of pending and failed events.
+// @Description Pending events wait for their first or next delivery attempt, failed events have run out of attempts.
+// @Tags admin
+// @Produce json
+// @Param status query string false "pending (default), failed or delivered"
+// @Param limit query int false "Maximum number of entries to return (1-100, default 20)"
+// @Param cursor query string false "Opaque cursor returned as next_cursor by the previous page"
+// @Success 200 {object} domain.Page[domain.OutboxEntry]
+// @Failure 400 {object} map[string]string "Invalid query parameters"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /admin/outbox [get]
+func (h *AdminHandler) GetOutboxEntries(c *gin.Context) {
+	page, err := parsePageRequest(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid query parameters", "details": err.Error()})
+		return
+	}
+
+	query := domain.OutboxQuery{Status: domain.OutboxStatus(c.Query("status")), Page: page}
+	entries, err := h.outbox.GetOutboxEntries(c.Request.Context(), query)
+
+	if err != nil {
+		h.outboxError(c, err, "Failed to retrieve outbox entries")
+		return
+	}
+
+	counts, err := h.outbox.GetOutboxCounts(c.Request.Context())
+
+	if err != nil {
+		h.outboxError(c, err, "Failed to count outbox entries")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"items":       entries.Items,
+		"next_cursor": entries.NextCursor,
+		"has_more":    entries.HasMore,
+		"pending":     counts.Pending,
+		"failed":      counts.Failed,
+	})
+}
+
+// RetryOutboxEntry godoc
+// @Summary Retry a failed outbox entry
+// @Description Gives a domain event that ran out of delivery attempts a fresh set of attempts, starting with the next relay run.
+// @Tags admin
+// @Produce json
+// @Param id path int true "Outbox entry ID"
+// @Success 200 {object} domain.OutboxEntry
+// @Failure 400 {object} map[string]string "Invalid ID"
+// @Failure 404 {object} map[string]string "Outbox entry not found"
+// @Failure 409 {object} map[string]string "Outbox entry is not failed"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /admin/outbox/{id}/retry [post]
+func (h *AdminHandler) RetryOutboxEntry(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id < 1 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID", "details": "id must be a positive integer"})
+		return
+	}
+
+	entry, err := h.outbox.RetryOutboxEntry(c.Request.Context(), id)
+
+	if err != nil {
+		h.outboxError(c, err, "Failed to retry outbox entry")
+		return
+	}
+
+	c.JSON(http.StatusOK, entry)
+}
+
+// Responds to a failed outbox request: 400 for invalid input, 404 for a missing entry,
+// 409 for an entry that cannot be retried and 500 otherwise.
+func (h *AdminHandler) outboxError(c *gin.Context, err error, message string) {
+	var validationErr *util.ValidationError
+	if errors.As(err, &validationErr) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid query parameters", "details": validationErr.Message})
+		return
+	}
+
+	if isNotFound(err) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Outbox entry not found", "details": err.Error()})
+		return
+	}
+
+	if isConflict(err) {
+		c.JSON(http.StatusConflict, gin.H{"error": "Outbox entry cannot be retried", "details": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusInternalServerError, gin.H{"error": message, "details": err.Error()})
+}
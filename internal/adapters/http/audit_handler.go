@@ -0,0 +1,108 @@
+package http
+
+import (
+	"Gin/internal/core/domain"
+	"Gin/internal/core/ports"
+	"Gin/pkg/util"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Handles the HTTP requests related to the audit log.
+type AuditHandler struct {
+	auditService ports.AuditDrivingPort
+}
+
+// Creates a new instance of AuditHandler.
+func NewAuditHandler(auditService ports.AuditDrivingPort) *AuditHandler {
+	return &AuditHandler{auditService: auditService}
+}
+
+// GetAuditEvents godoc
+// @Summary List the audit log
+// @Description Retrieves a page of the changes made to stories and users, newest first, each with its actor,
+// @Description request ID, client IP and the before and after values of the fields it changed.
+// @Tags audit
+// @Produce json
+// @Param entity_type query string false "Only changes to this type of entity (story or user)"
+// @Param entity_id query string false "Only changes to this entity; requires entity_type"
+// @Param actor query string false "Only changes made by this actor"
+// @Param from query string false "Only changes made at or after this time (RFC 3339 or YYYY-MM-DD)"
+// @Param to query string false "Only changes made before this time (RFC 3339 or YYYY-MM-DD)"
+// @Param limit query int false "Maximum number of events to return (1-100, default 20)"
+// @Param cursor query string false "Opaque cursor returned as next_cursor by the previous page"
+// @Success 200 {object} domain.Page[domain.AuditEvent]
+// @Failure 400 {object} map[string]string "Invalid query parameters"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /audit [get]
+func (h *AuditHandler) GetAuditEvents(c *gin.Context) {
+	query, err := parseAuditQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid query parameters", "details": err.Error()})
+		return
+	}
+
+	events, err := h.auditService.GetAuditEvents(c.Request.Context(), query)
+
+	if err != nil {
+		var validationErr *util.ValidationError
+		if errors.As(err, &validationErr) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid query parameters", "details": validationErr.Message})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve audit events", "details": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, events)
+}
+
+// Parses the filter and pagination query parameters of GET /audit into a domain.AuditQuery.
+func parseAuditQuery(c *gin.Context) (domain.AuditQuery, error) {
+	query := domain.AuditQuery{
+		EntityType: domain.AuditEntity(strings.TrimSpace(c.Query("entity_type"))),
+		EntityID:   strings.TrimSpace(c.Query("entity_id")),
+		Actor:      strings.TrimSpace(c.Query("actor")),
+	}
+
+	var err error
+
+	if query.From, err = parseTimeParam(c, "from"); err != nil {
+		return query, err
+	}
+
+	if query.To, err = parseTimeParam(c, "to"); err != nil {
+		return query, err
+	}
+
+	if query.Page, err = parsePageRequest(c); err != nil {
+		return query, err
+	}
+
+	return query, nil
+}
+
+// VerifyAudit godoc
+// @Summary Verify the audit chain
+// @Description Walks the hash chain of the audit log from its first event to its latest, checking each hash and
+// @Description its link to the previous event, and matches it against the signed checkpoints when a signing key is set.
+// @Description Reports the first broken link; valid is false when there is one.
+// @Tags audit
+// @Produce json
+// @Success 200 {object} domain.AuditVerification
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /audit/verify [get]
+func (h *AuditHandler) VerifyAudit(c *gin.Context) {
+	verification, err := h.auditService.VerifyAudit(c.Request.Context(), nil)
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to verify the audit chain", "details": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, verification)
+}
@@ -0,0 +1,176 @@
+package http
+
+import (
+	"Gin/internal/core/domain"
+	"Gin/internal/core/ports"
+	"Gin/pkg/util"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+// Handles the HTTP requests related to the comments on stories.
+type CommentHandler struct {
+	commentService ports.CommentDrivingPort
+	validate       *validator.Validate
+}
+
+// Creates a new instance of CommentHandler.
+func NewCommentHandler(commentService ports.CommentDrivingPort) *CommentHandler {
+	return &CommentHandler{
+		commentService: commentService,
+		validate:       validator.New(),
+	}
+}
+
+// CreateComment godoc
+// @Summary Comment on a story
+// @Description Creates a comment on a story, or a reply to one of its top-level comments when parent_id is set.
+// @Description Replies cannot be replied to.
+// @Tags comments
+// @Accept json
+// @Produce json
+// @Param id path string true "Story ID"
+// @Param comment body domain.NewCommentInput true "Comment creation object"
+// @Success 201 {object} domain.Comment
+// @Failure 400 {object} map[string]string "Invalid input, unknown author or parent comment"
+// @Failure 404 {object} map[string]string "Story not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /stories/{id}/comments [post]
+func (h *CommentHandler) CreateComment(c *gin.Context) {
+	var input domain.NewCommentInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return
+	}
+
+	if err := h.validate.Struct(input); err != nil {
+		validationErrors := err.(validator.ValidationErrors)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Validation failed", "details": validationErrors.Error()})
+		return
+	}
+
+	comment, err := h.commentService.CreateComment(c.Request.Context(), c.Param("id"), &input)
+
+	if err != nil {
+		h.commentError(c, err, "Story not found", "Failed to create comment")
+		return
+	}
+
+	c.JSON(http.StatusCreated, comment)
+}
+
+// GetComments godoc
+// @Summary List the comments on a story
+// @Description Retrieves a page of the top-level comments on a story, oldest first, with their number of replies.
+// @Description With parent_id, retrieves the replies to that comment instead.
+// @Tags comments
+// @Produce json
+// @Param id path string true "Story ID"
+// @Param parent_id query string false "ID of the top-level comment whose replies to list"
+// @Param limit query int false "Maximum number of comments to return (1-100, default 20)"
+// @Param cursor query string false "Opaque cursor returned as next_cursor by the previous page"
+// @Success 200 {object} domain.Page[domain.Comment]
+// @Failure 400 {object} map[string]string "Invalid query parameters"
+// @Failure 404 {object} map[string]string "Story or parent comment not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /stories/{id}/comments [get]
+func (h *CommentHandler) GetComments(c *gin.Context) {
+	page, err := parsePageRequest(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid query parameters", "details": err.Error()})
+		return
+	}
+
+	query := domain.CommentQuery{
+		StoryID:  c.Param("id"),
+		ParentID: strings.TrimSpace(c.Query("parent_id")),
+		Page:     page,
+	}
+
+	comments, err := h.commentService.GetComments(c.Request.Context(), query)
+
+	if err != nil {
+		h.commentError(c, err, "Story or comment not found", "Failed to retrieve comments")
+		return
+	}
+
+	c.JSON(http.StatusOK, comments)
+}
+
+// UpdateComment godoc
+// @Summary Update a comment
+// @Description Replaces the body of a comment.
+// @Tags comments
+// @Accept json
+// @Produce json
+// @Param id path string true "Comment ID"
+// @Param comment body domain.UpdateCommentInput true "Comment update object"
+// @Success 200 {object} domain.Comment
+// @Failure 400 {object} map[string]string "Invalid input"
+// @Failure 404 {object} map[string]string "Comment not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /comments/{id} [put]
+func (h *CommentHandler) UpdateComment(c *gin.Context) {
+	var input domain.UpdateCommentInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return
+	}
+
+	if err := h.validate.Struct(input); err != nil {
+		validationErrors := err.(validator.ValidationErrors)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Validation failed", "details": validationErrors.Error()})
+		return
+	}
+
+	comment, err := h.commentService.UpdateComment(c.Request.Context(), c.Param("id"), &input)
+
+	if err != nil {
+		h.commentError(c, err, "Comment not found", "Failed to update comment")
+		return
+	}
+
+	c.JSON(http.StatusOK, comment)
+}
+
+// DeleteComment godoc
+// @Summary Delete a comment
+// @Description Permanently deletes a comment and its replies.
+// @Tags comments
+// @Produce json
+// @Param id path string true "Comment ID"
+// @Success 204 "No Content"
+// @Failure 404 {object} map[string]string "Comment not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /comments/{id} [delete]
+func (h *CommentHandler) DeleteComment(c *gin.Context) {
+	err := h.commentService.DeleteComment(c.Request.Context(), c.Param("id"))
+
+	if err != nil {
+		h.commentError(c, err, "Comment not found", "Failed to delete comment")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// Responds to a failed comment request: 400 for invalid input, 404 with notFound for a missing resource
+// and 500 with message otherwise.
+func (h *CommentHandler) commentError(c *gin.Context, err error, notFound, message string) {
+	var validationErr *util.ValidationError
+	if errors.As(err, &validationErr) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Validation failed", "details": validationErr.Message})
+		return
+	}
+
+	if isNotFound(err) {
+		c.JSON(http.StatusNotFound, gin.H{"error": notFound, "details": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusInternalServerError, gin.H{"error": message, "details": err.Error()})
+}
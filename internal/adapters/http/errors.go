@@ -0,0 +1,24 @@
+package http
+
+import (
+	"Gin/pkg/util"
+	"errors"
+)
+
+// Reports whether the service could not find the requested resource.
+func isNotFound(err error) bool {
+	var notFoundErr *util.NotFoundError
+	return errors.As(err, &notFoundErr)
+}
+
+// Reports whether the request conflicts with the current state of the resource.
+func isConflict(err error) bool {
+	var conflictErr *util.ConflictError
+	return errors.As(err, &conflictErr)
+}
+
+// Reports whether an If-Match precondition did not hold.
+func isPreconditionFailed(err error) bool {
+	var preconditionErr *util.PreconditionFailedError
+	return errors.As(err, &preconditionErr)
+}
@@ -0,0 +1,51 @@
+package http
+
+import (
+	"Gin/pkg/util"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Sets the ETag header of the response to the strong entity tag of a version, e.g. "3".
+func setETag(c *gin.Context, version int64) {
+	c.Header("ETag", strconv.Quote(strconv.FormatInt(version, 10)))
+}
+
+// Parses the If-Match header into the version the client expects to change.
+// Returns 0 when the header is absent or "*", meaning any version.
+// Weak entity tags never match, as If-Match uses the strong comparison.
+func parseIfMatch(c *gin.Context) (int64, error) {
+	raw := strings.TrimSpace(c.GetHeader("If-Match"))
+	if raw == "" || raw == "*" {
+		return 0, nil
+	}
+
+	if strings.HasPrefix(raw, "W/") {
+		return 0, &util.PreconditionFailedError{Message: "If-Match does not accept weak entity tags"}
+	}
+
+	tag, err := strconv.Unquote(raw)
+	if err != nil || !strings.HasPrefix(raw, `"`) {
+		return 0, &util.ValidationError{Message: `If-Match must be a single entity tag such as "3"`}
+	}
+
+	version, err := strconv.ParseInt(tag, 10, 64)
+	if err != nil || version < 1 {
+		// Not one of our tags, so it cannot match the current version
+		return 0, &util.PreconditionFailedError{Message: "If-Match does not match any version"}
+	}
+
+	return version, nil
+}
+
+// Returns the status of a rejected If-Match header: 412 if it cannot match, 400 if it is malformed.
+func ifMatchStatus(err error) int {
+	if isPreconditionFailed(err) {
+		return http.StatusPreconditionFailed
+	}
+
+	return http.StatusBadRequest
+}
@@ -0,0 +1,302 @@
+package http
+
+import (
+	"Gin/internal/core/domain"
+	"Gin/pkg/util"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Format of an export response.
+type exportFormat string
+
+const (
+	exportNDJSON exportFormat = "ndjson"
+	exportCSV    exportFormat = "csv"
+)
+
+// Content type of each export format.
+var exportContentTypes = map[exportFormat]string{
+	exportNDJSON: "application/x-ndjson",
+	exportCSV:    "text/csv",
+}
+
+// Number of rows written between two flushes of the response.
+const exportFlushRows = 100
+
+// Selects the export format from the `format` query parameter or, when absent, from the Accept header.
+// NDJSON is the default when the client accepts anything.
+func parseExportFormat(c *gin.Context) (exportFormat, error) {
+	switch format := exportFormat(strings.ToLower(strings.TrimSpace(c.Query("format")))); format {
+	case exportNDJSON, exportCSV:
+		return format, nil
+	case "":
+	default:
+		return "", &util.ValidationError{Message: "format must be ndjson or csv"}
+	}
+
+	switch c.NegotiateFormat("application/x-ndjson", "application/ndjson", "text/csv") {
+	case "application/x-ndjson", "application/ndjson":
+		return exportNDJSON, nil
+	case "text/csv":
+		return exportCSV, nil
+	}
+
+	return "", errors.New("the Accept header must allow application/x-ndjson or text/csv")
+}
+
+// Writes the rows of an export to the response as they arrive.
+// Headers are only sent with the first row, so an error raised before any row can still be answered with JSON.
+type exportWriter[T any] struct {
+	c        *gin.Context
+	format   exportFormat
+	filename string            // Base name of the downloaded file, without extension
+	header   []string          // Column names of a CSV export
+	record   func(*T) []string // Fields of a CSV row, in the order of header
+	csv      *csv.Writer
+	json     *json.Encoder
+	rows     int
+}
+
+// Sends the headers of the response and, for CSV, the header row.
+func (w *exportWriter[T]) start() error {
+	name := fmt.Sprintf("%s-%s.%s", w.filename, time.Now().UTC().Format("20060102T150405Z"), w.format)
+
+	w.c.Header("Content-Type", exportContentTypes[w.format])
+	w.c.Header("Content-Disposition", `attachment; filename="`+name+`"`)
+	w.c.Header("X-Content-Type-Options", "nosniff")
+	w.c.Status(http.StatusOK)
+
+	if w.format == exportNDJSON {
+		w.json = json.NewEncoder(w.c.Writer)
+		return nil
+	}
+
+	w.csv = csv.NewWriter(w.c.Writer)
+	return w.csv.Write(w.header)
+}
+
+// Writes one row, flushing the response every exportFlushRows rows.
+func (w *exportWriter[T]) write(item *T) error {
+	if w.rows == 0 {
+		if err := w.start(); err != nil {
+			return err
+		}
+	}
+
+	var err error
+	if w.json != nil {
+		err = w.json.Encode(item)
+	} else {
+		err = w.csv.Write(w.record(item))
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if w.rows++; w.rows%exportFlushRows == 0 {
+		return w.flush()
+	}
+
+	return nil
+}
+
+// Pushes the buffered rows to the client.
+func (w *exportWriter[T]) flush() error {
+	if w.csv != nil {
+		w.csv.Flush()
+		if err := w.csv.Error(); err != nil {
+			return err
+		}
+	}
+
+	w.c.Writer.Flush()
+	return nil
+}
+
+// Ends the export once every row was handed to write, or reports the error that stopped it.
+// Before the first row, the error is answered with JSON like in the list endpoints.
+// Afterwards the status is already sent, so the connection is aborted to keep the client
+// from mistaking a truncated export for a complete one.
+func (w *exportWriter[T]) finish(err error, message string) {
+	if err == nil {
+		if w.rows == 0 {
+			err = w.start()
+		}
+		if err == nil {
+			err = w.flush()
+		}
+		if err == nil {
+			return
+		}
+	}
+
+	if w.rows == 0 && !w.c.Writer.Written() {
+		var validationErr *util.ValidationError
+		if errors.As(err, &validationErr) {
+			w.c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid query parameters", "details": validationErr.Message})
+			return
+		}
+
+		w.c.JSON(http.StatusInternalServerError, gin.H{"error": message, "details": err.Error()})
+		return
+	}
+
+	log.Printf("%s after %d rows: %v", message, w.rows, err)
+	w.c.Abort()
+
+	// Closing the hijacked connection cuts the response before its end is sent
+	if conn, _, err := w.c.Writer.Hijack(); err == nil {
+		conn.Close()
+	}
+}
+
+// Columns of a story CSV export.
+var storyExportHeader = []string{"id", "title", "author", "author_id", "content", "tags", "created_at", "updated_at", "deleted_at", "version"}
+
+// Returns the fields of a story CSV row, in the order of storyExportHeader.
+func storyExportRecord(story *domain.Story) []string {
+	return []string{
+		story.ID,
+		story.Title,
+		story.Author,
+		stringValue(story.AuthorID),
+		story.Content,
+		strings.Join(story.Tags, csvTagSeparator),
+		story.CreatedAt.UTC().Format(time.RFC3339Nano),
+		story.UpdatedAt.UTC().Format(time.RFC3339Nano),
+		timeValue(story.DeletedAt),
+		strconv.FormatInt(story.Version, 10),
+	}
+}
+
+// Columns of a user CSV export.
+var userExportHeader = []string{"id", "email", "name", "created_at", "updated_at", "deleted_at", "version"}
+
+// Returns the fields of a user CSV row, in the order of userExportHeader.
+func userExportRecord(user *domain.User) []string {
+	return []string{
+		user.ID,
+		user.Email,
+		user.Name,
+		user.CreatedAt.UTC().Format(time.RFC3339Nano),
+		user.UpdatedAt.UTC().Format(time.RFC3339Nano),
+		timeValue(user.DeletedAt),
+		strconv.FormatInt(user.Version, 10),
+	}
+}
+
+// Returns the value of an optional string, or an empty string.
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+
+	return *s
+}
+
+// Returns an optional timestamp in RFC 3339, or an empty string.
+func timeValue(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+// ExportStories godoc
+// @Summary Export stories
+// @Description Streams every story matching the filters of GET /stories, in its sort order, as NDJSON (one story per line)
+// @Description or CSV (tags separated by ";"). The format is taken from the format parameter, else from the Accept header.
+// @Description limit and cursor are ignored. The response is a download; if the export fails midway, the connection is aborted.
+// @Tags stories
+// @Produce application/x-ndjson
+// @Produce text/csv
+// @Param format query string false "ndjson or csv, overrides the Accept header" Enums(ndjson, csv)
+// @Param author query string false "Exact author name (case-insensitive)"
+// @Param author_id query string false "ID of the user who wrote the stories"
+// @Param title query string false "Substring of the title (case-insensitive)"
+// @Param tag query []string false "Tag name, repeat the parameter to filter by several tags" collectionFormat(multi)
+// @Param tag_match query string false "any: stories with at least one of the tags, all: stories with every tag" Enums(any, all) default(any)
+// @Param created_after query string false "Only stories created at or after this RFC 3339 timestamp or date"
+// @Param created_before query string false "Only stories created before this RFC 3339 timestamp or date"
+// @Param updated_after query string false "Only stories updated at or after this RFC 3339 timestamp or date"
+// @Param updated_before query string false "Only stories updated before this RFC 3339 timestamp or date"
+// @Param include_deleted query bool false "Also export soft-deleted stories (for administrators)"
+// @Param sort query string false "created_at, updated_at, title or author; prefix with - or suffix with :desc for descending" default(-created_at)
+// @Success 200 {string} string "One story per line or row"
+// @Failure 400 {object} map[string]string "Invalid query parameters"
+// @Failure 406 {object} map[string]string "Unsupported Accept header"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /stories/export [get]
+func (h *StoryHandler) ExportStories(c *gin.Context) {
+	format, err := parseExportFormat(c)
+	if err != nil {
+		exportFormatError(c, err)
+		return
+	}
+
+	query, err := parseStoryQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid query parameters", "details": err.Error()})
+		return
+	}
+
+	w := &exportWriter[domain.Story]{c: c, format: format, filename: "stories", header: storyExportHeader, record: storyExportRecord}
+	err = h.storyService.ExportStories(c.Request.Context(), query, w.write)
+	w.finish(err, "Failed to export stories")
+}
+
+// ExportUsers godoc
+// @Summary Export users
+// @Description Streams every user, newest first, as NDJSON (one user per line) or CSV.
+// @Description The format is taken from the format parameter, else from the Accept header.
+// @Description The response is a download; if the export fails midway, the connection is aborted.
+// @Tags users
+// @Produce application/x-ndjson
+// @Produce text/csv
+// @Param format query string false "ndjson or csv, overrides the Accept header" Enums(ndjson, csv)
+// @Param include_deleted query bool false "Also export soft-deleted users (for administrators)"
+// @Success 200 {string} string "One user per line or row"
+// @Failure 400 {object} gin.H "Invalid query parameters"
+// @Failure 406 {object} gin.H "Unsupported Accept header"
+// @Failure 500 {object} gin.H "Internal server error"
+// @Router /users/export [get]
+func (h *UserHandler) ExportUsers(c *gin.Context) {
+	format, err := parseExportFormat(c)
+	if err != nil {
+		exportFormatError(c, err)
+		return
+	}
+
+	includeDeleted, err := parseBoolParam(c, "include_deleted")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	w := &exportWriter[domain.User]{c: c, format: format, filename: "users", header: userExportHeader, record: userExportRecord}
+	err = h.userService.ExportUsers(c.Request.Context(), domain.UserQuery{IncludeDeleted: includeDeleted}, w.write)
+	w.finish(err, "Failed to export users")
+}
+
+// Answers an invalid format parameter with 400 and an unsupported Accept header with 406.
+func exportFormatError(c *gin.Context, err error) {
+	var validationErr *util.ValidationError
+	if errors.As(err, &validationErr) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid query parameters", "details": validationErr.Message})
+		return
+	}
+
+	c.JSON(http.StatusNotAcceptable, gin.H{"error": "Unsupported export format", "details": err.Error()})
+}
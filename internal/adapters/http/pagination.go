@@ -0,0 +1,60 @@
+package http
+
+import (
+	"Gin/internal/core/domain"
+	"Gin/pkg/util"
+	"fmt"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Parses the `limit` and `cursor` query parameters of a list request.
+func parsePageRequest(c *gin.Context) (domain.PageRequest, error) {
+	page := domain.PageRequest{}
+
+	var err error
+	if page.Limit, err = parseIntParam(c, "limit", domain.DefaultPageLimit, 1, domain.MaxPageLimit); err != nil {
+		return page, err
+	}
+
+	if raw := c.Query("cursor"); raw != "" {
+		cursor, err := domain.DecodeCursor(raw)
+		if err != nil {
+			return page, &util.ValidationError{Message: err.Error()}
+		}
+		page.Cursor = cursor
+	}
+
+	return page, nil
+}
+
+// Parses an optional integer query parameter, returning def when absent and an error when outside [min, max].
+func parseIntParam(c *gin.Context, param string, def, min, max int) (int, error) {
+	raw := c.Query(param)
+	if raw == "" {
+		return def, nil
+	}
+
+	value, err := strconv.Atoi(raw)
+	if err != nil || value < min || value > max {
+		return 0, &util.ValidationError{Message: fmt.Sprintf("%s must be an integer between %d and %d", param, min, max)}
+	}
+
+	return value, nil
+}
+
+// Parses an optional boolean query parameter, returning false when absent.
+func parseBoolParam(c *gin.Context, param string) (bool, error) {
+	raw := c.Query(param)
+	if raw == "" {
+		return false, nil
+	}
+
+	value, err := strconv.ParseBool(raw)
+	if err != nil {
+		return false, &util.ValidationError{Message: fmt.Sprintf("%s must be true or false", param)}
+	}
+
+	return value, nil
+}
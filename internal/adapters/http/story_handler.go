@@ -3,8 +3,11 @@ package http
 import (
 	"Gin/internal/core/domain"
 	"Gin/internal/core/ports"
+	"Gin/pkg/util"
 	"errors"
+	"math"
 	"net/http"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
@@ -27,12 +30,13 @@ func NewStoryHandler(storyService ports.StoryDrivingPort) *StoryHandler {
 // CreateStory godoc
 // @Summary Create a new story
 // @Description Creates a new story with the provided title, author, and content.
+// @Description The author is given as author_id, the ID of an existing user; the free-text author field is deprecated.
 // @Tags stories
 // @Accept json
 // @Produce json
 // @Param story body domain.NewStoryInput true "Story creation object"
 // @Success 201 {object} domain.Story
-// @Failure 400 {object} map[string]string "Invalid input"
+// @Failure 400 {object} map[string]string "Invalid input or unknown author"
 // @Failure 500 {object} map[string]string "Internal server error"
 // @Router /stories [post]
 func (h *StoryHandler) CreateStory(c *gin.Context) {
@@ -49,32 +53,40 @@ func (h *StoryHandler) CreateStory(c *gin.Context) {
 		return
 	}
 
-	story, err := h.storyService.CreateStory(&input)
+	story, err := h.storyService.CreateStory(c.Request.Context(), &input)
 	if err != nil {
+		var validationErr *util.ValidationError
+		if errors.As(err, &validationErr) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Validation failed", "details": validationErr.Message})
+			return
+		}
+
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create story", "details": err.Error()})
 		return
 	}
 
+	setETag(c, story.Version)
 	c.JSON(http.StatusCreated, story)
 }
 
 // GetStory godoc
 // @Summary Get a story by ID
-// @Description Retrieves a single story by its unique ID.
+// @Description Retrieves a single story by its unique ID. The ETag header holds its version.
 // @Tags stories
 // @Produce json
 // @Param id path string true "Story ID"
 // @Success 200 {object} domain.Story
+// @Header 200 {string} ETag "Version of the story"
 // @Failure 404 {object} map[string]string "Story not found"
 // @Failure 500 {object} map[string]string "Internal server error"
 // @Router /stories/{id} [get]
 func (h *StoryHandler) GetStory(c *gin.Context) {
 	id := c.Param("id")
-	story, err := h.storyService.GetStoryByID(id)
+	story, err := h.storyService.GetStoryByID(c.Request.Context(), id)
 
 	if err != nil {
 		// Check if the error is because the story was not found.
-		if errors.Is(err, errors.New("story not found")) { // <-- Asegúrate de que el mensaje de error coincida con el del servicio.
+		if isNotFound(err) {
 			c.JSON(http.StatusNotFound, gin.H{"error": "Story not found"})
 			return
 		}
@@ -87,21 +99,93 @@ func (h *StoryHandler) GetStory(c *gin.Context) {
 		return
 	}
 
+	setETag(c, story.Version)
 	c.JSON(http.StatusOK, story)
 }
 
 // GetAllStories godoc
 // @Summary Get all stories
-// @Description Retrieves a list of all stories.
+// @Description Retrieves a filtered, sorted page of stories (newest first by default).
 // @Tags stories
 // @Produce json
-// @Success 200 {array} domain.Story
+// @Param author query string false "Exact author name (case-insensitive)"
+// @Param author_id query string false "ID of the user who wrote the stories"
+// @Param title query string false "Substring of the title (case-insensitive)"
+// @Param tag query []string false "Tag name, repeat the parameter to filter by several tags" collectionFormat(multi)
+// @Param tag_match query string false "any: stories with at least one of the tags, all: stories with every tag" Enums(any, all) default(any)
+// @Param created_after query string false "Only stories created at or after this RFC 3339 timestamp or date"
+// @Param created_before query string false "Only stories created before this RFC 3339 timestamp or date"
+// @Param updated_after query string false "Only stories updated at or after this RFC 3339 timestamp or date"
+// @Param updated_before query string false "Only stories updated before this RFC 3339 timestamp or date"
+// @Param include_deleted query bool false "Also list soft-deleted stories (for administrators)"
+// @Param sort query string false "created_at, updated_at, title or author; prefix with - or suffix with :desc for descending" default(-created_at)
+// @Param limit query int false "Maximum number of stories to return (1-100, default 20)"
+// @Param cursor query string false "Opaque cursor returned as next_cursor by the previous page"
+// @Success 200 {object} domain.Page[domain.Story]
+// @Failure 400 {object} map[string]string "Invalid query parameters"
 // @Failure 500 {object} map[string]string "Internal server error"
 // @Router /stories [get]
 func (h *StoryHandler) GetAllStories(c *gin.Context) {
-	stories, err := h.storyService.GetAllStories()
+	query, err := parseStoryQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid query parameters", "details": err.Error()})
+		return
+	}
+
+	stories, err := h.storyService.GetAllStories(c.Request.Context(), query)
+
+	if err != nil {
+		var validationErr *util.ValidationError
+		if errors.As(err, &validationErr) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid query parameters", "details": validationErr.Message})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve stories", "details": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, stories)
+}
+
+// GetUserStories godoc
+// @Summary Get the stories of a user
+// @Description Retrieves a filtered, sorted page of the stories written by a user (newest first by default).
+// @Description Accepts the same filter, sort and pagination parameters as GET /stories.
+// @Tags stories
+// @Produce json
+// @Param id path string true "User ID"
+// @Param title query string false "Substring of the title (case-insensitive)"
+// @Param include_deleted query bool false "Also list soft-deleted stories (for administrators)"
+// @Param sort query string false "created_at, updated_at, title or author; prefix with - or suffix with :desc for descending" default(-created_at)
+// @Param limit query int false "Maximum number of stories to return (1-100, default 20)"
+// @Param cursor query string false "Opaque cursor returned as next_cursor by the previous page"
+// @Success 200 {object} domain.Page[domain.Story]
+// @Failure 400 {object} map[string]string "Invalid query parameters"
+// @Failure 404 {object} map[string]string "User not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /users/{id}/stories [get]
+func (h *StoryHandler) GetUserStories(c *gin.Context) {
+	query, err := parseStoryQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid query parameters", "details": err.Error()})
+		return
+	}
+
+	stories, err := h.storyService.GetStoriesByAuthor(c.Request.Context(), c.Param("id"), query)
 
 	if err != nil {
+		if isNotFound(err) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "User not found", "details": err.Error()})
+			return
+		}
+
+		var validationErr *util.ValidationError
+		if errors.As(err, &validationErr) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid query parameters", "details": validationErr.Message})
+			return
+		}
+
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve stories", "details": err.Error()})
 		return
 	}
@@ -109,23 +193,99 @@ func (h *StoryHandler) GetAllStories(c *gin.Context) {
 	c.JSON(http.StatusOK, stories)
 }
 
+// SearchStories godoc
+// @Summary Search stories
+// @Description Full-text search over story titles and content, ranked by relevance with highlighted snippets.
+// @Tags stories
+// @Produce json
+// @Param q query string true "Search terms (supports \"quoted phrases\", OR and -excluded words)"
+// @Param limit query int false "Maximum number of results to return (1-100, default 20)"
+// @Param offset query int false "Number of results to skip"
+// @Success 200 {array} domain.StorySearchResult
+// @Failure 400 {object} map[string]string "Invalid query parameters"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /stories/search [get]
+func (h *StoryHandler) SearchStories(c *gin.Context) {
+	query := domain.StorySearchQuery{Text: strings.TrimSpace(c.Query("q"))}
+	if query.Text == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid query parameters", "details": "q is required"})
+		return
+	}
+
+	var err error
+	if query.Limit, err = parseIntParam(c, "limit", domain.DefaultPageLimit, 1, domain.MaxPageLimit); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid query parameters", "details": err.Error()})
+		return
+	}
+
+	if query.Offset, err = parseIntParam(c, "offset", 0, 0, math.MaxInt32); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid query parameters", "details": err.Error()})
+		return
+	}
+
+	results, err := h.storyService.SearchStories(c.Request.Context(), query)
+
+	if err != nil {
+		var validationErr *util.ValidationError
+		if errors.As(err, &validationErr) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid query parameters", "details": validationErr.Message})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to search stories", "details": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, results)
+}
+
+// GetTags godoc
+// @Summary Get all tags
+// @Description Retrieves the tags used by stories with the number of stories using each one, most used first.
+// @Description Deleted stories are not counted.
+// @Tags stories
+// @Produce json
+// @Success 200 {array} domain.TagUsage
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /tags [get]
+func (h *StoryHandler) GetTags(c *gin.Context) {
+	tags, err := h.storyService.GetTags(c.Request.Context())
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve tags", "details": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, tags)
+}
+
 // UpdateStory godoc
 // @Summary Update an existing story
 // @Description Updates an existing story identified by ID with the provided fields.
+// @Description With an If-Match header, the update only applies if the story is still at that version.
 // @Tags stories
 // @Accept json
 // @Produce json
 // @Param id path string true "Story ID"
+// @Param If-Match header string false "ETag of the version being updated"
 // @Param story body domain.UpdateStoryInput true "Story update object"
 // @Success 200 {object} domain.Story
-// @Failure 400 {object} map[string]string "Invalid input"
+// @Header 200 {string} ETag "New version of the story"
+// @Failure 400 {object} map[string]string "Invalid input or unknown author"
 // @Failure 404 {object} map[string]string "Story not found"
+// @Failure 412 {object} map[string]string "The story was changed since the version in If-Match"
 // @Failure 500 {object} map[string]string "Internal server error"
 // @Router /stories/{id} [put]
 func (h *StoryHandler) UpdateStory(c *gin.Context) {
 	id := c.Param("id")
 	var input domain.UpdateStoryInput
 
+	expectedVersion, err := parseIfMatch(c)
+	if err != nil {
+		c.JSON(ifMatchStatus(err), gin.H{"error": "Invalid If-Match header", "details": err.Error()})
+		return
+	}
+
 	if err := c.ShouldBindJSON(&input); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
 		return
@@ -138,43 +298,102 @@ func (h *StoryHandler) UpdateStory(c *gin.Context) {
 		return
 	}
 
-	story, err := h.storyService.UpdateStory(id, &input)
+	story, err := h.storyService.UpdateStory(c.Request.Context(), id, &input, expectedVersion)
 
 	if err != nil {
-		if errors.Is(err, errors.New("story not found for update")) { // <-- Asegúrate de que el mensaje de error coincida con el del servicio.
+		var validationErr *util.ValidationError
+		if errors.As(err, &validationErr) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Validation failed", "details": validationErr.Message})
+			return
+		}
+		if isNotFound(err) {
 			c.JSON(http.StatusNotFound, gin.H{"error": "Story not found"})
 			return
 		}
+		if isPreconditionFailed(err) {
+			c.JSON(http.StatusPreconditionFailed, gin.H{"error": "Story was changed by someone else", "details": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update story", "details": err.Error()})
 		return
 	}
 
+	setETag(c, story.Version)
 	c.JSON(http.StatusOK, story)
 }
 
 // DeleteStory godoc
 // @Summary Delete a story by ID
-// @Description Deletes a story by its unique ID.
+// @Description Soft-deletes a story by its unique ID. It can be restored until it is purged.
+// @Description With an If-Match header, the story is only deleted if it is still at that version.
 // @Tags stories
 // @Produce json
 // @Param id path string true "Story ID"
+// @Param If-Match header string false "ETag of the version being deleted"
 // @Success 204 "No Content"
 // @Failure 404 {object} map[string]string "Story not found"
+// @Failure 412 {object} map[string]string "The story was changed since the version in If-Match"
 // @Failure 500 {object} map[string]string "Internal server error"
 // @Router /stories/{id} [delete]
 func (h *StoryHandler) DeleteStory(c *gin.Context) {
 	id := c.Param("id")
-	err := h.storyService.DeleteStory(id)
+
+	expectedVersion, err := parseIfMatch(c)
+	if err != nil {
+		c.JSON(ifMatchStatus(err), gin.H{"error": "Invalid If-Match header", "details": err.Error()})
+		return
+	}
+
+	err = h.storyService.DeleteStory(c.Request.Context(), id, expectedVersion)
 
 	if err != nil {
-		if errors.Is(err, errors.New("story not found for deletion")) { // <-- Asegúrate de que el mensaje de error coincida con el del servicio.
+		if isNotFound(err) {
 			c.JSON(http.StatusNotFound, gin.H{"error": "Story not found"})
 			return
 		}
 
+		if isPreconditionFailed(err) {
+			c.JSON(http.StatusPreconditionFailed, gin.H{"error": "Story was changed by someone else", "details": err.Error()})
+			return
+		}
+
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete story", "details": err.Error()})
 		return
 	}
 
 	c.Status(http.StatusNoContent) // 204 No Content for successful deletion
 }
+
+// RestoreStory godoc
+// @Summary Restore a deleted story
+// @Description Restores a soft-deleted story that has not been purged yet.
+// @Tags stories
+// @Produce json
+// @Param id path string true "Story ID"
+// @Success 200 {object} domain.Story
+// @Failure 404 {object} map[string]string "Deleted story not found"
+// @Failure 409 {object} map[string]string "The author of the story is deleted"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /stories/{id}/restore [post]
+func (h *StoryHandler) RestoreStory(c *gin.Context) {
+	id := c.Param("id")
+	story, err := h.storyService.RestoreStory(c.Request.Context(), id)
+
+	if err != nil {
+		if isNotFound(err) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Deleted story not found"})
+			return
+		}
+
+		if isConflict(err) {
+			c.JSON(http.StatusConflict, gin.H{"error": "Story cannot be restored", "details": err.Error()})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to restore story", "details": err.Error()})
+		return
+	}
+
+	setETag(c, story.Version)
+	c.JSON(http.StatusOK, story)
+}
@@ -0,0 +1,239 @@
+package http
+
+import (
+	"Gin/internal/core/domain"
+	"Gin/pkg/util"
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Maximum length of one NDJSON line.
+const maxImportLineBytes = 1 << 20
+
+// Separates the tags inside the tags column of a CSV import.
+const csvTagSeparator = ";"
+
+// ImportStories godoc
+// @Summary Import stories
+// @Description Creates many stories from an NDJSON stream (one NewStoryInput object per line)
+// @Description or a CSV stream with a header row naming the columns title, author, author_id, content and tags (separated by ";").
+// @Description Every record is validated like in POST /stories. In atomic mode (the default), nothing is created unless every record is valid;
+// @Description in best_effort mode, the valid records are created in batches and the invalid ones are skipped.
+// @Description The response reports the outcome of each record by its line number.
+// @Tags stories
+// @Accept application/x-ndjson
+// @Accept text/csv
+// @Produce json
+// @Param mode query string false "atomic or best_effort" Enums(atomic, best_effort) default(atomic)
+// @Success 200 {object} domain.StoryImportReport
+// @Failure 400 {object} map[string]string "Unreadable input or too many records"
+// @Failure 415 {object} map[string]string "Unsupported content type"
+// @Failure 422 {object} domain.StoryImportReport "Atomic import rejected because of invalid records"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /stories/import [post]
+func (h *StoryHandler) ImportStories(c *gin.Context) {
+	mode, err := domain.ParseStoryImportMode(c.Query("mode"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid query parameters", "details": err.Error()})
+		return
+	}
+
+	var records []domain.StoryImportRecord
+
+	switch c.ContentType() {
+	case "application/x-ndjson", "application/ndjson":
+		records, err = h.readNDJSONImport(c.Request.Body)
+	case "text/csv":
+		records, err = h.readCSVImport(c.Request.Body)
+	default:
+		c.JSON(http.StatusUnsupportedMediaType, gin.H{"error": "Unsupported content type", "details": "send application/x-ndjson or text/csv"})
+		return
+	}
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid import", "details": err.Error()})
+		return
+	}
+
+	report, err := h.storyService.ImportStories(c.Request.Context(), records, mode)
+
+	if err != nil {
+		var validationErr *util.ValidationError
+		if errors.As(err, &validationErr) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid import", "details": validationErr.Message})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to import stories", "details": err.Error()})
+		return
+	}
+
+	if report.Rejected() {
+		c.JSON(http.StatusUnprocessableEntity, report)
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// Reads one record per non-blank line of an NDJSON stream.
+// A line that is not a valid NewStoryInput becomes a record carrying the error.
+func (h *StoryHandler) readNDJSONImport(r io.Reader) ([]domain.StoryImportRecord, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxImportLineBytes)
+
+	records := make([]domain.StoryImportRecord, 0)
+
+	for line := 1; scanner.Scan(); line++ {
+		raw := bytes.TrimSpace(scanner.Bytes())
+		if len(raw) == 0 {
+			continue
+		}
+
+		if len(records) == domain.MaxStoryImportRecords {
+			return nil, fmt.Errorf("at most %d records can be imported at once", domain.MaxStoryImportRecords)
+		}
+
+		record := domain.StoryImportRecord{Line: line}
+
+		decoder := json.NewDecoder(bytes.NewReader(raw))
+		decoder.DisallowUnknownFields()
+
+		if err := decoder.Decode(&record.Input); err != nil {
+			record.Err = fmt.Errorf("invalid JSON: %w", err)
+		} else {
+			record.Err = h.validateImportRecord(&record.Input)
+		}
+
+		records = append(records, record)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read NDJSON: %w", err)
+	}
+
+	return records, nil
+}
+
+// Reads one record per row of a CSV stream whose header row names the columns.
+// A row with the wrong number of fields or an invalid NewStoryInput becomes a record carrying the error.
+func (h *StoryHandler) readCSVImport(r io.Reader) ([]domain.StoryImportRecord, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("CSV header row is missing")
+		}
+		return nil, fmt.Errorf("invalid CSV header: %w", err)
+	}
+
+	columns, err := csvImportColumns(header)
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]domain.StoryImportRecord, 0)
+
+	for {
+		row, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if len(records) == domain.MaxStoryImportRecords {
+			return nil, fmt.Errorf("at most %d records can be imported at once", domain.MaxStoryImportRecords)
+		}
+
+		line, _ := reader.FieldPos(0)
+		record := domain.StoryImportRecord{Line: line}
+
+		switch {
+		case errors.Is(err, csv.ErrFieldCount):
+			record.Err = fmt.Errorf("expected %d fields, got %d", len(header), len(row))
+		case err != nil:
+			// Other syntax errors leave the reader at an unknown position
+			return nil, fmt.Errorf("invalid CSV: %w", err)
+		default:
+			record.Input = csvImportInput(columns, row)
+			record.Err = h.validateImportRecord(&record.Input)
+		}
+
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+// Maps each CSV column name of the header to its index. The title and content columns are required.
+func csvImportColumns(header []string) (map[string]int, error) {
+	columns := make(map[string]int, len(header))
+
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(name))
+
+		switch name {
+		case "title", "author", "author_id", "content", "tags":
+		default:
+			return nil, fmt.Errorf("unknown CSV column %q, expected title, author, author_id, content or tags", name)
+		}
+
+		if _, ok := columns[name]; ok {
+			return nil, fmt.Errorf("duplicate CSV column %q", name)
+		}
+
+		columns[name] = i
+	}
+
+	for _, name := range []string{"title", "content"} {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("CSV column %q is required", name)
+		}
+	}
+
+	return columns, nil
+}
+
+// Builds the input of a CSV row from the columns of the header.
+func csvImportInput(columns map[string]int, row []string) domain.NewStoryInput {
+	field := func(name string) string {
+		if i, ok := columns[name]; ok {
+			return strings.TrimSpace(row[i])
+		}
+		return ""
+	}
+
+	input := domain.NewStoryInput{
+		Title:    field("title"),
+		Author:   field("author"),
+		AuthorID: field("author_id"),
+		Content:  field("content"),
+	}
+
+	for _, tag := range strings.Split(field("tags"), csvTagSeparator) {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			input.Tags = append(input.Tags, tag)
+		}
+	}
+
+	return input
+}
+
+// Validates an import record with the rules of POST /stories.
+func (h *StoryHandler) validateImportRecord(input *domain.NewStoryInput) error {
+	if err := h.validate.Struct(input); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,93 @@
+package http
+
+import (
+	"Gin/internal/core/domain"
+	"Gin/pkg/util"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// Parses the filter, sort and pagination query parameters of GET /stories into a domain.StoryQuery.
+//
+// Supported parameters:
+//   - author: exact author name (case-insensitive)
+//   - author_id: ID of the user who wrote the stories
+//   - title: substring of the title (case-insensitive)
+//   - tag: tag name, repeated for several tags; tag_match selects whether stories need any (default) or all of them
+//   - created_after, created_before, updated_after, updated_before: RFC 3339 timestamps or YYYY-MM-DD dates
+//   - sort: one of created_at, updated_at, title, author; prefix with "-" or suffix with ":desc" for descending
+//   - include_deleted: also list soft-deleted stories (intended for administrators)
+//   - limit, cursor: see parsePageRequest
+func parseStoryQuery(c *gin.Context) (domain.StoryQuery, error) {
+	query := domain.StoryQuery{
+		Author:   strings.TrimSpace(c.Query("author")),
+		AuthorID: strings.TrimSpace(c.Query("author_id")),
+		Title:    strings.TrimSpace(c.Query("title")),
+		Tags:     c.QueryArray("tag"), // Normalized by the service
+	}
+
+	var err error
+
+	if query.AuthorID != "" {
+		if _, err = uuid.Parse(query.AuthorID); err != nil {
+			return query, &util.ValidationError{Message: "author_id must be a UUID"}
+		}
+	}
+
+	ranges := []struct {
+		param  string
+		target **time.Time
+	}{
+		{"created_after", &query.CreatedAfter},
+		{"created_before", &query.CreatedBefore},
+		{"updated_after", &query.UpdatedAfter},
+		{"updated_before", &query.UpdatedBefore},
+	}
+
+	for _, r := range ranges {
+		if *r.target, err = parseTimeParam(c, r.param); err != nil {
+			return query, err
+		}
+	}
+
+	if query.TagMatch, err = domain.ParseTagMatch(c.Query("tag_match")); err != nil {
+		return query, &util.ValidationError{Message: err.Error()}
+	}
+
+	if query.IncludeDeleted, err = parseBoolParam(c, "include_deleted"); err != nil {
+		return query, err
+	}
+
+	if query.Sort, err = domain.ParseStorySort(c.Query("sort")); err != nil {
+		return query, &util.ValidationError{Message: err.Error()}
+	}
+
+	if query.Page, err = parsePageRequest(c); err != nil {
+		return query, err
+	}
+
+	if err := query.Validate(); err != nil {
+		return query, &util.ValidationError{Message: err.Error()}
+	}
+
+	return query, nil
+}
+
+// Parses an optional timestamp query parameter given either as RFC 3339 or as a plain date.
+func parseTimeParam(c *gin.Context, param string) (*time.Time, error) {
+	raw := c.Query(param)
+	if raw == "" {
+		return nil, nil
+	}
+
+	for _, layout := range []string{time.RFC3339Nano, time.DateOnly} {
+		if t, err := time.Parse(layout, raw); err == nil {
+			return &t, nil
+		}
+	}
+
+	return nil, &util.ValidationError{Message: param + " must be an RFC 3339 timestamp or a YYYY-MM-DD date"}
+}
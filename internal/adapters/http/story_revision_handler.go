@@ -0,0 +1,176 @@
+package http
+
+import (
+	"Gin/pkg/util"
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetStoryRevisions godoc
+// @Summary List the revisions of a story
+// @Description Retrieves a page of the previous versions of a story, newest first.
+// @Tags stories
+// @Produce json
+// @Param id path string true "Story ID"
+// @Param limit query int false "Maximum number of revisions to return (1-100, default 20)"
+// @Param cursor query string false "Opaque cursor returned as next_cursor by the previous page"
+// @Success 200 {object} domain.Page[domain.StoryRevision]
+// @Failure 400 {object} map[string]string "Invalid query parameters"
+// @Failure 404 {object} map[string]string "Story not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /stories/{id}/revisions [get]
+func (h *StoryHandler) GetStoryRevisions(c *gin.Context) {
+	page, err := parsePageRequest(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid query parameters", "details": err.Error()})
+		return
+	}
+
+	revisions, err := h.storyService.GetStoryRevisions(c.Request.Context(), c.Param("id"), page)
+
+	if err != nil {
+		h.revisionError(c, err, "Failed to retrieve story revisions")
+		return
+	}
+
+	c.JSON(http.StatusOK, revisions)
+}
+
+// GetStoryRevision godoc
+// @Summary Get a revision of a story
+// @Description Retrieves a previous version of a story by its revision number.
+// @Tags stories
+// @Produce json
+// @Param id path string true "Story ID"
+// @Param rev path int true "Revision number"
+// @Success 200 {object} domain.StoryRevision
+// @Failure 400 {object} map[string]string "Invalid revision number"
+// @Failure 404 {object} map[string]string "Story or revision not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /stories/{id}/revisions/{rev} [get]
+func (h *StoryHandler) GetStoryRevision(c *gin.Context) {
+	rev, err := parseRevisionParam(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid revision number", "details": err.Error()})
+		return
+	}
+
+	revision, err := h.storyService.GetStoryRevision(c.Request.Context(), c.Param("id"), rev)
+
+	if err != nil {
+		h.revisionError(c, err, "Failed to retrieve story revision")
+		return
+	}
+
+	c.JSON(http.StatusOK, revision)
+}
+
+// DiffStoryRevisions godoc
+// @Summary Compare two versions of a story
+// @Description Returns the line-level differences of the title, author and content between a revision and another version of the story.
+// @Description The current story is the version after the latest revision.
+// @Tags stories
+// @Produce json
+// @Param id path string true "Story ID"
+// @Param rev path int true "Revision number to compare from"
+// @Param to query int false "Revision number to compare to (defaults to the current story)"
+// @Success 200 {object} domain.StoryDiff
+// @Failure 400 {object} map[string]string "Invalid revision number, or versions too different to compare"
+// @Failure 404 {object} map[string]string "Story or revision not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /stories/{id}/revisions/{rev}/diff [get]
+func (h *StoryHandler) DiffStoryRevisions(c *gin.Context) {
+	from, err := parseRevisionParam(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid revision number", "details": err.Error()})
+		return
+	}
+
+	to, err := parseIntParam(c, "to", 0, 1, math.MaxInt32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid revision number", "details": err.Error()})
+		return
+	}
+
+	diff, err := h.storyService.DiffStoryRevisions(c.Request.Context(), c.Param("id"), from, to)
+
+	if err != nil {
+		h.revisionError(c, err, "Failed to compare story revisions")
+		return
+	}
+
+	c.JSON(http.StatusOK, diff)
+}
+
+// RestoreStoryRevision godoc
+// @Summary Roll a story back to a revision
+// @Description Replaces the title, author and content of a story with those of a previous version.
+// @Description The version being replaced is kept as a new revision, so the rollback can itself be undone.
+// @Tags stories
+// @Produce json
+// @Param id path string true "Story ID"
+// @Param rev path int true "Revision number to restore"
+// @Success 200 {object} domain.Story
+// @Failure 400 {object} map[string]string "Invalid revision number"
+// @Failure 404 {object} map[string]string "Story or revision not found"
+// @Failure 409 {object} map[string]string "The author of the revision is deleted"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /stories/{id}/revisions/{rev}/restore [post]
+func (h *StoryHandler) RestoreStoryRevision(c *gin.Context) {
+	rev, err := parseRevisionParam(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid revision number", "details": err.Error()})
+		return
+	}
+
+	story, err := h.storyService.RestoreStoryRevision(c.Request.Context(), c.Param("id"), rev)
+
+	if err != nil {
+		h.revisionError(c, err, "Failed to restore story revision")
+		return
+	}
+
+	setETag(c, story.Version)
+	c.JSON(http.StatusOK, story)
+}
+
+// Responds to a failed revision request: 400 for invalid input, 404 for a missing story or revision,
+// 409 for a revision that cannot be restored, 412 for a concurrent change and 500 otherwise.
+func (h *StoryHandler) revisionError(c *gin.Context, err error, message string) {
+	var validationErr *util.ValidationError
+	if errors.As(err, &validationErr) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid query parameters", "details": validationErr.Message})
+		return
+	}
+
+	if isNotFound(err) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Story or revision not found", "details": err.Error()})
+		return
+	}
+
+	if isConflict(err) {
+		c.JSON(http.StatusConflict, gin.H{"error": "Revision cannot be restored", "details": err.Error()})
+		return
+	}
+
+	if isPreconditionFailed(err) {
+		c.JSON(http.StatusPreconditionFailed, gin.H{"error": "Story was changed by someone else", "details": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusInternalServerError, gin.H{"error": message, "details": err.Error()})
+}
+
+// Parses the :rev path parameter as a positive revision number.
+func parseRevisionParam(c *gin.Context) (int, error) {
+	rev, err := strconv.Atoi(c.Param("rev"))
+	if err != nil || rev < 1 {
+		return 0, &util.ValidationError{Message: "rev must be a positive integer"}
+	}
+
+	return rev, nil
+}
@@ -2,6 +2,7 @@
 package http
 
 import (
+	"Gin/internal/core/domain"
 	"Gin/internal/core/ports"
 	"net/http"
 
@@ -49,22 +50,24 @@ func (h *UserHandler) CreateUser(c *gin.Context) {
 		return
 	}
 
-	user, err := h.userService.CreateUser(req.Email, req.Name)
+	user, err := h.userService.CreateUser(c.Request.Context(), req.Email, req.Name)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
+	setETag(c, user.Version)
 	c.JSON(http.StatusCreated, user)
 }
 
 // GetUserByID godoc
 // @Summary Get a user by ID
-// @Description Get a specific user by their ID
+// @Description Get a specific user by their ID. The ETag header holds its version
 // @Tags users
 // @Produce json
 // @Param id path string true "User ID"
 // @Success 200 {object} domain.User
+// @Header 200 {string} ETag "Version of the user"
 // @Failure 404 {object} gin.H "User not found"
 // @Failure 500 {object} gin.H "Internal server error"
 // @Router /users/{id} [get]
@@ -75,10 +78,10 @@ func (h *UserHandler) GetUserByID(c *gin.Context) {
 		return
 	}
 
-	user, err := h.userService.GetUserByID(id)
+	user, err := h.userService.GetUserByID(c.Request.Context(), id)
 	if err != nil {
 		// Differentiate between "not found" and other errors
-		if err.Error() == "user not found: user not found" { // Be careful with string comparison of errors, better to use custom error types
+		if isNotFound(err) {
 			c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
 			return
 		}
@@ -88,19 +91,40 @@ func (h *UserHandler) GetUserByID(c *gin.Context) {
 	// Note: UserService returns (nil, nil) if not found after FindUserByID in repo,
 	//       so the error check above should catch that. If user is nil here, it's already an error case.
 
+	setETag(c, user.Version)
 	c.JSON(http.StatusOK, user)
 }
 
 // GetAllUsers godoc
 // @Summary Get all users
-// @Description Retrieve a list of all registered users
+// @Description Retrieve a page of registered users, newest first
 // @Tags users
 // @Produce json
-// @Success 200 {array} domain.User
+// @Param limit query int false "Maximum number of users to return (1-100, default 20)"
+// @Param cursor query string false "Opaque cursor returned as next_cursor by the previous page"
+// @Param include_deleted query bool false "Also list soft-deleted users (for administrators)"
+// @Success 200 {object} domain.Page[domain.User]
+// @Failure 400 {object} gin.H "Invalid pagination parameters"
 // @Failure 500 {object} gin.H "Internal server error"
 // @Router /users [get]
 func (h *UserHandler) GetAllUsers(c *gin.Context) {
-	users, err := h.userService.GetAllUsers()
+	page, err := parsePageRequest(c)
+	if err == nil && page.Cursor != nil {
+		// Users are always listed by creation date, so the cursor must hold a timestamp
+		_, err = page.Cursor.TimeValue()
+	}
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	includeDeleted, err := parseBoolParam(c, "include_deleted")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	users, err := h.userService.GetAllUsers(c.Request.Context(), domain.UserQuery{IncludeDeleted: includeDeleted, Page: page})
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -111,14 +135,18 @@ func (h *UserHandler) GetAllUsers(c *gin.Context) {
 // UpdateUser godoc
 // @Summary Update an existing user
 // @Description Update an existing user's email or name by ID
+// @Description With an If-Match header, the update only applies if the user is still at that version
 // @Tags users
 // @Accept json
 // @Produce json
 // @Param id path string true "User ID"
+// @Param If-Match header string false "ETag of the version being updated"
 // @Param user body UpdateUserRequest true "User data to update"
 // @Success 200 {object} domain.User
+// @Header 200 {string} ETag "New version of the user"
 // @Failure 400 {object} gin.H "Invalid input"
 // @Failure 404 {object} gin.H "User not found"
+// @Failure 412 {object} gin.H "The user was changed since the version in If-Match"
 // @Failure 500 {object} gin.H "Internal server error"
 // @Router /users/{id} [put]
 func (h *UserHandler) UpdateUser(c *gin.Context) {
@@ -128,6 +156,12 @@ func (h *UserHandler) UpdateUser(c *gin.Context) {
 		return
 	}
 
+	expectedVersion, err := parseIfMatch(c)
+	if err != nil {
+		c.JSON(ifMatchStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
 	var req UpdateUserRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
@@ -144,26 +178,35 @@ func (h *UserHandler) UpdateUser(c *gin.Context) {
 		name = *req.Name
 	}
 
-	user, err := h.userService.UpdateUser(id, email, name)
+	user, err := h.userService.UpdateUser(c.Request.Context(), id, email, name, expectedVersion)
 	if err != nil {
-		if err.Error() == "user not found" { // Again, better to use custom error types
+		if isNotFound(err) {
 			c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
 			return
 		}
+		if isPreconditionFailed(err) {
+			c.JSON(http.StatusPreconditionFailed, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
+	setETag(c, user.Version)
 	c.JSON(http.StatusOK, user)
 }
 
 // DeleteUser godoc
 // @Summary Delete a user
-// @Description Delete a user by their ID
+// @Description Soft-delete a user by their ID. The user can be restored until it is purged.
+// @Description Users who still author stories cannot be deleted
 // @Tags users
 // @Produce json
 // @Param id path string true "User ID"
+// @Param If-Match header string false "ETag of the version being deleted"
 // @Success 204 "No Content"
 // @Failure 404 {object} gin.H "User not found"
+// @Failure 409 {object} gin.H "The user still authors stories"
+// @Failure 412 {object} gin.H "The user was changed since the version in If-Match"
 // @Failure 500 {object} gin.H "Internal server error"
 // @Router /users/{id} [delete]
 func (h *UserHandler) DeleteUser(c *gin.Context) {
@@ -173,14 +216,63 @@ func (h *UserHandler) DeleteUser(c *gin.Context) {
 		return
 	}
 
-	err := h.userService.DeleteUser(id)
+	expectedVersion, err := parseIfMatch(c)
+	if err != nil {
+		c.JSON(ifMatchStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	err = h.userService.DeleteUser(c.Request.Context(), id, expectedVersion)
 	if err != nil {
-		if err.Error() == "failed to delete user: user not found for deletion" { // Better to use custom error types
+		if isNotFound(err) {
 			c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
 			return
 		}
+		if isConflict(err) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		if isPreconditionFailed(err) {
+			c.JSON(http.StatusPreconditionFailed, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 	c.Status(http.StatusNoContent) // 204 No Content for successful deletion
 }
+
+// RestoreUser godoc
+// @Summary Restore a deleted user
+// @Description Restore a soft-deleted user that has not been purged yet
+// @Tags users
+// @Produce json
+// @Param id path string true "User ID"
+// @Success 200 {object} domain.User
+// @Failure 404 {object} gin.H "Deleted user not found"
+// @Failure 409 {object} gin.H "Email is used by another user"
+// @Failure 500 {object} gin.H "Internal server error"
+// @Router /users/{id}/restore [post]
+func (h *UserHandler) RestoreUser(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "User ID is required"})
+		return
+	}
+
+	user, err := h.userService.RestoreUser(c.Request.Context(), id)
+	if err != nil {
+		if isNotFound(err) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Deleted user not found"})
+			return
+		}
+		if isConflict(err) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	setETag(c, user.Version)
+	c.JSON(http.StatusOK, user)
+}
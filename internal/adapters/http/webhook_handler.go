@@ -0,0 +1,193 @@
+package http
+
+import (
+	"Gin/internal/core/domain"
+	"Gin/internal/core/ports"
+	"Gin/pkg/util"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+// Handles the HTTP requests related to the webhooks and their deliveries.
+type WebhookHandler struct {
+	webhookService ports.WebhookDrivingPort
+	validate       *validator.Validate
+}
+
+// Creates a new instance of WebhookHandler.
+func NewWebhookHandler(webhookService ports.WebhookDrivingPort) *WebhookHandler {
+	return &WebhookHandler{
+		webhookService: webhookService,
+		validate:       validator.New(),
+	}
+}
+
+// CreateWebhook godoc
+// @Summary Register a webhook
+// @Description Subscribes a URL to domain events, all of them unless events lists the types to send.
+// @Description Every delivery is a signed POST request; the secret, generated unless given, is only returned here.
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param webhook body domain.NewWebhookInput true "Webhook creation object"
+// @Success 201 {object} domain.CreatedWebhook
+// @Failure 400 {object} map[string]string "Invalid input or unknown event type"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /webhooks [post]
+func (h *WebhookHandler) CreateWebhook(c *gin.Context) {
+	var input domain.NewWebhookInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return
+	}
+
+	if err := h.validate.Struct(input); err != nil {
+		validationErrors := err.(validator.ValidationErrors)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Validation failed", "details": validationErrors.Error()})
+		return
+	}
+
+	webhook, err := h.webhookService.CreateWebhook(c.Request.Context(), &input)
+
+	if err != nil {
+		h.webhookError(c, err, "Webhook not found", "Failed to create webhook")
+		return
+	}
+
+	c.JSON(http.StatusCreated, webhook)
+}
+
+// GetWebhooks godoc
+// @Summary List the webhooks
+// @Description Retrieves every webhook, oldest first. Secrets are not included.
+// @Tags webhooks
+// @Produce json
+// @Success 200 {array} domain.Webhook
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /webhooks [get]
+func (h *WebhookHandler) GetWebhooks(c *gin.Context) {
+	webhooks, err := h.webhookService.GetWebhooks(c.Request.Context())
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve webhooks", "details": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, webhooks)
+}
+
+// GetWebhook godoc
+// @Summary Get a webhook
+// @Description Retrieves a webhook by ID. Its secret is not included.
+// @Tags webhooks
+// @Produce json
+// @Param id path string true "Webhook ID"
+// @Success 200 {object} domain.Webhook
+// @Failure 404 {object} map[string]string "Webhook not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /webhooks/{id} [get]
+func (h *WebhookHandler) GetWebhook(c *gin.Context) {
+	webhook, err := h.webhookService.GetWebhook(c.Request.Context(), c.Param("id"))
+
+	if err != nil {
+		h.webhookError(c, err, "Webhook not found", "Failed to retrieve webhook")
+		return
+	}
+
+	c.JSON(http.StatusOK, webhook)
+}
+
+// DeleteWebhook godoc
+// @Summary Delete a webhook
+// @Description Unsubscribes a webhook and removes its deliveries, including the pending ones.
+// @Tags webhooks
+// @Produce json
+// @Param id path string true "Webhook ID"
+// @Success 204 "No Content"
+// @Failure 404 {object} map[string]string "Webhook not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /webhooks/{id} [delete]
+func (h *WebhookHandler) DeleteWebhook(c *gin.Context) {
+	err := h.webhookService.DeleteWebhook(c.Request.Context(), c.Param("id"))
+
+	if err != nil {
+		h.webhookError(c, err, "Webhook not found", "Failed to delete webhook")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetWebhookDeliveries godoc
+// @Summary List the deliveries of a webhook
+// @Description Retrieves a page of the deliveries of a webhook, newest first, with the status code,
+// @Description error and duration of their latest attempt.
+// @Tags webhooks
+// @Produce json
+// @Param id path string true "Webhook ID"
+// @Param limit query int false "Maximum number of deliveries to return (1-100, default 20)"
+// @Param cursor query string false "Opaque cursor returned as next_cursor by the previous page"
+// @Success 200 {object} domain.Page[domain.WebhookDelivery]
+// @Failure 400 {object} map[string]string "Invalid query parameters"
+// @Failure 404 {object} map[string]string "Webhook not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /webhooks/{id}/deliveries [get]
+func (h *WebhookHandler) GetWebhookDeliveries(c *gin.Context) {
+	page, err := parsePageRequest(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid query parameters", "details": err.Error()})
+		return
+	}
+
+	deliveries, err := h.webhookService.GetWebhookDeliveries(c.Request.Context(), c.Param("id"), page)
+
+	if err != nil {
+		h.webhookError(c, err, "Webhook not found", "Failed to retrieve webhook deliveries")
+		return
+	}
+
+	c.JSON(http.StatusOK, deliveries)
+}
+
+// RedeliverWebhookDelivery godoc
+// @Summary Redeliver a webhook delivery
+// @Description Queues a new delivery of the same payload to the webhook, whatever the outcome of the original one.
+// @Description The new delivery has its own ID and refers to the original one in redelivery_of.
+// @Tags webhooks
+// @Produce json
+// @Param id path string true "Webhook ID"
+// @Param delivery_id path string true "Delivery ID"
+// @Success 202 {object} domain.WebhookDelivery
+// @Failure 404 {object} map[string]string "Webhook or delivery not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /webhooks/{id}/deliveries/{delivery_id}/redeliver [post]
+func (h *WebhookHandler) RedeliverWebhookDelivery(c *gin.Context) {
+	delivery, err := h.webhookService.RedeliverWebhookDelivery(c.Request.Context(), c.Param("id"), c.Param("delivery_id"))
+
+	if err != nil {
+		h.webhookError(c, err, "Delivery not found", "Failed to redeliver webhook delivery")
+		return
+	}
+
+	c.JSON(http.StatusAccepted, delivery)
+}
+
+// Responds to a failed webhook request: 400 for invalid input, 404 with notFound for a missing resource
+// and 500 with message otherwise.
+func (h *WebhookHandler) webhookError(c *gin.Context, err error, notFound, message string) {
+	var validationErr *util.ValidationError
+	if errors.As(err, &validationErr) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Validation failed", "details": validationErr.Message})
+		return
+	}
+
+	if isNotFound(err) {
+		c.JSON(http.StatusNotFound, gin.H{"error": notFound, "details": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusInternalServerError, gin.H{"error": message, "details": err.Error()})
+}
@@ -0,0 +1,81 @@
+package webhook
+
+import (
+	"Gin/internal/core/domain"
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Request headers of the deliveries.
+const (
+	SignatureHeader = "X-Webhook-Signature" // sha256=<hex HMAC-SHA256 of "<timestamp>.<body>" keyed by the secret>
+	TimestampHeader = "X-Webhook-Timestamp" // Unix seconds at which the request was signed
+	EventHeader     = "X-Webhook-Event"
+	DeliveryHeader  = "X-Webhook-Delivery" // Delivery ID, the same across the retries of a delivery
+)
+
+// Largest part of a response body read before the connection is released.
+const maxResponseBody = 64 << 10
+
+// Implements the ports.WebhookSenderPort interface over HTTP.
+type HTTPSender struct {
+	client *http.Client
+}
+
+// Creates a new instance of HTTPSender giving up on a request after timeout.
+// Redirects are not followed: the receiver must answer at the registered URL.
+func NewHTTPSender(timeout time.Duration) *HTTPSender {
+	return &HTTPSender{client: &http.Client{
+		Timeout: timeout,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}}
+}
+
+// Returns the signature of a delivery sent at the given Unix timestamp, as set in the SignatureHeader.
+// Receivers compute it from the TimestampHeader and the raw body, and compare it in constant time.
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(body)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Sends a delivery to its webhook as a signed JSON POST request.
+func (s *HTTPSender) Send(ctx context.Context, webhook *domain.Webhook, delivery *domain.WebhookDelivery) (domain.WebhookResponse, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return domain.WebhookResponse{}, fmt.Errorf("webhook: failed to build request: %w", err)
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("User-Agent", "golang-api-webhooks")
+	request.Header.Set(SignatureHeader, Sign(webhook.Secret, timestamp, delivery.Payload))
+	request.Header.Set(TimestampHeader, timestamp)
+	request.Header.Set(EventHeader, string(delivery.EventType))
+	request.Header.Set(DeliveryHeader, delivery.ID)
+
+	start := time.Now()
+	response, err := s.client.Do(request)
+	if err != nil {
+		return domain.WebhookResponse{Duration: time.Since(start)}, fmt.Errorf("webhook: request failed: %w", err)
+	}
+	defer response.Body.Close()
+
+	// Reading the body lets the connection be reused
+	io.Copy(io.Discard, io.LimitReader(response.Body, maxResponseBody))
+
+	return domain.WebhookResponse{StatusCode: response.StatusCode, Duration: time.Since(start)}, nil
+}
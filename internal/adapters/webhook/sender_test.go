@@ -0,0 +1,124 @@
+package webhook
+
+import (
+	"Gin/internal/core/domain"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestSendSignsTheDelivery(t *testing.T) {
+	webhook := &domain.Webhook{ID: "webhook", Secret: "0123456789abcdef"}
+	delivery := &domain.WebhookDelivery{ID: "delivery", EventType: domain.EventStoryCreated, Payload: []byte(`{"event":"story.created"}`)}
+
+	received := make(chan *http.Request, 1)
+	var body []byte
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		received <- r
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+	webhook.URL = server.URL
+
+	response, err := NewHTTPSender(time.Second).Send(context.Background(), webhook, delivery)
+	if err != nil {
+		t.Fatalf("Send failed: %v", err)
+	}
+	if response.StatusCode != http.StatusNoContent {
+		t.Errorf("status code = %d, want %d", response.StatusCode, http.StatusNoContent)
+	}
+
+	request := <-received
+	if request.Method != http.MethodPost {
+		t.Errorf("method = %s, want POST", request.Method)
+	}
+	if string(body) != string(delivery.Payload) {
+		t.Errorf("body = %s, want %s", body, delivery.Payload)
+	}
+
+	timestamp := request.Header.Get(TimestampHeader)
+	sentAt, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil || time.Since(time.Unix(sentAt, 0)) > time.Minute {
+		t.Errorf("%s = %q, want the current Unix time", TimestampHeader, timestamp)
+	}
+
+	if got, want := request.Header.Get(SignatureHeader), Sign(webhook.Secret, timestamp, body); got != want {
+		t.Errorf("%s = %q, want %q", SignatureHeader, got, want)
+	}
+	if got := request.Header.Get(EventHeader); got != string(domain.EventStoryCreated) {
+		t.Errorf("%s = %q, want %q", EventHeader, got, domain.EventStoryCreated)
+	}
+	if got := request.Header.Get(DeliveryHeader); got != delivery.ID {
+		t.Errorf("%s = %q, want %q", DeliveryHeader, got, delivery.ID)
+	}
+}
+
+func TestSignMatchesAKnownSignature(t *testing.T) {
+	// echo -n '1700000000.{}' | openssl dgst -sha256 -hmac secret
+	want := "sha256=b8569b78799ff9e3cbff0fc2d63a33a2b57f3282abd07c37ae5e8e7d79a5f163"
+	if got := Sign("secret", "1700000000", []byte("{}")); got != want {
+		t.Errorf("Sign = %s, want %s", got, want)
+	}
+}
+
+func TestSendReportsErrorStatuses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	webhook := &domain.Webhook{URL: server.URL, Secret: "0123456789abcdef"}
+	response, err := NewHTTPSender(time.Second).Send(context.Background(), webhook, &domain.WebhookDelivery{Payload: []byte(`{}`)})
+
+	if err != nil {
+		t.Fatalf("Send failed: %v", err)
+	}
+	if response.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("status code = %d, want %d", response.StatusCode, http.StatusServiceUnavailable)
+	}
+}
+
+func TestSendDoesNotFollowRedirects(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/moved" {
+			t.Error("redirect was followed")
+		}
+		http.Redirect(w, r, "/moved", http.StatusFound)
+	}))
+	defer server.Close()
+
+	webhook := &domain.Webhook{URL: server.URL, Secret: "0123456789abcdef"}
+	response, err := NewHTTPSender(time.Second).Send(context.Background(), webhook, &domain.WebhookDelivery{Payload: []byte(`{}`)})
+
+	if err != nil {
+		t.Fatalf("Send failed: %v", err)
+	}
+	if response.StatusCode != http.StatusFound {
+		t.Errorf("status code = %d, want %d", response.StatusCode, http.StatusFound)
+	}
+}
+
+func TestSendTimesOut(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	webhook := &domain.Webhook{URL: server.URL, Secret: "0123456789abcdef"}
+	response, err := NewHTTPSender(50*time.Millisecond).Send(context.Background(), webhook, &domain.WebhookDelivery{Payload: []byte(`{}`)})
+
+	if err == nil {
+		t.Fatal("Send succeeded, want a timeout")
+	}
+	if response.StatusCode != 0 {
+		t.Errorf("status code = %d, want 0", response.StatusCode)
+	}
+}
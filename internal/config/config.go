@@ -1 +1,280 @@
 package config
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Supported storage drivers.
+const (
+	StoragePostgres = "postgres"
+	StorageSQLite   = "sqlite"
+	StorageMemory   = "memory"
+)
+
+// Database file used by the SQLite driver when DB_CONNECTION_STRING is not set.
+const DefaultSQLitePath = "golang-api.db"
+
+// Represents the application configuration, read from environment variables.
+type Config struct {
+	StorageDriver              string             // STORAGE_DRIVER: postgres (default), sqlite or memory
+	DBConnectionString         string             // DB_CONNECTION_STRING: PostgreSQL connection string or SQLite file path
+	DBReplicaConnectionStrings []string           // DB_REPLICA_CONNECTION_STRINGS: comma-separated PostgreSQL read replicas, empty to read from the primary only
+	DBAutoMigrate              bool               // DB_AUTO_MIGRATE: apply pending migrations at startup
+	DBQueryTimeout             time.Duration      // DB_QUERY_TIMEOUT: deadline for the queries of one request, 0 disables it
+	DBConnectTimeout           time.Duration      // DB_CONNECT_TIMEOUT: how long startup keeps retrying to reach the database, 0 tries once
+	DBMaxOpenConns             int                // DB_MAX_OPEN_CONNS: maximum open connections per pool, 0 means unlimited
+	DBMaxIdleConns             int                // DB_MAX_IDLE_CONNS: maximum idle connections kept per pool
+	DBConnMaxLifetime          time.Duration      // DB_CONN_MAX_LIFETIME: connections older than this are closed, 0 keeps them forever
+	DBConnMaxIdleTime          time.Duration      // DB_CONN_MAX_IDLE_TIME: connections idle longer than this are closed, 0 keeps them forever
+	SoftDeleteRetention        time.Duration      // SOFT_DELETE_RETENTION: how long deleted rows are kept before `purge` removes them
+	CacheEnabled               bool               // CACHE_ENABLED: cache story reads in process
+	CacheSize                  int                // CACHE_SIZE: maximum number of stories, and of story listings, kept in the cache
+	CacheTTL                   time.Duration      // CACHE_TTL: how long a cached story or listing is served
+	EventLog                   bool               // EVENT_LOG: log every domain event
+	OutboxPollInterval         time.Duration      // OUTBOX_POLL_INTERVAL: how often the relay looks for events to deliver
+	OutboxBatchSize            int                // OUTBOX_BATCH_SIZE: maximum number of events claimed by the relay at once
+	OutboxMaxAttempts          int                // OUTBOX_MAX_ATTEMPTS: deliveries tried for an event before it is marked failed
+	WebhookTimeout             time.Duration      // WEBHOOK_TIMEOUT: how long a webhook receiver has to answer a delivery
+	WebhookMaxAttempts         int                // WEBHOOK_MAX_ATTEMPTS: requests tried for a webhook delivery before it is marked failed
+	AuditSigningKey            ed25519.PrivateKey // AUDIT_SIGNING_KEY: base64 Ed25519 seed signing the audit checkpoints, empty disables them
+	AuditCheckpointInterval    time.Duration      // AUDIT_CHECKPOINT_INTERVAL: how often the server signs a checkpoint of the audit chain
+	TenantSources              []string           // TENANT_SOURCES: comma-separated sources of the tenant of a request (header, subdomain, token), token among them, empty serves the default tenant only
+	TenantHeader               string             // TENANT_HEADER: header holding the tenant for the header source
+	TenantBaseDomain           string             // TENANT_BASE_DOMAIN: domain under which the subdomain source reads the tenant, such as api.example.com
+	TenantTokenSecret          []byte             // TENANT_TOKEN_SECRET: HS256 secret of the bearer tokens read by the token source
+}
+
+// Deadline for the queries of one request when DB_QUERY_TIMEOUT is not set.
+const DefaultDBQueryTimeout = 10 * time.Second
+
+// How long soft-deleted rows are kept when SOFT_DELETE_RETENTION is not set.
+const DefaultSoftDeleteRetention = 30 * 24 * time.Hour
+
+// Story cache settings used when the matching variables are not set.
+const (
+	DefaultCacheSize = 1000
+	DefaultCacheTTL  = 30 * time.Second
+)
+
+// Outbox relay settings used when the matching variables are not set.
+const (
+	DefaultOutboxPollInterval = time.Second
+	DefaultOutboxBatchSize    = 100
+	DefaultOutboxMaxAttempts  = 10
+)
+
+// Webhook settings used when the matching variables are not set.
+const (
+	DefaultWebhookTimeout     = 10 * time.Second
+	DefaultWebhookMaxAttempts = 10
+)
+
+// How often the server signs a checkpoint of the audit chain when AUDIT_CHECKPOINT_INTERVAL is not set.
+const DefaultAuditCheckpointInterval = time.Hour
+
+// Sources of the tenant of a request, listed in TENANT_SOURCES.
+const (
+	TenantSourceHeader    = "header"    // The TENANT_HEADER request header
+	TenantSourceSubdomain = "subdomain" // The first label of the host, under TENANT_BASE_DOMAIN
+	TenantSourceToken     = "token"     // The tenant_id claim of a bearer token signed with TENANT_TOKEN_SECRET
+)
+
+// Header holding the tenant when TENANT_HEADER is not set.
+const DefaultTenantHeader = "X-Tenant-ID"
+
+// How long startup waits for the database when DB_CONNECT_TIMEOUT is not set.
+const DefaultDBConnectTimeout = 30 * time.Second
+
+// Connection pool settings used when the matching variables are not set.
+const (
+	DefaultDBMaxOpenConns    = 25
+	DefaultDBMaxIdleConns    = 10
+	DefaultDBConnMaxLifetime = 30 * time.Minute
+	DefaultDBConnMaxIdleTime = 5 * time.Minute
+)
+
+// Loads the configuration from the environment.
+func Load() (*Config, error) {
+	cfg := &Config{
+		StorageDriver:           os.Getenv("STORAGE_DRIVER"),
+		DBConnectionString:      os.Getenv("DB_CONNECTION_STRING"),
+		DBQueryTimeout:          DefaultDBQueryTimeout,
+		DBConnectTimeout:        DefaultDBConnectTimeout,
+		DBMaxOpenConns:          DefaultDBMaxOpenConns,
+		DBMaxIdleConns:          DefaultDBMaxIdleConns,
+		DBConnMaxLifetime:       DefaultDBConnMaxLifetime,
+		DBConnMaxIdleTime:       DefaultDBConnMaxIdleTime,
+		SoftDeleteRetention:     DefaultSoftDeleteRetention,
+		CacheSize:               DefaultCacheSize,
+		CacheTTL:                DefaultCacheTTL,
+		OutboxPollInterval:      DefaultOutboxPollInterval,
+		OutboxBatchSize:         DefaultOutboxBatchSize,
+		OutboxMaxAttempts:       DefaultOutboxMaxAttempts,
+		WebhookTimeout:          DefaultWebhookTimeout,
+		WebhookMaxAttempts:      DefaultWebhookMaxAttempts,
+		AuditCheckpointInterval: DefaultAuditCheckpointInterval,
+		TenantHeader:            DefaultTenantHeader,
+		TenantBaseDomain:        strings.ToLower(strings.TrimSpace(os.Getenv("TENANT_BASE_DOMAIN"))),
+		TenantTokenSecret:       []byte(os.Getenv("TENANT_TOKEN_SECRET")),
+	}
+
+	if cfg.StorageDriver == "" {
+		cfg.StorageDriver = StoragePostgres
+	}
+
+	switch cfg.StorageDriver {
+	case StoragePostgres, StorageMemory:
+	case StorageSQLite:
+		if cfg.DBConnectionString == "" {
+			cfg.DBConnectionString = DefaultSQLitePath
+		}
+	default:
+		return nil, fmt.Errorf("config: unsupported STORAGE_DRIVER %q (expected %s, %s or %s)", cfg.StorageDriver, StoragePostgres, StorageSQLite, StorageMemory)
+	}
+
+	for _, connStr := range strings.Split(os.Getenv("DB_REPLICA_CONNECTION_STRINGS"), ",") {
+		if connStr = strings.TrimSpace(connStr); connStr != "" {
+			cfg.DBReplicaConnectionStrings = append(cfg.DBReplicaConnectionStrings, connStr)
+		}
+	}
+
+	if len(cfg.DBReplicaConnectionStrings) > 0 && cfg.StorageDriver != StoragePostgres {
+		return nil, fmt.Errorf("config: DB_REPLICA_CONNECTION_STRINGS is only supported by the %s storage driver", StoragePostgres)
+	}
+
+	switches := []struct {
+		name   string
+		target *bool
+	}{
+		{"DB_AUTO_MIGRATE", &cfg.DBAutoMigrate},
+		{"CACHE_ENABLED", &cfg.CacheEnabled},
+		{"EVENT_LOG", &cfg.EventLog},
+	}
+
+	for _, sw := range switches {
+		if raw := os.Getenv(sw.name); raw != "" {
+			value, err := strconv.ParseBool(raw)
+			if err != nil {
+				return nil, fmt.Errorf("config: invalid %s %q: %w", sw.name, raw, err)
+			}
+			*sw.target = value
+		}
+	}
+
+	durations := []struct {
+		name    string
+		example string
+		target  *time.Duration
+	}{
+		{"DB_QUERY_TIMEOUT", "5s", &cfg.DBQueryTimeout},
+		{"DB_CONNECT_TIMEOUT", "30s", &cfg.DBConnectTimeout},
+		{"DB_CONN_MAX_LIFETIME", "30m", &cfg.DBConnMaxLifetime},
+		{"DB_CONN_MAX_IDLE_TIME", "5m", &cfg.DBConnMaxIdleTime},
+		{"SOFT_DELETE_RETENTION", "720h", &cfg.SoftDeleteRetention},
+		{"CACHE_TTL", "30s", &cfg.CacheTTL},
+		{"OUTBOX_POLL_INTERVAL", "1s", &cfg.OutboxPollInterval},
+		{"WEBHOOK_TIMEOUT", "10s", &cfg.WebhookTimeout},
+		{"AUDIT_CHECKPOINT_INTERVAL", "1h", &cfg.AuditCheckpointInterval},
+	}
+
+	for _, d := range durations {
+		if raw := os.Getenv(d.name); raw != "" {
+			value, err := time.ParseDuration(raw)
+			if err != nil || value < 0 {
+				return nil, fmt.Errorf("config: invalid %s %q, expected a duration such as %s", d.name, raw, d.example)
+			}
+			*d.target = value
+		}
+	}
+
+	counts := []struct {
+		name   string
+		target *int
+	}{
+		{"DB_MAX_OPEN_CONNS", &cfg.DBMaxOpenConns},
+		{"DB_MAX_IDLE_CONNS", &cfg.DBMaxIdleConns},
+		{"CACHE_SIZE", &cfg.CacheSize},
+		{"OUTBOX_BATCH_SIZE", &cfg.OutboxBatchSize},
+		{"OUTBOX_MAX_ATTEMPTS", &cfg.OutboxMaxAttempts},
+		{"WEBHOOK_MAX_ATTEMPTS", &cfg.WebhookMaxAttempts},
+	}
+
+	for _, c := range counts {
+		if raw := os.Getenv(c.name); raw != "" {
+			value, err := strconv.Atoi(raw)
+			if err != nil || value < 0 {
+				return nil, fmt.Errorf("config: invalid %s %q, expected a non-negative integer", c.name, raw)
+			}
+			*c.target = value
+		}
+	}
+
+	if cfg.CacheEnabled && (cfg.CacheSize == 0 || cfg.CacheTTL == 0) {
+		return nil, errors.New("config: CACHE_SIZE and CACHE_TTL must be positive when CACHE_ENABLED is set")
+	}
+
+	if cfg.OutboxPollInterval == 0 || cfg.OutboxBatchSize == 0 || cfg.OutboxMaxAttempts == 0 {
+		return nil, errors.New("config: OUTBOX_POLL_INTERVAL, OUTBOX_BATCH_SIZE and OUTBOX_MAX_ATTEMPTS must be positive")
+	}
+
+	if cfg.WebhookTimeout == 0 || cfg.WebhookMaxAttempts == 0 {
+		return nil, errors.New("config: WEBHOOK_TIMEOUT and WEBHOOK_MAX_ATTEMPTS must be positive")
+	}
+
+	if raw := os.Getenv("AUDIT_SIGNING_KEY"); raw != "" {
+		seed, err := base64.StdEncoding.DecodeString(raw)
+		if err != nil || len(seed) != ed25519.SeedSize {
+			return nil, fmt.Errorf("config: invalid AUDIT_SIGNING_KEY, expected the base64 encoding of a %d-byte Ed25519 seed", ed25519.SeedSize)
+		}
+		cfg.AuditSigningKey = ed25519.NewKeyFromSeed(seed)
+	}
+
+	if cfg.AuditCheckpointInterval == 0 {
+		return nil, errors.New("config: AUDIT_CHECKPOINT_INTERVAL must be positive")
+	}
+
+	if header := strings.TrimSpace(os.Getenv("TENANT_HEADER")); header != "" {
+		cfg.TenantHeader = header
+	}
+
+	for _, source := range strings.Split(os.Getenv("TENANT_SOURCES"), ",") {
+		switch source = strings.TrimSpace(source); source {
+		case "":
+		case TenantSourceHeader:
+		case TenantSourceSubdomain:
+			if cfg.TenantBaseDomain == "" {
+				return nil, fmt.Errorf("config: TENANT_BASE_DOMAIN is required by the %s tenant source", TenantSourceSubdomain)
+			}
+		case TenantSourceToken:
+			if len(cfg.TenantTokenSecret) == 0 {
+				return nil, fmt.Errorf("config: TENANT_TOKEN_SECRET is required by the %s tenant source", TenantSourceToken)
+			}
+		default:
+			return nil, fmt.Errorf("config: unsupported tenant source %q in TENANT_SOURCES (expected %s, %s or %s)", source, TenantSourceHeader, TenantSourceSubdomain, TenantSourceToken)
+		}
+
+		if source != "" {
+			cfg.TenantSources = append(cfg.TenantSources, source)
+		}
+	}
+
+	// Any caller can send any header or host, so they can only narrow down the tenant of a verified token
+	if len(cfg.TenantSources) > 0 && !slices.Contains(cfg.TenantSources, TenantSourceToken) {
+		return nil, fmt.Errorf("config: TENANT_SOURCES must include the %s source, which the %s and %s sources can only repeat", TenantSourceToken, TenantSourceHeader, TenantSourceSubdomain)
+	}
+
+	return cfg, nil
+}
+
+// Reports whether the configured storage driver needs a SQL database connection.
+func (c *Config) UsesDatabase() bool {
+	return c.StorageDriver != StorageMemory
+}
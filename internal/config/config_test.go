@@ -0,0 +1,32 @@
+package config
+
+import "testing"
+
+func TestLoadRequiresTheTokenTenantSource(t *testing.T) {
+	tests := []struct {
+		name    string
+		sources string
+		valid   bool
+	}{
+		{name: "no sources", sources: "", valid: true},
+		{name: "token", sources: "token", valid: true},
+		{name: "header and token", sources: "header,token", valid: true},
+		{name: "subdomain and token", sources: "token, subdomain", valid: true},
+		{name: "header only", sources: "header", valid: false},
+		{name: "header and subdomain", sources: "header,subdomain", valid: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("STORAGE_DRIVER", StorageMemory)
+			t.Setenv("TENANT_BASE_DOMAIN", "api.example.com")
+			t.Setenv("TENANT_TOKEN_SECRET", "0123456789abcdef")
+			t.Setenv("TENANT_SOURCES", tt.sources)
+
+			_, err := Load()
+			if valid := err == nil; valid != tt.valid {
+				t.Errorf("Load error = %v, want valid %v", err, tt.valid)
+			}
+		})
+	}
+}
@@ -0,0 +1,199 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// Represents a change made to a story or a user: who made it, through which request, and what it changed.
+type AuditEvent struct {
+	ID         int64           `json:"id"` // Assigned in increasing order
+	TenantID   string          `json:"-"`  // The tenant of the context the event was recorded with
+	Actor      string          `json:"actor"`
+	Action     AuditAction     `json:"action"`
+	EntityType AuditEntity     `json:"entity_type"`
+	EntityID   string          `json:"entity_id"` // Empty for purges, which remove many entities at once
+	OccurredAt time.Time       `json:"occurred_at"`
+	RequestID  string          `json:"request_id"` // Empty outside HTTP requests
+	ClientIP   string          `json:"client_ip"`  // Empty outside HTTP requests
+	Diff       json.RawMessage `json:"diff"`       // The fields that changed, as {"field": {"before": ..., "after": ...}}
+	PrevHash   string          `json:"prev_hash"`  // Hash of the previous event of the chain, empty for the first one
+	Hash       string          `json:"hash"`       // See AuditEvent.ComputeHash; empty for the events recorded before the chain existed
+}
+
+// Represents what a mutation did to its entity.
+type AuditAction string
+
+// Every audited action.
+const (
+	AuditCreate  AuditAction = "create"
+	AuditUpdate  AuditAction = "update"
+	AuditDelete  AuditAction = "delete"
+	AuditRestore AuditAction = "restore"
+	AuditPurge   AuditAction = "purge"
+)
+
+// Represents the type of an audited entity.
+type AuditEntity string
+
+// Every audited entity type.
+const (
+	AuditStory AuditEntity = "story"
+	AuditUser  AuditEntity = "user"
+)
+
+// Represents a field of an audit diff, with its value before and after the change.
+// A field that did not exist before or after the change, such as on a creation, is null on that side.
+type AuditChange struct {
+	Before any `json:"before"`
+	After  any `json:"after"`
+}
+
+// Returns an audit event whose diff holds the fields that differ between before and after,
+// either of which may be nil. Both are compared through their JSON encoding, so the diff names
+// fields as the API does. The tenant, actor and request come from the context; the ID is assigned by the repository
+// and the hashes by Chain.
+func NewAuditEvent(ctx context.Context, action AuditAction, entityType AuditEntity, entityID string, before, after any) (*AuditEvent, error) {
+	beforeFields, err := auditFields(before)
+	if err != nil {
+		return nil, err
+	}
+
+	afterFields, err := auditFields(after)
+	if err != nil {
+		return nil, err
+	}
+
+	diff := make(map[string]AuditChange)
+
+	for name, value := range beforeFields {
+		if changed, ok := afterFields[name]; !ok || !reflect.DeepEqual(value, changed) {
+			diff[name] = AuditChange{Before: value, After: changed}
+		}
+	}
+
+	for name, value := range afterFields {
+		if _, ok := beforeFields[name]; !ok {
+			diff[name] = AuditChange{After: value}
+		}
+	}
+
+	encoded, err := json.Marshal(diff)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode audit diff: %w", err)
+	}
+
+	request := AuditContextFrom(ctx)
+
+	return &AuditEvent{
+		TenantID:   TenantFrom(ctx),
+		Actor:      request.Actor,
+		Action:     action,
+		EntityType: entityType,
+		EntityID:   entityID,
+		OccurredAt: time.Now().UTC().Truncate(time.Microsecond), // The precision of the databases, so the hash survives a round trip
+		RequestID:  request.RequestID,
+		ClientIP:   request.ClientIP,
+		Diff:       encoded,
+	}, nil
+}
+
+// Returns the fields of an entity as encoded in JSON, or none for nil.
+func auditFields(entity any) (map[string]any, error) {
+	fields := make(map[string]any)
+
+	if entity == nil || reflect.ValueOf(entity).IsZero() {
+		return fields, nil
+	}
+
+	encoded, err := json.Marshal(entity)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode audited entity: %w", err)
+	}
+
+	if err := json.Unmarshal(encoded, &fields); err != nil {
+		return nil, fmt.Errorf("failed to decode audited entity: %w", err)
+	}
+
+	return fields, nil
+}
+
+// Represents who is making the changes of a context, and through which request.
+type AuditContext struct {
+	Actor     string
+	RequestID string
+	ClientIP  string
+}
+
+// Actor of the changes made without an identified caller.
+const AnonymousActor = "anonymous"
+
+type auditContextKey struct{}
+
+// Returns a context whose changes are audited as made by the given actor and request.
+func WithAuditContext(ctx context.Context, audit AuditContext) context.Context {
+	return context.WithValue(ctx, auditContextKey{}, audit)
+}
+
+// Returns the actor and request of the changes made with ctx, AnonymousActor if none was set.
+func AuditContextFrom(ctx context.Context) AuditContext {
+	audit, _ := ctx.Value(auditContextKey{}).(AuditContext)
+	if audit.Actor == "" {
+		audit.Actor = AnonymousActor
+	}
+
+	return audit
+}
+
+// Represents the filters and pagination of an audit log listing, newest events first.
+type AuditQuery struct {
+	EntityType AuditEntity
+	EntityID   string
+	Actor      string
+	From       *time.Time // Inclusive
+	To         *time.Time // Exclusive
+	Page       PageRequest
+}
+
+// Checks the filters of the query.
+func (q AuditQuery) Validate() error {
+	switch q.EntityType {
+	case "", AuditStory, AuditUser:
+	default:
+		return fmt.Errorf("entity_type: must be %s or %s", AuditStory, AuditUser)
+	}
+
+	if q.EntityID != "" && q.EntityType == "" {
+		return errors.New("entity_id: requires entity_type")
+	}
+
+	if q.From != nil && q.To != nil && !q.From.Before(*q.To) {
+		return errors.New("from: must be before to")
+	}
+
+	return nil
+}
+
+// Sort key of audit cursors, which only list events newest first.
+const auditCursorSort = "audit"
+
+// Returns the pagination cursor pointing at the given event.
+func AuditCursor(event AuditEvent) Cursor {
+	id := strconv.FormatInt(event.ID, 10)
+	return Cursor{Sort: auditCursorSort, Value: id, ID: id}
+}
+
+// Returns the event ID stored in a cursor produced by AuditCursor.
+func (c Cursor) AuditValue() (int64, error) {
+	id, err := strconv.ParseInt(c.Value, 10, 64)
+	if err != nil || c.Sort != auditCursorSort {
+		return 0, errors.New("invalid cursor")
+	}
+
+	return id, nil
+}
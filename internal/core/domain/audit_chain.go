@@ -0,0 +1,234 @@
+package domain
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Contents of an audit event covered by its hash, in a fixed order.
+// The ID is left out, being assigned on insertion: the order of the chain is given by the previous hashes.
+type auditHashContents struct {
+	PrevHash   string          `json:"prev_hash"`
+	TenantID   string          `json:"tenant_id,omitempty"` // Empty for the default tenant, so the events recorded before tenants keep their hashes
+	Actor      string          `json:"actor"`
+	Action     AuditAction     `json:"action"`
+	EntityType AuditEntity     `json:"entity_type"`
+	EntityID   string          `json:"entity_id"`
+	OccurredAt string          `json:"occurred_at"`
+	RequestID  string          `json:"request_id"`
+	ClientIP   string          `json:"client_ip"`
+	Diff       json.RawMessage `json:"diff"`
+}
+
+// Returns the hex SHA-256 hash of the contents of the event and of its PrevHash.
+// The diff is hashed in a canonical encoding, as PostgreSQL does not keep the order of its keys.
+func (e *AuditEvent) ComputeHash() string {
+	encoded, _ := json.Marshal(auditHashContents{
+		PrevHash:   e.PrevHash,
+		TenantID:   hashedTenant(e.TenantID),
+		Actor:      e.Actor,
+		Action:     e.Action,
+		EntityType: e.EntityType,
+		EntityID:   e.EntityID,
+		OccurredAt: e.OccurredAt.UTC().Format(time.RFC3339Nano),
+		RequestID:  e.RequestID,
+		ClientIP:   e.ClientIP,
+		Diff:       canonicalJSON(e.Diff),
+	})
+
+	sum := sha256.Sum256(encoded)
+	return hex.EncodeToString(sum[:])
+}
+
+// Returns the tenant as covered by the hash of an event, empty for the default tenant.
+func hashedTenant(tenant string) string {
+	if tenant == DefaultTenant {
+		return ""
+	}
+
+	return tenant
+}
+
+// Links the event to the chain whose latest hash is prevHash.
+func (e *AuditEvent) Chain(prevHash string) {
+	e.PrevHash = prevHash
+	e.Hash = e.ComputeHash()
+}
+
+// Returns a JSON document with its object keys sorted and without spaces, or the document as is if it is invalid.
+// Numbers keep their text, so none is rounded on the way.
+func canonicalJSON(document json.RawMessage) json.RawMessage {
+	decoder := json.NewDecoder(bytes.NewReader(document))
+	decoder.UseNumber()
+
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return document
+	}
+
+	canonical, err := json.Marshal(value)
+	if err != nil {
+		return document
+	}
+
+	return canonical
+}
+
+// Represents a signed statement of the hash of the audit chain of a tenant up to an event.
+// Exported out of the database, checkpoints reveal the events that were rewritten or removed since they were signed,
+// including the latest ones, which the chain alone cannot vouch for.
+type AuditCheckpoint struct {
+	ID        int64     `json:"id"`                  // Assigned in increasing order, 0 until saved
+	TenantID  string    `json:"tenant_id,omitempty"` // The tenant of the chain, empty in the exports made before tenants for the default one
+	EventID   int64     `json:"event_id"`
+	Hash      string    `json:"hash"`
+	CreatedAt time.Time `json:"created_at"`
+	Signature string    `json:"signature"` // Base64 Ed25519 signature of the tenant, the event ID, the hash and the creation time
+}
+
+// Returns a checkpoint of the chain of the tenant of the given event up to it, signed with key.
+func NewAuditCheckpoint(event *AuditEvent, key ed25519.PrivateKey) *AuditCheckpoint {
+	checkpoint := &AuditCheckpoint{
+		TenantID:  event.TenantID,
+		EventID:   event.ID,
+		Hash:      event.Hash,
+		CreatedAt: time.Now().UTC().Truncate(time.Microsecond),
+	}
+	checkpoint.Signature = base64.StdEncoding.EncodeToString(ed25519.Sign(key, checkpoint.signedContents()))
+
+	return checkpoint
+}
+
+// Reports whether the checkpoint was signed by the private key of key.
+func (c *AuditCheckpoint) VerifySignature(key ed25519.PublicKey) bool {
+	signature, err := base64.StdEncoding.DecodeString(c.Signature)
+	return err == nil && len(key) == ed25519.PublicKeySize && ed25519.Verify(key, c.signedContents(), signature)
+}
+
+// Returns the bytes covered by the signature of the checkpoint.
+// The tenant is left out for the default one, so the checkpoints signed before tenants keep their signatures.
+func (c *AuditCheckpoint) signedContents() []byte {
+	contents := fmt.Appendf(nil, "audit-checkpoint\n%d\n%s\n%s", c.EventID, c.Hash, c.CreatedAt.UTC().Format(time.RFC3339Nano))
+
+	if tenant := hashedTenant(c.Tenant()); tenant != "" {
+		contents = fmt.Appendf(contents, "\n%s", tenant)
+	}
+
+	return contents
+}
+
+// Returns the tenant of the chain of the checkpoint.
+func (c *AuditCheckpoint) Tenant() string {
+	if c.TenantID == "" {
+		return DefaultTenant
+	}
+
+	return c.TenantID
+}
+
+// Represents the outcome of a walk through the audit chain.
+type AuditVerification struct {
+	Valid       bool             `json:"valid"`
+	Events      int64            `json:"events"`      // Chained events checked
+	Unchained   int64            `json:"unchained"`   // Events recorded before the chain existed, which cannot be checked
+	Checkpoints int              `json:"checkpoints"` // Checkpoints matched against the chain
+	LastEventID int64            `json:"last_event_id,omitempty"`
+	LastHash    string           `json:"last_hash,omitempty"`
+	BrokenLink  *AuditBrokenLink `json:"broken_link,omitempty"` // The first problem found, which ends the walk
+}
+
+// Represents the first event at which the audit chain does not hold.
+type AuditBrokenLink struct {
+	EventID int64  `json:"event_id"`
+	Reason  string `json:"reason"`
+}
+
+// Walks the audit chain of a tenant one event at a time, in ID order, comparing it with checkpoints along the way.
+type AuditChainVerifier struct {
+	result      AuditVerification
+	checkpoints []AuditCheckpoint // Not reached yet, in event ID order
+}
+
+// Creates a new instance of AuditChainVerifier matching the chain against the checkpoints signed by key.
+// A checkpoint with another signature breaks the chain right away.
+func NewAuditChainVerifier(checkpoints []AuditCheckpoint, key ed25519.PublicKey) *AuditChainVerifier {
+	v := &AuditChainVerifier{checkpoints: append([]AuditCheckpoint(nil), checkpoints...)}
+	sort.SliceStable(v.checkpoints, func(i, j int) bool { return v.checkpoints[i].EventID < v.checkpoints[j].EventID })
+
+	for _, checkpoint := range v.checkpoints {
+		if !checkpoint.VerifySignature(key) {
+			v.fail(checkpoint.EventID, "checkpoint signature is invalid")
+			break
+		}
+	}
+
+	return v
+}
+
+// Checks the next event of the chain. Returns false once the chain is broken, after which events are ignored.
+func (v *AuditChainVerifier) Check(event *AuditEvent) bool {
+	if v.result.BrokenLink != nil {
+		return false
+	}
+
+	// A checkpoint of an event that is not there anymore
+	if len(v.checkpoints) > 0 && v.checkpoints[0].EventID < event.ID {
+		return v.fail(v.checkpoints[0].EventID, "event of a checkpoint is missing")
+	}
+
+	switch {
+	case event.Hash == "" && v.result.Events == 0:
+		// Only the events recorded before the chain existed may have no hash
+		v.result.Unchained++
+		v.result.LastEventID = event.ID
+		return true
+	case event.Hash == "":
+		return v.fail(event.ID, "event has no hash")
+	case event.PrevHash != v.result.LastHash && v.result.Events == 0:
+		return v.fail(event.ID, "first chained event does not start the chain")
+	case event.PrevHash != v.result.LastHash:
+		return v.fail(event.ID, fmt.Sprintf("previous hash does not match the hash of event %d", v.result.LastEventID))
+	case event.ComputeHash() != event.Hash:
+		return v.fail(event.ID, "contents do not match the hash")
+	}
+
+	for len(v.checkpoints) > 0 && v.checkpoints[0].EventID == event.ID {
+		if v.checkpoints[0].Hash != event.Hash {
+			return v.fail(event.ID, "hash does not match the checkpoint")
+		}
+		v.checkpoints = v.checkpoints[1:]
+		v.result.Checkpoints++
+	}
+
+	v.result.Events++
+	v.result.LastEventID = event.ID
+	v.result.LastHash = event.Hash
+
+	return true
+}
+
+// Returns the outcome of the walk, once every event has been checked.
+func (v *AuditChainVerifier) Result() *AuditVerification {
+	// A checkpoint past the end of the chain means its latest events were removed
+	if v.result.BrokenLink == nil && len(v.checkpoints) > 0 {
+		v.fail(v.checkpoints[0].EventID, "event of a checkpoint is missing")
+	}
+
+	result := v.result
+	result.Valid = result.BrokenLink == nil
+
+	return &result
+}
+
+// Records the first broken link of the chain.
+func (v *AuditChainVerifier) fail(eventID int64, reason string) bool {
+	v.result.BrokenLink = &AuditBrokenLink{EventID: eventID, Reason: reason}
+	return false
+}
@@ -0,0 +1,40 @@
+package domain
+
+import "time"
+
+// Represents a comment on a story, or a reply to such a comment.
+// Threads are one level deep: a reply cannot itself be replied to.
+type Comment struct {
+	ID         string    `json:"id"`
+	StoryID    string    `json:"story_id"`
+	ParentID   *string   `json:"parent_id"` // The comment replied to, nil for a top-level comment
+	AuthorID   string    `json:"author_id"` // The user who wrote the comment
+	Body       string    `json:"body"`
+	ReplyCount int64     `json:"reply_count"` // Always 0 for replies
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// Represents the input for creating a new comment
+type NewCommentInput struct {
+	AuthorID string `json:"author_id" validate:"required,uuid"`
+	ParentID string `json:"parent_id" validate:"omitempty,uuid"` // Set to reply to a top-level comment of the same story
+	Body     string `json:"body" validate:"required,min=1,max=5000"`
+}
+
+// Represents the input for updating a comment
+type UpdateCommentInput struct {
+	Body string `json:"body" validate:"required,min=1,max=5000"`
+}
+
+// Represents a listing of the comments of a story, oldest first.
+type CommentQuery struct {
+	StoryID  string
+	ParentID string // Lists the replies to this comment; empty lists the top-level comments
+	Page     PageRequest
+}
+
+// Returns the pagination cursor pointing at the given comment.
+func CommentCursor(comment Comment) Cursor {
+	return NewTimeCursor("", comment.CreatedAt, comment.ID)
+}
@@ -0,0 +1,27 @@
+package domain
+
+// Represents the state of one database connection pool, as reported by database/sql.
+type DBPoolStats struct {
+	Name               string `json:"name"`              // "primary" or "replica N"
+	Healthy            *bool  `json:"healthy,omitempty"` // Outcome of the last health check, replicas only
+	MaxOpenConnections int    `json:"max_open_connections"`
+	OpenConnections    int    `json:"open_connections"`
+	InUse              int    `json:"in_use"`
+	Idle               int    `json:"idle"`
+	WaitCount          int64  `json:"wait_count"`       // Connections waited for since startup
+	WaitDurationMillis int64  `json:"wait_duration_ms"` // Total time spent waiting for a connection
+	MaxIdleClosed      int64  `json:"max_idle_closed"`
+	MaxIdleTimeClosed  int64  `json:"max_idle_time_closed"`
+	MaxLifetimeClosed  int64  `json:"max_lifetime_closed"`
+}
+
+// Represents the counters of an in-process cache.
+type CacheStats struct {
+	Name      string `json:"name"`
+	Size      int    `json:"size"` // Entries currently cached, expired ones included until they are next read
+	Capacity  int    `json:"capacity"`
+	TTLMillis int64  `json:"ttl_ms"`
+	Hits      int64  `json:"hits"`
+	Misses    int64  `json:"misses"`
+	Evictions int64  `json:"evictions"` // Entries dropped to make room
+}
@@ -0,0 +1,31 @@
+package domain
+
+import "time"
+
+// Delay before the first retry of a failed delivery, doubled after each failed attempt up to MaxRetryDelay.
+const (
+	FirstRetryDelay = time.Second
+	MaxRetryDelay   = 10 * time.Minute
+)
+
+// Returns how long to wait before the next delivery attempt, after the given number of failed attempts.
+func RetryDelay(attempts int) time.Duration {
+	delay := FirstRetryDelay
+	for i := 1; i < attempts && delay < MaxRetryDelay; i++ {
+		delay *= 2
+	}
+
+	return min(delay, MaxRetryDelay)
+}
+
+// Represents the outcome of a delivery run over a batch of due deliveries (outbox entries, webhook deliveries).
+type DeliveryRun struct {
+	Delivered int // Succeeded
+	Retried   int // Failed, will be attempted again
+	Failed    int // Failed for the last time
+}
+
+// Returns the number of deliveries the run went through.
+func (r DeliveryRun) Total() int {
+	return r.Delivered + r.Retried + r.Failed
+}
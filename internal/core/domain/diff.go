@@ -0,0 +1,233 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+)
+
+// Represents what happened to a line between two texts.
+type DiffOp string
+
+// Operations of a line-level diff.
+const (
+	DiffEqual  DiffOp = "equal"
+	DiffInsert DiffOp = "insert"
+	DiffDelete DiffOp = "delete"
+)
+
+// Represents one line of a diff.
+type DiffLine struct {
+	Op   DiffOp `json:"op"`
+	Text string `json:"text"`
+}
+
+// Maximum number of lines of each text left to compare once their common first and last lines are set aside.
+// The comparison takes time proportional to the lines times the differences, so larger diffs are refused.
+const MaxDiffLines = 5000
+
+// Returned by DiffLines when the texts have more than MaxDiffLines lines left to compare.
+var ErrDiffTooLarge = errors.New("diff is too large")
+
+// Returns the line-level differences turning a into b, a shortest edit script found with the linear-space variant
+// of Myers' algorithm. Deletions come before insertions when lines are replaced.
+func DiffLines(a, b string) ([]DiffLine, error) {
+	x, y := splitLines(a), splitLines(b)
+
+	prefix := commonPrefix(x, y)
+	suffix := commonSuffix(x[prefix:], y[prefix:])
+
+	if len(x)-prefix-suffix > MaxDiffLines || len(y)-prefix-suffix > MaxDiffLines {
+		return nil, ErrDiffTooLarge
+	}
+
+	lines := make([]DiffLine, 0, max(len(x), len(y)))
+	lines = appendLines(lines, DiffEqual, x[:prefix])
+	lines = appendDiff(lines, x[prefix:len(x)-suffix], y[prefix:len(y)-suffix])
+	lines = appendLines(lines, DiffEqual, x[len(x)-suffix:])
+
+	return deletionsFirst(lines), nil
+}
+
+// Appends the differences turning x into y, splitting both around the middle of a shortest edit script
+// until one side is empty. Only the middle snake search allocates, in proportion to len(x)+len(y).
+func appendDiff(lines []DiffLine, x, y []string) []DiffLine {
+	prefix := commonPrefix(x, y)
+	lines = appendLines(lines, DiffEqual, x[:prefix])
+	x, y = x[prefix:], y[prefix:]
+
+	suffix := commonSuffix(x, y)
+	equalTail := x[len(x)-suffix:]
+	x, y = x[:len(x)-suffix], y[:len(y)-suffix]
+
+	switch {
+	case len(x) == 0:
+		lines = appendLines(lines, DiffInsert, y)
+	case len(y) == 0:
+		lines = appendLines(lines, DiffDelete, x)
+	default:
+		if i, j, ok := middleSnake(x, y); ok {
+			lines = appendDiff(lines, x[:i], y[:j])
+			lines = appendDiff(lines, x[i:], y[j:])
+		} else {
+			lines = appendLines(lines, DiffDelete, x)
+			lines = appendLines(lines, DiffInsert, y)
+		}
+	}
+
+	return appendLines(lines, DiffEqual, equalTail)
+}
+
+// Searches forwards from the start and backwards from the end of x and y at the same time, and returns
+// the point where the two paths meet, which splits a shortest edit script in two. Reports false if the
+// texts have no line in common.
+func middleSnake(x, y []string) (int, int, bool) {
+	n, m := len(x), len(y)
+	maxD := (n + m + 1) / 2
+	offset := maxD
+	size := 2*maxD + 2
+
+	// forward[offset+k] is the furthest x reached on diagonal k from the start, backward[offset+k] from the end
+	forward := make([]int, size)
+	backward := make([]int, size)
+	for i := range forward {
+		forward[i], backward[i] = -1, -1
+	}
+	forward[offset+1], backward[offset+1] = 0, 0
+
+	delta := n - m
+	odd := delta%2 != 0
+
+	// Diagonals that went past the end of a text are not explored again
+	forwardStart, forwardEnd, backwardStart, backwardEnd := 0, 0, 0, 0
+
+	for d := 0; d < maxD; d++ {
+		for k := -d + forwardStart; k <= d-forwardEnd; k += 2 {
+			var i int
+			if k == -d || (k != d && forward[offset+k-1] < forward[offset+k+1]) {
+				i = forward[offset+k+1]
+			} else {
+				i = forward[offset+k-1] + 1
+			}
+
+			j := i - k
+			for i < n && j < m && x[i] == y[j] {
+				i++
+				j++
+			}
+			forward[offset+k] = i
+
+			switch {
+			case i > n:
+				forwardEnd += 2
+			case j > m:
+				forwardStart += 2
+			case odd:
+				reverse := offset + delta - k
+				if reverse >= 0 && reverse < size && backward[reverse] != -1 && i >= n-backward[reverse] {
+					return i, j, true
+				}
+			}
+		}
+
+		for k := -d + backwardStart; k <= d-backwardEnd; k += 2 {
+			var i int
+			if k == -d || (k != d && backward[offset+k-1] < backward[offset+k+1]) {
+				i = backward[offset+k+1]
+			} else {
+				i = backward[offset+k-1] + 1
+			}
+
+			j := i - k
+			for i < n && j < m && x[n-i-1] == y[m-j-1] {
+				i++
+				j++
+			}
+			backward[offset+k] = i
+
+			switch {
+			case i > n:
+				backwardEnd += 2
+			case j > m:
+				backwardStart += 2
+			case !odd:
+				reverse := offset + delta - k
+				if reverse >= 0 && reverse < size && forward[reverse] != -1 {
+					forwardI := forward[reverse]
+					forwardJ := forwardI - (reverse - offset)
+					if forwardI >= n-i {
+						return forwardI, forwardJ, true
+					}
+				}
+			}
+		}
+	}
+
+	return 0, 0, false
+}
+
+// Reorders every run of changed lines so its deletions come before its insertions.
+func deletionsFirst(lines []DiffLine) []DiffLine {
+	for start := 0; start < len(lines); {
+		if lines[start].Op == DiffEqual {
+			start++
+			continue
+		}
+
+		end := start
+		for end < len(lines) && lines[end].Op != DiffEqual {
+			end++
+		}
+
+		changed := make([]DiffLine, 0, end-start)
+		for _, op := range []DiffOp{DiffDelete, DiffInsert} {
+			for _, line := range lines[start:end] {
+				if line.Op == op {
+					changed = append(changed, line)
+				}
+			}
+		}
+		copy(lines[start:end], changed)
+
+		start = end
+	}
+
+	return lines
+}
+
+// Appends one line with the given operation per text.
+func appendLines(lines []DiffLine, op DiffOp, texts []string) []DiffLine {
+	for _, text := range texts {
+		lines = append(lines, DiffLine{Op: op, Text: text})
+	}
+
+	return lines
+}
+
+// Returns the number of first lines x and y have in common.
+func commonPrefix(x, y []string) int {
+	n := 0
+	for n < len(x) && n < len(y) && x[n] == y[n] {
+		n++
+	}
+
+	return n
+}
+
+// Returns the number of last lines x and y have in common.
+func commonSuffix(x, y []string) int {
+	n := 0
+	for n < len(x) && n < len(y) && x[len(x)-n-1] == y[len(y)-n-1] {
+		n++
+	}
+
+	return n
+}
+
+// Splits a text into lines, treating "\r\n" like "\n". An empty text has no lines.
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+
+	return strings.Split(strings.ReplaceAll(s, "\r\n", "\n"), "\n")
+}
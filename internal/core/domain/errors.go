@@ -0,0 +1,19 @@
+package domain
+
+import "errors"
+
+// Returned by repositories when the requested row does not exist or is soft-deleted.
+var (
+	ErrStoryNotFound       = errors.New("story not found")
+	ErrUserNotFound        = errors.New("user not found")
+	ErrCommentNotFound     = errors.New("comment not found")
+	ErrOutboxEntryNotFound = errors.New("outbox entry not found")
+	ErrWebhookNotFound     = errors.New("webhook not found")
+	ErrDeliveryNotFound    = errors.New("webhook delivery not found")
+)
+
+// Returned by repositories when the row was changed since it was read: its version no longer matches.
+var ErrVersionConflict = errors.New("version conflict")
+
+// Returned by repositories when another active user already has the email.
+var ErrEmailInUse = errors.New("email already in use")
@@ -0,0 +1,156 @@
+package domain
+
+import (
+	"slices"
+	"time"
+)
+
+// Identifies the kind of a domain event.
+type EventType string
+
+// Every domain event type, named after the entity and what happened to it.
+const (
+	EventStoryCreated   EventType = "story.created"
+	EventStoryUpdated   EventType = "story.updated"
+	EventStoryDeleted   EventType = "story.deleted"
+	EventStoryRestored  EventType = "story.restored"
+	EventStoriesPurged  EventType = "story.purged"
+	EventUserCreated    EventType = "user.created"
+	EventUserUpdated    EventType = "user.updated"
+	EventUserDeleted    EventType = "user.deleted"
+	EventUserRestored   EventType = "user.restored"
+	EventUsersPurged    EventType = "user.purged"
+	EventCommentCreated EventType = "comment.created"
+	EventCommentUpdated EventType = "comment.updated"
+	EventCommentDeleted EventType = "comment.deleted"
+)
+
+// Represents a change made by a service, announced once it is committed.
+type Event interface {
+	EventType() EventType
+}
+
+// Announces a new story.
+type StoryCreated struct {
+	Story Story `json:"story"`
+}
+
+// Announces a change to a story, with the names of the fields that changed (title, author, author_id, content, tags).
+type StoryUpdated struct {
+	Story   Story    `json:"story"`
+	Changes []string `json:"changes"`
+}
+
+// Announces the soft deletion of a story.
+type StoryDeleted struct {
+	StoryID string `json:"story_id"`
+}
+
+// Announces that a soft-deleted story is back.
+type StoryRestored struct {
+	Story Story `json:"story"`
+}
+
+// Announces that the stories deleted before a given time were removed for good.
+type StoriesPurged struct {
+	DeletedBefore time.Time `json:"deleted_before"`
+	Count         int64     `json:"count"`
+}
+
+// Announces a new user.
+type UserCreated struct {
+	User User `json:"user"`
+}
+
+// Announces a change to a user, with the names of the fields that changed (email, name).
+type UserUpdated struct {
+	User    User     `json:"user"`
+	Changes []string `json:"changes"`
+}
+
+// Announces the soft deletion of a user.
+type UserDeleted struct {
+	UserID string `json:"user_id"`
+}
+
+// Announces that a soft-deleted user is back.
+type UserRestored struct {
+	User User `json:"user"`
+}
+
+// Announces that the users deleted before a given time were removed for good.
+type UsersPurged struct {
+	DeletedBefore time.Time `json:"deleted_before"`
+	Count         int64     `json:"count"`
+}
+
+// Announces a new comment or reply.
+type CommentCreated struct {
+	Comment Comment `json:"comment"`
+}
+
+// Announces a change to the body of a comment.
+type CommentUpdated struct {
+	Comment Comment `json:"comment"`
+}
+
+// Announces the deletion of a comment, along with its replies.
+type CommentDeleted struct {
+	CommentID string `json:"comment_id"`
+}
+
+func (StoryCreated) EventType() EventType   { return EventStoryCreated }
+func (StoryUpdated) EventType() EventType   { return EventStoryUpdated }
+func (StoryDeleted) EventType() EventType   { return EventStoryDeleted }
+func (StoryRestored) EventType() EventType  { return EventStoryRestored }
+func (StoriesPurged) EventType() EventType  { return EventStoriesPurged }
+func (UserCreated) EventType() EventType    { return EventUserCreated }
+func (UserUpdated) EventType() EventType    { return EventUserUpdated }
+func (UserDeleted) EventType() EventType    { return EventUserDeleted }
+func (UserRestored) EventType() EventType   { return EventUserRestored }
+func (UsersPurged) EventType() EventType    { return EventUsersPurged }
+func (CommentCreated) EventType() EventType { return EventCommentCreated }
+func (CommentUpdated) EventType() EventType { return EventCommentUpdated }
+func (CommentDeleted) EventType() EventType { return EventCommentDeleted }
+
+// Returns the names of the story fields that differ between before and after.
+func StoryChanges(before, after *Story) []string {
+	changes := make([]string, 0, 5)
+
+	if before.Title != after.Title {
+		changes = append(changes, "title")
+	}
+
+	if before.Author != after.Author {
+		changes = append(changes, "author")
+	}
+
+	if (before.AuthorID == nil) != (after.AuthorID == nil) || (before.AuthorID != nil && *before.AuthorID != *after.AuthorID) {
+		changes = append(changes, "author_id")
+	}
+
+	if before.Content != after.Content {
+		changes = append(changes, "content")
+	}
+
+	if !slices.Equal(before.Tags, after.Tags) {
+		changes = append(changes, "tags")
+	}
+
+	return changes
+}
+
+// Returns the names of the user fields that differ between before and after.
+func UserChanges(before, after *User) []string {
+	changes := make([]string, 0, 2)
+
+	if before.Email != after.Email {
+		changes = append(changes, "email")
+	}
+
+	if before.Name != after.Name {
+		changes = append(changes, "name")
+	}
+
+	return changes
+}
@@ -0,0 +1,169 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Represents a domain event recorded in the outbox, in the transaction of the change it announces,
+// and kept there until it has been delivered to the event sinks.
+type OutboxEntry struct {
+	ID            int64           `json:"id"` // Assigned in increasing order, so entries are delivered in the order they were recorded
+	TenantID      string          `json:"-"`  // Set by the repository from the tenant of the context; the event is delivered in this tenant
+	EventType     EventType       `json:"event_type"`
+	Payload       json.RawMessage `json:"payload"`
+	CreatedAt     time.Time       `json:"created_at"`
+	Attempts      int             `json:"attempts"`
+	LastError     *string         `json:"last_error"`
+	NextAttemptAt time.Time       `json:"next_attempt_at"`
+	DeliveredAt   *time.Time      `json:"delivered_at"`
+	FailedAt      *time.Time      `json:"failed_at"` // Set once the entry has run out of attempts
+}
+
+// Represents where an outbox entry stands in its delivery.
+type OutboxStatus string
+
+// Every outbox status.
+const (
+	OutboxPending   OutboxStatus = "pending"   // Waiting for its first or next attempt
+	OutboxDelivered OutboxStatus = "delivered" // Handed to every sink
+	OutboxFailed    OutboxStatus = "failed"    // Out of attempts, only retried on request
+)
+
+// Returns an outbox entry holding the event encoded as JSON.
+// The ID and times are assigned by the repository.
+func NewOutboxEntry(event Event) (*OutboxEntry, error) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s event: %w", event.EventType(), err)
+	}
+
+	return &OutboxEntry{EventType: event.EventType(), Payload: payload}, nil
+}
+
+type outboxEntryContextKey struct{}
+
+// Returns a context handing the event of the given outbox entry to the event sinks,
+// so a sink can recognize an entry it is handed again after a failure.
+func WithOutboxEntry(ctx context.Context, id int64) context.Context {
+	return context.WithValue(ctx, outboxEntryContextKey{}, id)
+}
+
+// Returns the ID of the outbox entry whose event is handed to the sinks with ctx, false outside of the outbox relay.
+func OutboxEntryFrom(ctx context.Context) (int64, bool) {
+	id, ok := ctx.Value(outboxEntryContextKey{}).(int64)
+	return id, ok
+}
+
+// Returns the status of the entry.
+func (e *OutboxEntry) Status() OutboxStatus {
+	switch {
+	case e.DeliveredAt != nil:
+		return OutboxDelivered
+	case e.FailedAt != nil:
+		return OutboxFailed
+	default:
+		return OutboxPending
+	}
+}
+
+// Decodes the event held by the entry.
+func (e *OutboxEntry) Event() (Event, error) {
+	decode, ok := eventDecoders[e.EventType]
+	if !ok {
+		return nil, fmt.Errorf("unknown event type %q", e.EventType)
+	}
+
+	return decode(e.Payload)
+}
+
+// Records a successful delivery.
+func (e *OutboxEntry) MarkDelivered(at time.Time) {
+	e.Attempts++
+	e.LastError = nil
+	e.DeliveredAt = &at
+}
+
+// Records a failed delivery: the entry is retried with an exponential backoff,
+// or marked as failed once it has been attempted maxAttempts times.
+func (e *OutboxEntry) MarkAttemptFailed(cause error, at time.Time, maxAttempts int) {
+	e.Attempts++
+	message := cause.Error()
+	e.LastError = &message
+
+	if e.Attempts >= maxAttempts {
+		e.FailedAt = &at
+		return
+	}
+
+	e.NextAttemptAt = at.Add(RetryDelay(e.Attempts))
+}
+
+// Makes a failed entry pending again, with a fresh set of attempts starting at the given time.
+func (e *OutboxEntry) Retry(at time.Time) {
+	e.Attempts = 0
+	e.FailedAt = nil
+	e.NextAttemptAt = at
+}
+
+// Represents the filters and pagination of an outbox listing, oldest entries first.
+type OutboxQuery struct {
+	Status OutboxStatus
+	Page   PageRequest
+}
+
+// Represents the number of outbox entries waiting for delivery or out of attempts.
+type OutboxCounts struct {
+	Pending int64 `json:"pending"`
+	Failed  int64 `json:"failed"`
+}
+
+// Sort key of outbox cursors, which only list entries oldest first.
+const outboxCursorSort = "id"
+
+// Returns the pagination cursor pointing at the given entry.
+func OutboxCursor(entry OutboxEntry) Cursor {
+	id := strconv.FormatInt(entry.ID, 10)
+	return Cursor{Sort: outboxCursorSort, Value: id, ID: id}
+}
+
+// Returns the entry ID stored in a cursor produced by OutboxCursor.
+func (c Cursor) OutboxValue() (int64, error) {
+	id, err := strconv.ParseInt(c.Value, 10, 64)
+	if err != nil || c.Sort != outboxCursorSort {
+		return 0, errors.New("invalid cursor")
+	}
+
+	return id, nil
+}
+
+// Decodes the payload of each event type back into its event.
+var eventDecoders = map[EventType]func(json.RawMessage) (Event, error){
+	EventStoryCreated:   decodeEvent[StoryCreated],
+	EventStoryUpdated:   decodeEvent[StoryUpdated],
+	EventStoryDeleted:   decodeEvent[StoryDeleted],
+	EventStoryRestored:  decodeEvent[StoryRestored],
+	EventStoriesPurged:  decodeEvent[StoriesPurged],
+	EventUserCreated:    decodeEvent[UserCreated],
+	EventUserUpdated:    decodeEvent[UserUpdated],
+	EventUserDeleted:    decodeEvent[UserDeleted],
+	EventUserRestored:   decodeEvent[UserRestored],
+	EventUsersPurged:    decodeEvent[UsersPurged],
+	EventCommentCreated: decodeEvent[CommentCreated],
+	EventCommentUpdated: decodeEvent[CommentUpdated],
+	EventCommentDeleted: decodeEvent[CommentDeleted],
+}
+
+// Decodes the payload of an event of type T.
+func decodeEvent[T Event](payload json.RawMessage) (Event, error) {
+	var event T
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return nil, fmt.Errorf("failed to decode %s event: %w", event.EventType(), err)
+	}
+
+	return event, nil
+}
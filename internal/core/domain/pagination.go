@@ -0,0 +1,114 @@
+package domain
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Default and maximum number of items returned by a list request.
+const (
+	DefaultPageLimit = 20
+	MaxPageLimit     = 100
+)
+
+// Represents the pagination parameters of a list request.
+type PageRequest struct {
+	Limit  int
+	Cursor *Cursor // nil for the first page
+}
+
+// Represents the position after which the next page starts.
+// Lists are ordered by (sort key, id), so the cursor holds both values of the last item returned,
+// plus the sort it was produced for so it cannot be replayed against a different ordering.
+type Cursor struct {
+	Sort  string `json:"s,omitempty"`
+	Value string `json:"v"`
+	ID    string `json:"i"`
+}
+
+// Returns a cursor positioned at an item whose sort key is a timestamp.
+func NewTimeCursor(sort string, t time.Time, id string) Cursor {
+	return Cursor{Sort: sort, Value: t.UTC().Format(time.RFC3339Nano), ID: id}
+}
+
+// Returns the cursor value parsed as a timestamp.
+func (c Cursor) TimeValue() (time.Time, error) {
+	t, err := time.Parse(time.RFC3339Nano, c.Value)
+	if err != nil {
+		return time.Time{}, errors.New("invalid cursor")
+	}
+
+	return t, nil
+}
+
+// Represents a page of items with the information needed to request the next one.
+type Page[T any] struct {
+	Items      []T    `json:"items"`
+	NextCursor string `json:"next_cursor"`
+	HasMore    bool   `json:"has_more"`
+}
+
+// Returns the limit clamped between 1 and MaxPageLimit, using DefaultPageLimit when unset.
+func (p PageRequest) NormalizedLimit() int {
+	if p.Limit <= 0 {
+		return DefaultPageLimit
+	}
+
+	if p.Limit > MaxPageLimit {
+		return MaxPageLimit
+	}
+
+	return p.Limit
+}
+
+// Encodes the cursor as an opaque URL-safe string.
+func (c Cursor) Encode() string {
+	raw, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// Decodes a cursor previously produced by Cursor.Encode.
+func DecodeCursor(s string) (*Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, errors.New("invalid cursor")
+	}
+
+	cursor := &Cursor{}
+	if err := json.Unmarshal(raw, cursor); err != nil || !cursor.hasValidID() {
+		return nil, errors.New("invalid cursor")
+	}
+
+	return cursor, nil
+}
+
+// Reports whether the ID of the cursor has the type of the IDs it is compared with:
+// a number for audit events and outbox entries, a UUID in its canonical form for every other listing.
+func (c Cursor) hasValidID() bool {
+	if c.Sort == auditCursorSort || c.Sort == outboxCursorSort {
+		_, err := strconv.ParseInt(c.ID, 10, 64)
+		return err == nil
+	}
+
+	id, err := uuid.Parse(c.ID)
+	return err == nil && id.String() == c.ID
+}
+
+// Builds a page from items fetched with one extra row beyond the limit.
+// The extra row only signals that more items exist and is dropped from the result.
+func NewPage[T any](items []T, limit int, cursorOf func(T) Cursor) *Page[T] {
+	page := &Page[T]{Items: items}
+
+	if len(items) > limit {
+		page.Items = items[:limit]
+		page.HasMore = true
+		page.NextCursor = cursorOf(page.Items[limit-1]).Encode()
+	}
+
+	return page
+}
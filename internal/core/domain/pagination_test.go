@@ -0,0 +1,33 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDecodeCursorChecksTheIDType(t *testing.T) {
+	at := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		cursor Cursor
+		valid  bool
+	}{
+		{name: "story", cursor: NewTimeCursor("-created_at", at, "3f1e2d4c-1111-4222-8333-444455556666"), valid: true},
+		{name: "story with a tampered ID", cursor: NewTimeCursor("-created_at", at, "1 OR 1=1"), valid: false},
+		{name: "story with a braced UUID", cursor: NewTimeCursor("-created_at", at, "{3f1e2d4c-1111-4222-8333-444455556666}"), valid: false},
+		{name: "comment without an ID", cursor: NewTimeCursor("", at, ""), valid: false},
+		{name: "audit", cursor: AuditCursor(AuditEvent{ID: 42}), valid: true},
+		{name: "audit with a UUID", cursor: Cursor{Sort: auditCursorSort, Value: "42", ID: "3f1e2d4c-1111-4222-8333-444455556666"}, valid: false},
+		{name: "outbox", cursor: OutboxCursor(OutboxEntry{ID: 7}), valid: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := DecodeCursor(tt.cursor.Encode())
+			if valid := err == nil; valid != tt.valid {
+				t.Errorf("DecodeCursor error = %v, want valid %v", err, tt.valid)
+			}
+		})
+	}
+}
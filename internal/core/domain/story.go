@@ -1,29 +1,77 @@
 package domain
 
 import (
+	"strings"
 	"time"
 )
 
 // Represents a story entity
 type Story struct {
-	ID        string    `json:"id"`
-	Title     string    `json:"title"`
-	Author    string    `json:"author"`
-	Content   string    `json:"content"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID        string     `json:"id"`
+	Title     string     `json:"title"`
+	Author    string     `json:"author"`    // Display name, kept equal to the name of the linked user
+	AuthorID  *string    `json:"author_id"` // The user who wrote the story, nil for stories not linked to a user
+	Content   string     `json:"content"`
+	Tags      []string   `json:"tags"` // Normalized tag names, sorted
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+	DeletedAt *time.Time `json:"deleted_at,omitempty"` // Set while the story is soft-deleted
+	Version   int64      `json:"version"`              // Incremented on every update, exposed as the ETag
+	TenantID  string     `json:"-"`                    // Set by the repository from the tenant of the context
 }
 
-// Represents the input for creating a new story
+// Represents the input for creating a new story.
+// The author is given as the ID of a user; a free-text author name is still accepted for stories without a user.
 type NewStoryInput struct {
-	Title   string `json:"title" validate:"required,min=3,max=255"`
-	Author  string `json:"author" validate:"required,min=3,max=255"`
-	Content string `json:"content" validate:"required,min=10"`
+	Title    string   `json:"title" validate:"required,min=3,max=255"`
+	AuthorID string   `json:"author_id" validate:"required_without=Author,omitempty,uuid"`
+	Author   string   `json:"author" validate:"required_without=AuthorID,omitempty,min=3,max=255"` // Deprecated: use AuthorID
+	Content  string   `json:"content" validate:"required,min=10,max=100000"`
+	Tags     []string `json:"tags" validate:"omitempty,max=20,dive,min=1,max=50"` // Normalized by the service
 }
 
 // Represents the input for updating a story
 type UpdateStoryInput struct {
-	Title   *string `json:"title" validate:"omitempty,min=3,max=255"`
-	Author  *string `json:"author" validate:"omitempty,min=3,max=255"`
-	Content *string `json:"content" validate:"omitempty,min=10"`
+	Title    *string   `json:"title" validate:"omitempty,min=3,max=255"`
+	AuthorID *string   `json:"author_id" validate:"omitempty,uuid"`
+	Author   *string   `json:"author" validate:"omitempty,min=3,max=255"` // Only for stories without a user
+	Content  *string   `json:"content" validate:"omitempty,min=10,max=100000"`
+	Tags     *[]string `json:"tags" validate:"omitempty,max=20,dive,min=1,max=50"` // Replaces all the tags; an empty list removes them
+}
+
+// Represents a full-text search over story titles and content
+type StorySearchQuery struct {
+	Text   string // Search terms in web search syntax: words, "quoted phrases", OR, -excluded
+	Limit  int
+	Offset int
+}
+
+// Splits the search text into lowercase terms to include and terms to exclude ("-term").
+// Quotes and the OR keyword are dropped, so every included term is required.
+// Used by storage adapters without a native web search syntax parser.
+func (q StorySearchQuery) Terms() (include, exclude []string) {
+	for _, field := range strings.Fields(strings.ToLower(q.Text)) {
+		field = strings.Trim(field, `"`)
+		if field == "" || field == "or" {
+			continue
+		}
+
+		if strings.HasPrefix(field, "-") {
+			if term := strings.Trim(field, `-"`); term != "" {
+				exclude = append(exclude, term)
+			}
+			continue
+		}
+
+		include = append(include, field)
+	}
+
+	return include, exclude
+}
+
+// Represents a story matched by a full-text search, with its relevance and a highlighted excerpt
+type StorySearchResult struct {
+	Story   Story   `json:"story"`
+	Rank    float64 `json:"rank"`
+	Snippet string  `json:"snippet"` // Matching fragments of the content, terms wrapped in <mark></mark>
 }
@@ -0,0 +1,68 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+)
+
+const (
+	MaxStoryImportRecords = 10000 // Maximum number of records in one import
+	StoryImportBatchSize  = 100   // Number of stories inserted per transaction in best effort mode
+)
+
+// Selects what happens to an import when some of its records are invalid.
+type StoryImportMode string
+
+const (
+	StoryImportAtomic     StoryImportMode = "atomic"      // Nothing is imported unless every record is valid
+	StoryImportBestEffort StoryImportMode = "best_effort" // Valid records are imported, invalid ones are reported
+)
+
+// Parses an import mode, defaulting to StoryImportAtomic when expr is empty.
+func ParseStoryImportMode(expr string) (StoryImportMode, error) {
+	switch mode := StoryImportMode(strings.ToLower(strings.TrimSpace(expr))); mode {
+	case "":
+		return StoryImportAtomic, nil
+	case StoryImportAtomic, StoryImportBestEffort:
+		return mode, nil
+	}
+
+	return "", errors.New("mode must be atomic or best_effort")
+}
+
+// Represents one record of an import, read from the given line of the input.
+type StoryImportRecord struct {
+	Line  int
+	Input NewStoryInput
+	Err   error // Set when the record could not be read or failed validation; the record is then never imported
+}
+
+// Outcome of an import record.
+type StoryImportStatus string
+
+const (
+	StoryImportCreated StoryImportStatus = "created" // The story was created
+	StoryImportInvalid StoryImportStatus = "invalid" // The record was rejected, see the error
+	StoryImportSkipped StoryImportStatus = "skipped" // The record is valid, but an atomic import was rejected because of other records
+)
+
+// Reports the outcome of one import record.
+type StoryImportResult struct {
+	Line   int               `json:"line"`
+	Status StoryImportStatus `json:"status"`
+	ID     string            `json:"id,omitempty"`    // ID of the created story
+	Error  string            `json:"error,omitempty"` // Why the record was rejected
+}
+
+// Reports the outcome of an import, record by record in input order.
+type StoryImportReport struct {
+	Mode    StoryImportMode     `json:"mode"`
+	Created int                 `json:"created"`
+	Invalid int                 `json:"invalid"`
+	Results []StoryImportResult `json:"results"`
+}
+
+// Reports whether an atomic import was rejected, so nothing was created.
+func (r *StoryImportReport) Rejected() bool {
+	return r.Mode == StoryImportAtomic && r.Invalid > 0
+}
@@ -0,0 +1,198 @@
+package domain
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Represents a field stories can be sorted by.
+type StorySortField string
+
+// Whitelisted story sort fields.
+const (
+	StorySortCreatedAt StorySortField = "created_at"
+	StorySortUpdatedAt StorySortField = "updated_at"
+	StorySortTitle     StorySortField = "title"
+	StorySortAuthor    StorySortField = "author"
+)
+
+// Reports whether the field is one of the whitelisted sort fields.
+func (f StorySortField) IsValid() bool {
+	switch f {
+	case StorySortCreatedAt, StorySortUpdatedAt, StorySortTitle, StorySortAuthor:
+		return true
+	}
+
+	return false
+}
+
+// Reports whether the field holds a timestamp.
+func (f StorySortField) IsTime() bool {
+	return f == StorySortCreatedAt || f == StorySortUpdatedAt
+}
+
+// Represents the ordering of a story listing. Ties are always broken by ID in the same direction.
+type StorySort struct {
+	Field StorySortField
+	Desc  bool
+}
+
+// The ordering used when no sort is requested: newest stories first.
+var DefaultStorySort = StorySort{Field: StorySortCreatedAt, Desc: true}
+
+// Returns the sort in its query-string form, e.g. "-created_at" or "title".
+func (s StorySort) String() string {
+	if s.Desc {
+		return "-" + string(s.Field)
+	}
+
+	return string(s.Field)
+}
+
+// Returns the cursor pointing at the given story under this sort.
+func (s StorySort) CursorFor(story Story) Cursor {
+	switch s.Field {
+	case StorySortUpdatedAt:
+		return NewTimeCursor(s.String(), story.UpdatedAt, story.ID)
+	case StorySortTitle:
+		return Cursor{Sort: s.String(), Value: story.Title, ID: story.ID}
+	case StorySortAuthor:
+		return Cursor{Sort: s.String(), Value: story.Author, ID: story.ID}
+	default:
+		return NewTimeCursor(s.String(), story.CreatedAt, story.ID)
+	}
+}
+
+// Returns the cursor sort key converted to the type of the sort field (time.Time or string).
+func (s StorySort) CursorValue(cursor Cursor) (any, error) {
+	if cursor.Sort != s.String() {
+		return nil, errors.New("cursor does not match the requested sort")
+	}
+
+	if s.Field.IsTime() {
+		return cursor.TimeValue()
+	}
+
+	return cursor.Value, nil
+}
+
+// Parses a sort expression such as "title", "-created_at" or "updated_at:asc".
+func ParseStorySort(expr string) (StorySort, error) {
+	expr = strings.TrimSpace(expr)
+	if expr == "" {
+		return DefaultStorySort, nil
+	}
+
+	sort := StorySort{}
+
+	if strings.HasPrefix(expr, "-") {
+		sort.Desc = true
+		expr = expr[1:]
+	} else if field, dir, found := strings.Cut(expr, ":"); found {
+		switch strings.ToLower(dir) {
+		case "asc":
+		case "desc":
+			sort.Desc = true
+		default:
+			return sort, errors.New("sort direction must be asc or desc")
+		}
+		expr = field
+	}
+
+	sort.Field = StorySortField(expr)
+	if !sort.Field.IsValid() {
+		return sort, errors.New("sort field must be one of created_at, updated_at, title, author")
+	}
+
+	return sort, nil
+}
+
+// Represents the filters, ordering and pagination of a story listing.
+type StoryQuery struct {
+	Author         string     // Exact author match (case-insensitive)
+	AuthorID       string     // Only stories linked to this user
+	Title          string     // Case-insensitive substring of the title
+	Tags           []string   // Normalized tag names
+	TagMatch       TagMatch   // Whether stories need any (the default) or all of the tags
+	CreatedAfter   *time.Time // Inclusive lower bound on created_at
+	CreatedBefore  *time.Time // Exclusive upper bound on created_at
+	UpdatedAfter   *time.Time // Inclusive lower bound on updated_at
+	UpdatedBefore  *time.Time // Exclusive upper bound on updated_at
+	IncludeDeleted bool       // Also list soft-deleted stories
+	Sort           StorySort
+	Page           PageRequest
+}
+
+// Checks that the query is consistent: ranges are not inverted and the cursor belongs to the sort.
+func (q StoryQuery) Validate() error {
+	if q.CreatedAfter != nil && q.CreatedBefore != nil && !q.CreatedAfter.Before(*q.CreatedBefore) {
+		return errors.New("created_after must be before created_before")
+	}
+
+	if q.UpdatedAfter != nil && q.UpdatedBefore != nil && !q.UpdatedAfter.Before(*q.UpdatedBefore) {
+		return errors.New("updated_after must be before updated_before")
+	}
+
+	if q.TagMatch != "" && q.TagMatch != TagMatchAny && q.TagMatch != TagMatchAll {
+		return errors.New("tag_match must be any or all")
+	}
+
+	if q.Page.Cursor != nil {
+		if _, err := q.Sort.CursorValue(*q.Page.Cursor); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Reports whether the story passes the filters of the query, whatever its pagination.
+// Mirrors the conditions the repositories put on the listing.
+func (q StoryQuery) Matches(story *Story) bool {
+	if story.DeletedAt != nil && !q.IncludeDeleted {
+		return false
+	}
+
+	if q.Author != "" && !strings.EqualFold(story.Author, q.Author) {
+		return false
+	}
+
+	if q.AuthorID != "" && (story.AuthorID == nil || *story.AuthorID != q.AuthorID) {
+		return false
+	}
+
+	if len(q.Tags) > 0 && !matchesTags(story.Tags, q.Tags, q.TagMatch) {
+		return false
+	}
+
+	if q.Title != "" && !strings.Contains(strings.ToLower(story.Title), strings.ToLower(q.Title)) {
+		return false
+	}
+
+	return inRange(story.CreatedAt, q.CreatedAfter, q.CreatedBefore) &&
+		inRange(story.UpdatedAt, q.UpdatedAfter, q.UpdatedBefore)
+}
+
+// Reports whether the story tags contain any or all of the wanted tags.
+func matchesTags(tags, wanted []string, match TagMatch) bool {
+	for _, tag := range wanted {
+		found := slices.Contains(tags, tag)
+
+		if found && match != TagMatchAll {
+			return true
+		}
+
+		if !found && match == TagMatchAll {
+			return false
+		}
+	}
+
+	return match == TagMatchAll
+}
+
+// Reports whether t is within [after, before), treating nil bounds as open.
+func inRange(t time.Time, after, before *time.Time) bool {
+	return (after == nil || !t.Before(*after)) && (before == nil || t.Before(*before))
+}
@@ -0,0 +1,83 @@
+package domain
+
+import (
+	"errors"
+	"strconv"
+	"time"
+)
+
+// Represents a previous version of a story, recorded each time the story is changed.
+// Revisions are numbered from 1 per story; the current story is the version after the latest revision.
+type StoryRevision struct {
+	StoryID   string    `json:"story_id"`
+	Revision  int       `json:"revision"`
+	Title     string    `json:"title"`
+	Author    string    `json:"author"`
+	AuthorID  *string   `json:"author_id"`
+	Content   string    `json:"content"`
+	CreatedAt time.Time `json:"created_at"` // When this version was replaced
+}
+
+// Returns a revision holding the current title, author and content of the story.
+// The revision number and creation time are assigned by the repository.
+func NewStoryRevision(story *Story) *StoryRevision {
+	return &StoryRevision{
+		StoryID:  story.ID,
+		Title:    story.Title,
+		Author:   story.Author,
+		AuthorID: story.AuthorID,
+		Content:  story.Content,
+	}
+}
+
+// Sort key of revision cursors, which only list revisions newest first.
+const storyRevisionCursorSort = "-revision"
+
+// Returns the pagination cursor pointing at the given revision.
+func StoryRevisionCursor(revision StoryRevision) Cursor {
+	return Cursor{Sort: storyRevisionCursorSort, Value: strconv.Itoa(revision.Revision), ID: revision.StoryID}
+}
+
+// Returns the revision number stored in a cursor produced by StoryRevisionCursor for the story.
+func (c Cursor) RevisionValue(storyID string) (int, error) {
+	revision, err := strconv.Atoi(c.Value)
+	if err != nil || c.Sort != storyRevisionCursorSort || c.ID != storyID {
+		return 0, errors.New("invalid cursor")
+	}
+
+	return revision, nil
+}
+
+// Represents the line-level differences between two versions of a story.
+type StoryDiff struct {
+	StoryID string     `json:"story_id"`
+	From    int        `json:"from"`
+	To      int        `json:"to"`
+	Title   []DiffLine `json:"title"`
+	Author  []DiffLine `json:"author"`
+	Content []DiffLine `json:"content"`
+}
+
+// Returns the differences between two versions of a story, or ErrDiffTooLarge.
+func NewStoryDiff(from, to *StoryRevision) (*StoryDiff, error) {
+	diff := &StoryDiff{StoryID: from.StoryID, From: from.Revision, To: to.Revision}
+
+	fields := []struct {
+		target   *[]DiffLine
+		from, to string
+	}{
+		{&diff.Title, from.Title, to.Title},
+		{&diff.Author, from.Author, to.Author},
+		{&diff.Content, from.Content, to.Content},
+	}
+
+	for _, field := range fields {
+		lines, err := DiffLines(field.from, field.to)
+		if err != nil {
+			return nil, err
+		}
+		*field.target = lines
+	}
+
+	return diff, nil
+}
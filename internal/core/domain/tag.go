@@ -0,0 +1,89 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+const (
+	MaxStoryTags = 20 // Maximum number of tags on one story
+	MaxTagLength = 50 // Maximum length of a normalized tag name
+	MaxQueryTags = 10 // Maximum number of tags in one listing filter
+)
+
+// Represents a tag with the number of stories using it.
+type TagUsage struct {
+	Name    string `json:"name"`
+	Stories int64  `json:"stories"` // Number of stories, not counting deleted ones
+}
+
+// Selects how a listing filtered by several tags matches them.
+type TagMatch string
+
+const (
+	TagMatchAny TagMatch = "any" // Stories with at least one of the tags
+	TagMatchAll TagMatch = "all" // Stories with every tag
+)
+
+// Parses a tag match mode, defaulting to TagMatchAny when expr is empty.
+func ParseTagMatch(expr string) (TagMatch, error) {
+	switch match := TagMatch(strings.ToLower(strings.TrimSpace(expr))); match {
+	case "":
+		return TagMatchAny, nil
+	case TagMatchAny, TagMatchAll:
+		return match, nil
+	}
+
+	return "", errors.New("tag_match must be any or all")
+}
+
+// Normalizes a tag name into a slug: lowercased and trimmed, with every run of characters
+// other than letters and digits replaced by a single "-". Returns an empty string if nothing is left.
+func NormalizeTag(raw string) string {
+	var slug strings.Builder
+
+	for _, r := range strings.ToLower(strings.TrimSpace(raw)) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			slug.WriteRune(r)
+			continue
+		}
+
+		if slug.Len() > 0 && !strings.HasSuffix(slug.String(), "-") {
+			slug.WriteByte('-')
+		}
+	}
+
+	return strings.TrimSuffix(slug.String(), "-")
+}
+
+// Normalizes a list of tag names, dropping duplicates and sorting them by name.
+// Returns an error if a tag is empty once normalized, too long, or if there are too many tags.
+func NormalizeTags(raw []string, limit int) ([]string, error) {
+	tags := make([]string, 0, len(raw))
+
+	for _, name := range raw {
+		tag := NormalizeTag(name)
+
+		if tag == "" {
+			return nil, fmt.Errorf("tag %q has no letters or digits", name)
+		}
+
+		if len([]rune(tag)) > MaxTagLength {
+			return nil, fmt.Errorf("tag %q is longer than %d characters", tag, MaxTagLength)
+		}
+
+		tags = append(tags, tag)
+	}
+
+	slices.Sort(tags)
+	tags = slices.Compact(tags)
+
+	if len(tags) > limit {
+		return nil, fmt.Errorf("at most %d tags are allowed", limit)
+	}
+
+	return tags, nil
+}
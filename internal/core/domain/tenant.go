@@ -0,0 +1,34 @@
+package domain
+
+import (
+	"context"
+	"regexp"
+)
+
+// Tenant of the requests that do not name one when multi-tenancy is disabled, and of the data created before it existed.
+const DefaultTenant = "default"
+
+// A tenant ID is a DNS label, so it can also be a subdomain: lowercase letters, digits and inner hyphens.
+var tenantPattern = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?$`)
+
+// Reports whether id is a valid tenant ID.
+func IsTenantID(id string) bool {
+	return tenantPattern.MatchString(id)
+}
+
+type tenantContextKey struct{}
+
+// Returns a context whose users and stories are those of the given tenant.
+func WithTenant(ctx context.Context, tenant string) context.Context {
+	return context.WithValue(ctx, tenantContextKey{}, tenant)
+}
+
+// Returns the tenant of ctx, DefaultTenant if none was set.
+// Every repository of users and stories only sees the data of this tenant.
+func TenantFrom(ctx context.Context) string {
+	if tenant, ok := ctx.Value(tenantContextKey{}).(string); ok && tenant != "" {
+		return tenant
+	}
+
+	return DefaultTenant
+}
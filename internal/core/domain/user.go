@@ -7,11 +7,20 @@ import (
 
 // Represents a user entity
 type User struct {
-	ID        string    `json:"id"`
-	Email     string    `json:"email"`
-	Name      string    `json:"name"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID        string     `json:"id"`
+	Email     string     `json:"email"`
+	Name      string     `json:"name"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+	DeletedAt *time.Time `json:"deleted_at,omitempty"` // Set while the user is soft-deleted
+	Version   int64      `json:"version"`              // Incremented on every update, exposed as the ETag
+	TenantID  string     `json:"-"`                    // Set by the repository from the tenant of the context
+}
+
+// Represents the options of a user listing
+type UserQuery struct {
+	IncludeDeleted bool // Also list soft-deleted users
+	Page           PageRequest
 }
 
 // Represents the input for creating a new user
@@ -31,5 +40,6 @@ func NewUser(email, name string) (*User, error) {
 		Name:      name,
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
+		Version:   1,
 	}, nil
 }
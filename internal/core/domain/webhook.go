@@ -0,0 +1,140 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// Represents a subscription of an external URL to domain events.
+type Webhook struct {
+	ID        string      `json:"id"`
+	TenantID  string      `json:"-"` // Set by the repository from the tenant of the context; only the events of this tenant are sent
+	URL       string      `json:"url"`
+	Events    []EventType `json:"events"` // Event types sent to the URL, empty for every event
+	Secret    string      `json:"-"`      // Signs the deliveries; only shown when the webhook is created
+	CreatedAt time.Time   `json:"created_at"`
+}
+
+// Represents a webhook as returned by its creation, the only time its secret is shown.
+type CreatedWebhook struct {
+	Webhook
+	Secret string `json:"secret"`
+}
+
+// Represents the input for registering a webhook.
+type NewWebhookInput struct {
+	URL    string   `json:"url" validate:"required,url,max=2000"`
+	Events []string `json:"events" validate:"max=20"`                   // Event types such as story.created, empty for every event
+	Secret string   `json:"secret" validate:"omitempty,min=16,max=200"` // Generated when empty
+}
+
+// Reports whether the webhook subscribes to events of the given type.
+func (w *Webhook) Matches(eventType EventType) bool {
+	return len(w.Events) == 0 || slices.Contains(w.Events, eventType)
+}
+
+// Reports whether the name is a known event type.
+func IsEventType(name string) bool {
+	_, ok := eventDecoders[EventType(name)]
+	return ok
+}
+
+// Represents the sending of an event to a webhook, with the outcome of its latest attempt.
+type WebhookDelivery struct {
+	ID            string          `json:"id"`
+	TenantID      string          `json:"-"` // Set by the repository from the tenant of the context, that of its webhook
+	WebhookID     string          `json:"webhook_id"`
+	EventType     EventType       `json:"event_type"`
+	Payload       json.RawMessage `json:"payload"`         // Request body, as signed
+	OutboxEntryID *int64          `json:"outbox_entry_id"` // The outbox entry of the event, nil for a redelivery; a webhook gets one delivery per entry
+	RedeliveryOf  *string         `json:"redelivery_of"`   // The delivery this one repeats, if requested through the API
+	CreatedAt     time.Time       `json:"created_at"`
+	Attempts      int             `json:"attempts"`
+	StatusCode    *int            `json:"status_code"` // Response status of the latest attempt, nil if no response was received
+	LastError     *string         `json:"last_error"`
+	DurationMs    *int64          `json:"duration_ms"` // Duration of the latest attempt
+	NextAttemptAt time.Time       `json:"next_attempt_at"`
+	DeliveredAt   *time.Time      `json:"delivered_at"`
+	FailedAt      *time.Time      `json:"failed_at"` // Set once the delivery has run out of attempts
+}
+
+// Represents the body sent to the webhooks: the event type, when it was sent for the first time, and the event itself.
+type WebhookPayload struct {
+	Event     EventType `json:"event"`
+	CreatedAt time.Time `json:"created_at"`
+	Data      Event     `json:"data"`
+}
+
+// Returns a delivery to the webhook of the event held by the given outbox entry.
+// The ID and times are assigned by the repository.
+func NewWebhookDelivery(webhook *Webhook, outboxEntryID int64, event Event, at time.Time) (*WebhookDelivery, error) {
+	payload, err := json.Marshal(WebhookPayload{Event: event.EventType(), CreatedAt: at, Data: event})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s event: %w", event.EventType(), err)
+	}
+
+	return &WebhookDelivery{WebhookID: webhook.ID, OutboxEntryID: &outboxEntryID, EventType: event.EventType(), Payload: payload}, nil
+}
+
+// Returns a new delivery sending the same payload to the same webhook again.
+func (d *WebhookDelivery) Redelivery() *WebhookDelivery {
+	return &WebhookDelivery{WebhookID: d.WebhookID, EventType: d.EventType, Payload: d.Payload, RedeliveryOf: &d.ID}
+}
+
+// Represents the outcome of an attempt to send a delivery.
+type WebhookResponse struct {
+	StatusCode int // 0 if no response was received
+	Duration   time.Duration
+}
+
+// Returns the status of the delivery, with the same meaning as for outbox entries.
+func (d *WebhookDelivery) Status() OutboxStatus {
+	switch {
+	case d.DeliveredAt != nil:
+		return OutboxDelivered
+	case d.FailedAt != nil:
+		return OutboxFailed
+	default:
+		return OutboxPending
+	}
+}
+
+// Records an attempt: a 2xx response delivers it, anything else schedules a retry with an exponential backoff,
+// or marks the delivery as failed once it has been attempted maxAttempts times.
+func (d *WebhookDelivery) RecordAttempt(response WebhookResponse, cause error, at time.Time, maxAttempts int) {
+	d.Attempts++
+	d.StatusCode, d.LastError = nil, nil
+
+	durationMs := response.Duration.Milliseconds()
+	d.DurationMs = &durationMs
+
+	if response.StatusCode != 0 {
+		d.StatusCode = &response.StatusCode
+	}
+
+	if cause == nil && (response.StatusCode < 200 || response.StatusCode > 299) {
+		cause = fmt.Errorf("unexpected response status %d", response.StatusCode)
+	}
+
+	if cause == nil {
+		d.DeliveredAt = &at
+		return
+	}
+
+	message := cause.Error()
+	d.LastError = &message
+
+	if d.Attempts >= maxAttempts {
+		d.FailedAt = &at
+		return
+	}
+
+	d.NextAttemptAt = at.Add(RetryDelay(d.Attempts))
+}
+
+// Returns the pagination cursor pointing at the given delivery.
+func WebhookDeliveryCursor(delivery WebhookDelivery) Cursor {
+	return NewTimeCursor("", delivery.CreatedAt, delivery.ID)
+}
@@ -0,0 +1,29 @@
+package ports
+
+import (
+	"Gin/internal/core/domain"
+	"context"
+)
+
+// This is the interface that the repository will use to store the audit log.
+// The log is append-only: events are never changed or removed. Each tenant has its own chain, and every method
+// but FindAuditTenants only sees the events and checkpoints of the tenant of the context.
+type AuditDrivenPort interface {
+	LockAuditTail(ctx context.Context) (string, error) // Hash of the latest event; other appends of the tenant wait until the transaction ends
+	SaveAuditEvents(ctx context.Context, events []*domain.AuditEvent) error
+	FindAuditEvents(ctx context.Context, query domain.AuditQuery) (*domain.Page[domain.AuditEvent], error) // Newest first
+	FindLastAuditEvent(ctx context.Context) (*domain.AuditEvent, error)                                    // nil when the chain is empty
+	StreamAuditEvents(ctx context.Context, fn func(*domain.AuditEvent) error) error                        // Oldest first
+	SaveAuditCheckpoint(ctx context.Context, checkpoint *domain.AuditCheckpoint) error
+	FindAuditCheckpoints(ctx context.Context) ([]domain.AuditCheckpoint, error) // Oldest first
+	FindAuditTenants(ctx context.Context) ([]string, error)                     // The tenants with audit events, in every tenant
+}
+
+// This is the interface that the handler will use to interact with the audit service.
+type AuditDrivingPort interface {
+	GetAuditEvents(ctx context.Context, query domain.AuditQuery) (*domain.Page[domain.AuditEvent], error)
+	VerifyAudit(ctx context.Context, checkpoints []domain.AuditCheckpoint) (*domain.AuditVerification, error)
+	CreateAuditCheckpoint(ctx context.Context) (*domain.AuditCheckpoint, error)
+	GetAuditCheckpoints(ctx context.Context) ([]domain.AuditCheckpoint, error)
+	GetAuditTenants(ctx context.Context) ([]string, error) // For the operators, who checkpoint and verify the chain of every tenant
+}
@@ -0,0 +1,23 @@
+package ports
+
+import (
+	"Gin/internal/core/domain"
+	"context"
+)
+
+// This is the interface that the repository will use to store the comments on stories.
+type CommentDrivenPort interface {
+	SaveComment(ctx context.Context, comment *domain.Comment) error
+	FindCommentByID(ctx context.Context, id string) (*domain.Comment, error)
+	FindComments(ctx context.Context, query domain.CommentQuery) (*domain.Page[domain.Comment], error) // Oldest first, with reply counts
+	UpdateComment(ctx context.Context, comment *domain.Comment) error
+	DeleteComment(ctx context.Context, id string) error // Also deletes the replies
+}
+
+// This is the interface that the handler will use to interact with the comment service.
+type CommentDrivingPort interface {
+	CreateComment(ctx context.Context, storyID string, input *domain.NewCommentInput) (*domain.Comment, error)
+	GetComments(ctx context.Context, query domain.CommentQuery) (*domain.Page[domain.Comment], error)
+	UpdateComment(ctx context.Context, id string, input *domain.UpdateCommentInput) (*domain.Comment, error)
+	DeleteComment(ctx context.Context, id string) error
+}
@@ -0,0 +1,15 @@
+package ports
+
+import (
+	"Gin/internal/core/domain"
+)
+
+// This is the interface that the admin handler will use to report on the database connection pools.
+type DatabaseStatsPort interface {
+	DatabaseStats() []domain.DBPoolStats // The primary pool first, then the read replicas
+}
+
+// This is the interface that the admin handler will use to report on the in-process caches.
+type CacheStatsPort interface {
+	CacheStats() []domain.CacheStats
+}
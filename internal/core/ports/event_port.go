@@ -0,0 +1,13 @@
+package ports
+
+import (
+	"Gin/internal/core/domain"
+	"context"
+)
+
+// This is the interface that the outbox relay will use to hand over the committed domain events.
+// A returned error makes the relay deliver the event again later, to every sink:
+// delivery is at least once, so sinks must tolerate duplicates.
+type EventSink interface {
+	Deliver(ctx context.Context, event domain.Event) error
+}
@@ -0,0 +1,30 @@
+package ports
+
+import (
+	"Gin/internal/core/domain"
+	"context"
+	"time"
+)
+
+// This is the interface that the services use to record domain events next to the changes they announce,
+// and that the outbox relay uses to deliver them.
+type OutboxDrivenPort interface {
+	SaveOutboxEntries(ctx context.Context, entries []*domain.OutboxEntry) error // Assigns the IDs and times; run it in the transaction of the change
+	// Returns up to limit pending entries due at now, oldest first, and postpones their next attempt by lease
+	// so other relays skip them while they are being delivered.
+	ClaimOutboxEntries(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*domain.OutboxEntry, error)
+	UpdateOutboxEntry(ctx context.Context, entry *domain.OutboxEntry) error // Saves the delivery state of the entry
+	FindOutboxEntry(ctx context.Context, id int64) (*domain.OutboxEntry, error)
+	FindOutboxEntries(ctx context.Context, query domain.OutboxQuery) (*domain.Page[domain.OutboxEntry], error)
+	CountOutboxEntries(ctx context.Context) (*domain.OutboxCounts, error)
+	PurgeDeliveredOutboxEntries(ctx context.Context, deliveredBefore time.Time) (int64, error) // Returns the number of entries removed
+}
+
+// This is the interface that the outbox relay and the admin handler will use.
+type OutboxDrivingPort interface {
+	DeliverOutbox(ctx context.Context) (*domain.DeliveryRun, error) // Delivers one batch of due entries
+	GetOutboxEntries(ctx context.Context, query domain.OutboxQuery) (*domain.Page[domain.OutboxEntry], error)
+	GetOutboxCounts(ctx context.Context) (*domain.OutboxCounts, error)
+	RetryOutboxEntry(ctx context.Context, id int64) (*domain.OutboxEntry, error)
+	PurgeDeliveredOutbox(ctx context.Context, retention time.Duration) (int64, error)
+}
@@ -1,21 +1,42 @@
 package ports
 
-import "Gin/internal/core/domain"
+import (
+	"Gin/internal/core/domain"
+	"context"
+	"time"
+)
 
 // This is the interface that the repository will use to interact with the database.
 type StoryDrivenPort interface {
-	SaveStory(story *domain.Story) error
-	FindStoryByID(id string) (*domain.Story, error)
-	FindAllStories() ([]domain.Story, error)
-	UpdateStory(story *domain.Story) error
-	DeleteStory(id string) error
+	SaveStory(ctx context.Context, story *domain.Story) error
+	SaveStories(ctx context.Context, stories []*domain.Story) error // Saves many new stories at once, for imports
+	FindStoryByID(ctx context.Context, id string) (*domain.Story, error)
+	FindAllStories(ctx context.Context, query domain.StoryQuery) (*domain.Page[domain.Story], error)
+	StreamStories(ctx context.Context, query domain.StoryQuery, fn func(*domain.Story) error) error // Calls fn for every story of the listing, ignoring its pagination
+	SearchStories(ctx context.Context, query domain.StorySearchQuery) ([]domain.StorySearchResult, error)
+	FindTags(ctx context.Context) ([]domain.TagUsage, error)    // Tags used by active stories, most used first
+	UpdateStory(ctx context.Context, story *domain.Story) error // Also replaces the tags of the story
+	DeleteStory(ctx context.Context, id string) error           // Soft delete: sets deleted_at
+	RestoreStory(ctx context.Context, id string) error
+	PurgeDeletedStories(ctx context.Context, deletedBefore time.Time) (int64, error) // Permanently removes soft-deleted stories
 }
 
 // This is the interface that the handler will use to interact with the service.
 type StoryDrivingPort interface {
-	CreateStory(input *domain.NewStoryInput) (*domain.Story, error)
-	GetStoryByID(id string) (*domain.Story, error)
-	GetAllStories() ([]domain.Story, error)
-	UpdateStory(id string, input *domain.UpdateStoryInput) (*domain.Story, error)
-	DeleteStory(id string) error
+	CreateStory(ctx context.Context, input *domain.NewStoryInput) (*domain.Story, error)
+	ImportStories(ctx context.Context, records []domain.StoryImportRecord, mode domain.StoryImportMode) (*domain.StoryImportReport, error)
+	GetStoryByID(ctx context.Context, id string) (*domain.Story, error)
+	GetAllStories(ctx context.Context, query domain.StoryQuery) (*domain.Page[domain.Story], error)
+	GetStoriesByAuthor(ctx context.Context, userID string, query domain.StoryQuery) (*domain.Page[domain.Story], error)
+	ExportStories(ctx context.Context, query domain.StoryQuery, fn func(*domain.Story) error) error // Streams every story of the listing to fn
+	SearchStories(ctx context.Context, query domain.StorySearchQuery) ([]domain.StorySearchResult, error)
+	GetTags(ctx context.Context) ([]domain.TagUsage, error)
+	UpdateStory(ctx context.Context, id string, input *domain.UpdateStoryInput, expectedVersion int64) (*domain.Story, error) // expectedVersion 0 skips the version check
+	DeleteStory(ctx context.Context, id string, expectedVersion int64) error
+	RestoreStory(ctx context.Context, id string) (*domain.Story, error)
+	PurgeDeletedStories(ctx context.Context, retention time.Duration) (int64, error)
+	GetStoryRevisions(ctx context.Context, id string, page domain.PageRequest) (*domain.Page[domain.StoryRevision], error)
+	GetStoryRevision(ctx context.Context, id string, revision int) (*domain.StoryRevision, error)
+	DiffStoryRevisions(ctx context.Context, id string, from, to int) (*domain.StoryDiff, error) // to = 0 compares with the current story
+	RestoreStoryRevision(ctx context.Context, id string, revision int) (*domain.Story, error)
 }
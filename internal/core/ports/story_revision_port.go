@@ -0,0 +1,14 @@
+package ports
+
+import (
+	"Gin/internal/core/domain"
+	"context"
+)
+
+// This is the interface that the repository will use to store the previous versions of stories.
+type StoryRevisionDrivenPort interface {
+	SaveRevision(ctx context.Context, revision *domain.StoryRevision) error // Assigns the next revision number of the story
+	FindRevision(ctx context.Context, storyID string, revision int) (*domain.StoryRevision, error)
+	FindRevisions(ctx context.Context, storyID string, page domain.PageRequest) (*domain.Page[domain.StoryRevision], error) // Newest first
+	LatestRevision(ctx context.Context, storyID string) (int, error)                                                        // 0 if the story has no revisions
+}
@@ -0,0 +1,22 @@
+package ports
+
+import "context"
+
+// Groups the repositories bound to one transaction.
+type Repositories struct {
+	Stories        StoryDrivenPort
+	StoryRevisions StoryRevisionDrivenPort
+	Users          UserDrivenPort
+	Comments       CommentDrivenPort
+	Outbox         OutboxDrivenPort
+	Audit          AuditDrivenPort
+}
+
+// UnitOfWork (or Transaction Port)
+// Runs several repository operations atomically.
+type UnitOfWork interface {
+	// Runs fn in a transaction, passing it repositories bound to that transaction.
+	// The transaction is committed if fn returns nil and rolled back otherwise.
+	// fn may be called again if the transaction fails to serialize, so it must not have side effects outside the repositories.
+	Execute(ctx context.Context, fn func(ctx context.Context, repos Repositories) error) error
+}
@@ -2,24 +2,32 @@ package ports
 
 import (
 	"Gin/internal/core/domain"
+	"context"
+	"time"
 )
 
 // UserDriverPort (or Application Service Port)
 // Defines the operations that the Core exposes to external adapters (HTTP, CLI, etc.).
 type UserDriverPort interface {
-	CreateUser(email, name string) (*domain.User, error)
-	GetUserByID(id string) (*domain.User, error)
-	GetAllUsers() ([]domain.User, error)                     // New: List all users
-	UpdateUser(id, email, name string) (*domain.User, error) // New: Update an existing user
-	DeleteUser(id string) error                              // New: Delete a user
+	CreateUser(ctx context.Context, email, name string) (*domain.User, error)
+	GetUserByID(ctx context.Context, id string) (*domain.User, error)
+	GetAllUsers(ctx context.Context, query domain.UserQuery) (*domain.Page[domain.User], error)          // List users page by page
+	ExportUsers(ctx context.Context, query domain.UserQuery, fn func(*domain.User) error) error          // Stream every user of the listing to fn
+	UpdateUser(ctx context.Context, id, email, name string, expectedVersion int64) (*domain.User, error) // New: Update an existing user, expectedVersion 0 skips the version check
+	DeleteUser(ctx context.Context, id string, expectedVersion int64) error                              // New: Delete a user
+	RestoreUser(ctx context.Context, id string) (*domain.User, error)                                    // Undo a soft delete
+	PurgeDeletedUsers(ctx context.Context, retention time.Duration) (int64, error)                       // Permanently remove users deleted longer ago than retention
 }
 
 // UserDrivenPort (or Repository Port)
 // Defines the operations that the Core needs from infrastructure adapters (DB, external services).
 type UserDrivenPort interface {
-	SaveUser(user *domain.User) error
-	FindUserByID(id string) (*domain.User, error)
-	FindAllUsers() ([]domain.User, error) // New: Find all users
-	UpdateUser(user *domain.User) error   // New: Update user in DB
-	DeleteUser(id string) error           // New: Delete user from DB
+	SaveUser(ctx context.Context, user *domain.User) error
+	FindUserByID(ctx context.Context, id string) (*domain.User, error)
+	FindAllUsers(ctx context.Context, query domain.UserQuery) (*domain.Page[domain.User], error) // Find a page of users
+	StreamUsers(ctx context.Context, query domain.UserQuery, fn func(*domain.User) error) error  // Call fn for every user of the listing, ignoring its pagination
+	UpdateUser(ctx context.Context, user *domain.User) error                                     // New: Update user in DB
+	DeleteUser(ctx context.Context, id string) error                                             // Soft delete: sets deleted_at
+	RestoreUser(ctx context.Context, id string) error                                            // Clear deleted_at
+	PurgeDeletedUsers(ctx context.Context, deletedBefore time.Time) (int64, error)               // Permanently remove soft-deleted users
 }
@@ -0,0 +1,41 @@
+package ports
+
+import (
+	"Gin/internal/core/domain"
+	"context"
+	"time"
+)
+
+// This is the interface that the repository will use to store the webhooks and their deliveries.
+type WebhookDrivenPort interface {
+	SaveWebhook(ctx context.Context, webhook *domain.Webhook) error
+	FindWebhookByID(ctx context.Context, id string) (*domain.Webhook, error)
+	FindWebhooks(ctx context.Context) ([]domain.Webhook, error)                            // Oldest first
+	DeleteWebhook(ctx context.Context, id string) error                                    // Also deletes its deliveries
+	SaveWebhookDeliveries(ctx context.Context, deliveries []*domain.WebhookDelivery) error // Skips those whose outbox entry already has a delivery to their webhook
+	// Returns up to limit pending deliveries due at now, oldest first, and postpones their next attempt by lease
+	// so other dispatchers skip them while they are being sent.
+	ClaimWebhookDeliveries(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*domain.WebhookDelivery, error)
+	UpdateWebhookDelivery(ctx context.Context, delivery *domain.WebhookDelivery) error // Saves the outcome of the latest attempt
+	FindWebhookDelivery(ctx context.Context, webhookID, id string) (*domain.WebhookDelivery, error)
+	FindWebhookDeliveries(ctx context.Context, webhookID string, page domain.PageRequest) (*domain.Page[domain.WebhookDelivery], error) // Newest first
+	PurgeWebhookDeliveries(ctx context.Context, createdBefore time.Time) (int64, error)                                                 // Removes the delivered and failed ones
+}
+
+// This is the interface that the webhook service will use to send a delivery to its webhook.
+// An error means no response was received; any response, whatever its status, is returned without error.
+type WebhookSenderPort interface {
+	Send(ctx context.Context, webhook *domain.Webhook, delivery *domain.WebhookDelivery) (domain.WebhookResponse, error)
+}
+
+// This is the interface that the handler and the webhook dispatcher will use to interact with the webhook service.
+type WebhookDrivingPort interface {
+	CreateWebhook(ctx context.Context, input *domain.NewWebhookInput) (*domain.CreatedWebhook, error)
+	GetWebhooks(ctx context.Context) ([]domain.Webhook, error)
+	GetWebhook(ctx context.Context, id string) (*domain.Webhook, error)
+	DeleteWebhook(ctx context.Context, id string) error
+	GetWebhookDeliveries(ctx context.Context, webhookID string, page domain.PageRequest) (*domain.Page[domain.WebhookDelivery], error)
+	RedeliverWebhookDelivery(ctx context.Context, webhookID, deliveryID string) (*domain.WebhookDelivery, error)
+	DispatchWebhooks(ctx context.Context) (*domain.DeliveryRun, error) // Sends one batch of due deliveries
+	PurgeWebhookDeliveries(ctx context.Context, retention time.Duration) (int64, error)
+}
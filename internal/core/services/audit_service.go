@@ -0,0 +1,179 @@
+package services
+
+import (
+	"Gin/internal/core/domain"
+	"Gin/internal/core/ports"
+	"Gin/pkg/util"
+	"context"
+	"crypto/ed25519"
+	"errors"
+)
+
+// Implements the ports.AuditDrivingPort interface for AuditService.
+type AuditService struct {
+	repo       ports.AuditDrivenPort
+	signingKey ed25519.PrivateKey // nil when checkpoints are disabled
+}
+
+// Creates a new instance of AuditService signing its checkpoints with signingKey, which may be nil to disable them.
+func NewAuditService(repo ports.AuditDrivenPort, signingKey ed25519.PrivateKey) *AuditService {
+	return &AuditService{repo: repo, signingKey: signingKey}
+}
+
+// Records in the audit log, in the transaction of the change, what a mutation did to an entity.
+// before and after are the entity on either side of the change, nil when it did not exist.
+// The event is chained to the latest one, whose hash stays locked until the transaction ends.
+func recordAudit(ctx context.Context, audit ports.AuditDrivenPort, action domain.AuditAction, entityType domain.AuditEntity, entityID string, before, after any) error {
+	event, err := domain.NewAuditEvent(ctx, action, entityType, entityID, before, after)
+	if err != nil {
+		return &util.InternalError{Message: "failed to encode audit event", Err: err}
+	}
+
+	return recordAuditEvents(ctx, audit, event)
+}
+
+// Appends events to the audit chain of the tenant in their order, locking its end once for all of them.
+// Must run in the transaction of the changes the events describe.
+func recordAuditEvents(ctx context.Context, audit ports.AuditDrivenPort, events ...*domain.AuditEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	prevHash, err := audit.LockAuditTail(ctx)
+	if err != nil {
+		return &util.InternalError{Message: "failed to lock the audit chain", Err: err}
+	}
+
+	for _, event := range events {
+		event.Chain(prevHash)
+		prevHash = event.Hash
+	}
+
+	if err := audit.SaveAuditEvents(ctx, events); err != nil {
+		return &util.InternalError{Message: "failed to record audit event", Err: err}
+	}
+
+	return nil
+}
+
+// Handles the retrieval of a page of the audit log, newest events first.
+func (s *AuditService) GetAuditEvents(ctx context.Context, query domain.AuditQuery) (*domain.Page[domain.AuditEvent], error) {
+	if err := query.Validate(); err != nil {
+		return nil, &util.ValidationError{Message: err.Error()}
+	}
+
+	if query.Page.Cursor != nil {
+		if _, err := query.Page.Cursor.AuditValue(); err != nil {
+			return nil, &util.ValidationError{Message: err.Error()}
+		}
+	}
+
+	events, err := s.repo.FindAuditEvents(ctx, query)
+
+	if err != nil {
+		return nil, &util.InternalError{Message: "failed to retrieve audit events", Err: err}
+	}
+
+	return events, nil
+}
+
+// Handles the verification of the audit chain of the tenant, from its first event to its latest, against its stored
+// checkpoints and those of the given ones that are of the tenant, such as checkpoints exported earlier.
+// Checkpoints are only checked when a signing key is set. The returned verification reports the first broken link, if any.
+func (s *AuditService) VerifyAudit(ctx context.Context, checkpoints []domain.AuditCheckpoint) (*domain.AuditVerification, error) {
+	var key ed25519.PublicKey
+
+	if s.signingKey != nil {
+		stored, err := s.repo.FindAuditCheckpoints(ctx)
+		if err != nil {
+			return nil, &util.InternalError{Message: "failed to retrieve audit checkpoints", Err: err}
+		}
+
+		tenant := domain.TenantFrom(ctx)
+		for _, checkpoint := range checkpoints {
+			if checkpoint.Tenant() == tenant {
+				stored = append(stored, checkpoint)
+			}
+		}
+
+		checkpoints = stored
+		key = s.signingKey.Public().(ed25519.PublicKey)
+	} else {
+		checkpoints = nil
+	}
+
+	verifier := domain.NewAuditChainVerifier(checkpoints, key)
+
+	err := s.repo.StreamAuditEvents(ctx, func(event *domain.AuditEvent) error {
+		if !verifier.Check(event) {
+			return errChainBroken
+		}
+		return nil
+	})
+
+	if err != nil && !errors.Is(err, errChainBroken) {
+		return nil, &util.InternalError{Message: "failed to read the audit chain", Err: err}
+	}
+
+	return verifier.Result(), nil
+}
+
+// Stops the walk through the audit chain at its first broken link.
+var errChainBroken = errors.New("audit chain broken")
+
+// Handles the signature of a checkpoint of the audit chain of the tenant up to its latest event.
+// Returns nil when there is nothing new to sign since the previous checkpoint.
+func (s *AuditService) CreateAuditCheckpoint(ctx context.Context) (*domain.AuditCheckpoint, error) {
+	if s.signingKey == nil {
+		return nil, &util.InternalError{Message: "audit checkpoints are disabled: no signing key is set"}
+	}
+
+	last, err := s.repo.FindLastAuditEvent(ctx)
+	if err != nil {
+		return nil, &util.InternalError{Message: "failed to retrieve the latest audit event", Err: err}
+	}
+
+	// Events recorded before the chain existed have no hash to vouch for
+	if last == nil || last.Hash == "" {
+		return nil, nil
+	}
+
+	checkpoints, err := s.repo.FindAuditCheckpoints(ctx)
+	if err != nil {
+		return nil, &util.InternalError{Message: "failed to retrieve audit checkpoints", Err: err}
+	}
+
+	if len(checkpoints) > 0 && checkpoints[len(checkpoints)-1].EventID == last.ID {
+		return nil, nil
+	}
+
+	checkpoint := domain.NewAuditCheckpoint(last, s.signingKey)
+
+	if err := s.repo.SaveAuditCheckpoint(ctx, checkpoint); err != nil {
+		return nil, &util.InternalError{Message: "failed to save audit checkpoint", Err: err}
+	}
+
+	return checkpoint, nil
+}
+
+// Handles the retrieval of every checkpoint of the audit chain of the tenant, oldest first.
+func (s *AuditService) GetAuditCheckpoints(ctx context.Context) ([]domain.AuditCheckpoint, error) {
+	checkpoints, err := s.repo.FindAuditCheckpoints(ctx)
+
+	if err != nil {
+		return nil, &util.InternalError{Message: "failed to retrieve audit checkpoints", Err: err}
+	}
+
+	return checkpoints, nil
+}
+
+// Handles the retrieval of the tenants that have an audit chain.
+func (s *AuditService) GetAuditTenants(ctx context.Context) ([]string, error) {
+	tenants, err := s.repo.FindAuditTenants(ctx)
+
+	if err != nil {
+		return nil, &util.InternalError{Message: "failed to retrieve the tenants of the audit log", Err: err}
+	}
+
+	return tenants, nil
+}
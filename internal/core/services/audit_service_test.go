@@ -0,0 +1,84 @@
+package services_test
+
+import (
+	"Gin/internal/adapters/db/memory"
+	"Gin/internal/core/domain"
+	"Gin/internal/core/services"
+	"context"
+	"crypto/ed25519"
+	"slices"
+	"testing"
+)
+
+func TestEachTenantHasItsOwnAuditChain(t *testing.T) {
+	acme := domain.WithTenant(context.Background(), "acme")
+	globex := domain.WithTenant(context.Background(), "globex")
+
+	users, audit := memory.NewUserRepository(), memory.NewAuditRepository()
+	uow := memory.NewUnitOfWork(memory.NewStoryRepository(), memory.NewStoryRevisionRepository(), users, memory.NewCommentRepository(), memory.NewOutboxRepository(), audit)
+
+	userService := services.NewUserService(users, uow)
+	auditService := services.NewAuditService(audit, ed25519.NewKeyFromSeed(make([]byte, ed25519.SeedSize)))
+
+	for _, email := range []string{"jane@example.com", "john@example.com"} {
+		if _, err := userService.CreateUser(acme, email, "Someone"); err != nil {
+			t.Fatalf("CreateUser failed: %v", err)
+		}
+	}
+	if _, err := userService.CreateUser(globex, "jane@example.com", "Jane"); err != nil {
+		t.Fatalf("CreateUser failed: %v", err)
+	}
+
+	// The chain of globex starts at its own first event, not after those of acme
+	events, err := audit.FindAuditEvents(globex, domain.AuditQuery{})
+	if err != nil {
+		t.Fatalf("FindAuditEvents failed: %v", err)
+	}
+	if len(events.Items) != 1 || events.Items[0].PrevHash != "" {
+		t.Errorf("audit events of globex = %+v, want one starting the chain", events.Items)
+	}
+
+	tenants, err := auditService.GetAuditTenants(context.Background())
+	if err != nil {
+		t.Fatalf("GetAuditTenants failed: %v", err)
+	}
+	if !slices.Equal(tenants, []string{"acme", "globex"}) {
+		t.Errorf("tenants = %v, want acme and globex", tenants)
+	}
+
+	acmeCheckpoint, err := auditService.CreateAuditCheckpoint(acme)
+	if err != nil {
+		t.Fatalf("CreateAuditCheckpoint failed: %v", err)
+	}
+	if _, err := auditService.CreateAuditCheckpoint(globex); err != nil {
+		t.Fatalf("CreateAuditCheckpoint failed: %v", err)
+	}
+
+	// The verification of globex only counts its own events and checkpoints, skipping the checkpoint of acme it is given
+	verification, err := auditService.VerifyAudit(globex, []domain.AuditCheckpoint{*acmeCheckpoint})
+	if err != nil {
+		t.Fatalf("VerifyAudit failed: %v", err)
+	}
+	if !verification.Valid || verification.Events != 1 || verification.Checkpoints != 1 {
+		t.Errorf("verification of globex = %+v, want its event and checkpoint", verification)
+	}
+
+	// A checkpoint of acme passed off as one of globex does not hold
+	moved := *acmeCheckpoint
+	moved.TenantID = "globex"
+	verification, err = auditService.VerifyAudit(globex, []domain.AuditCheckpoint{moved})
+	if err != nil {
+		t.Fatalf("VerifyAudit failed: %v", err)
+	}
+	if verification.Valid {
+		t.Errorf("verification of globex = %+v, want the checkpoint of acme rejected", verification)
+	}
+
+	verification, err = auditService.VerifyAudit(acme, nil)
+	if err != nil {
+		t.Fatalf("VerifyAudit failed: %v", err)
+	}
+	if !verification.Valid || verification.Events != 2 || verification.Checkpoints != 1 {
+		t.Errorf("verification of acme = %+v, want its events and checkpoint", verification)
+	}
+}
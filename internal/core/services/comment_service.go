@@ -0,0 +1,183 @@
+package services
+
+import (
+	"Gin/internal/core/domain"
+	"Gin/internal/core/ports"
+	"Gin/pkg/util"
+	"context"
+	"errors"
+	"fmt"
+)
+
+// Implements the ports.CommentDrivingPort interface for CommentService.
+type CommentService struct {
+	repo    ports.CommentDrivenPort
+	stories ports.StoryDrivenPort // Checks that the commented story exists
+	uow     ports.UnitOfWork      // Runs multi-step use cases in a transaction, and records their events in the outbox
+}
+
+// Creates a new instance of CommentService.
+func NewCommentService(repo ports.CommentDrivenPort, stories ports.StoryDrivenPort, uow ports.UnitOfWork) *CommentService {
+	return &CommentService{repo: repo, stories: stories, uow: uow}
+}
+
+// Handles the creation of a comment on a story, or of a reply to a top-level comment of the story.
+// The story, the author and the parent comment are checked in the same transaction as the insert.
+func (s *CommentService) CreateComment(ctx context.Context, storyID string, input *domain.NewCommentInput) (*domain.Comment, error) {
+	comment := &domain.Comment{
+		StoryID:  storyID,
+		AuthorID: input.AuthorID,
+		Body:     input.Body,
+		// ID, CreatedAt, UpdatedAt are automatically set by the repository
+	}
+
+	err := s.uow.Execute(ctx, func(ctx context.Context, repos ports.Repositories) error {
+		story, err := repos.Stories.FindStoryByID(ctx, storyID)
+
+		if err != nil {
+			return &util.InternalError{Message: "failed to retrieve story from repository", Err: err}
+		}
+
+		if story == nil {
+			return &util.NotFoundError{Message: fmt.Sprintf("story with ID %s not found", storyID)}
+		}
+
+		author, err := repos.Users.FindUserByID(ctx, input.AuthorID)
+
+		if err != nil {
+			return &util.InternalError{Message: "failed to retrieve comment author from repository", Err: err}
+		}
+
+		if author == nil {
+			return &util.ValidationError{Message: fmt.Sprintf("author_id: user with ID %s not found", input.AuthorID)}
+		}
+
+		if input.ParentID != "" {
+			parent, err := repos.Comments.FindCommentByID(ctx, input.ParentID)
+
+			if err != nil {
+				return &util.InternalError{Message: "failed to retrieve parent comment from repository", Err: err}
+			}
+
+			if parent == nil || parent.StoryID != storyID {
+				return &util.ValidationError{Message: fmt.Sprintf("parent_id: comment with ID %s not found on this story", input.ParentID)}
+			}
+
+			// Threads are one level deep
+			if parent.ParentID != nil {
+				return &util.ValidationError{Message: "parent_id: replies cannot be replied to, reply to the top-level comment instead"}
+			}
+
+			comment.ParentID = &parent.ID
+		}
+
+		if err := repos.Comments.SaveComment(ctx, comment); err != nil {
+			return &util.InternalError{Message: "failed to save comment", Err: err}
+		}
+
+		return recordEvents(ctx, repos.Outbox, domain.CommentCreated{Comment: *comment})
+	})
+
+	if err != nil {
+		return nil, serviceError(err, "failed to save comment")
+	}
+
+	return comment, nil
+}
+
+// Handles the retrieval of a page of the top-level comments of a story, or of the replies to one of them.
+func (s *CommentService) GetComments(ctx context.Context, query domain.CommentQuery) (*domain.Page[domain.Comment], error) {
+	if query.Page.Cursor != nil {
+		if _, err := query.Page.Cursor.TimeValue(); err != nil {
+			return nil, &util.ValidationError{Message: err.Error()}
+		}
+	}
+
+	story, err := s.stories.FindStoryByID(ctx, query.StoryID)
+
+	if err != nil {
+		return nil, &util.InternalError{Message: "failed to retrieve story from repository", Err: err}
+	}
+
+	if story == nil {
+		return nil, &util.NotFoundError{Message: fmt.Sprintf("story with ID %s not found", query.StoryID)}
+	}
+
+	if query.ParentID != "" {
+		parent, err := s.repo.FindCommentByID(ctx, query.ParentID)
+
+		if err != nil {
+			return nil, &util.InternalError{Message: "failed to retrieve parent comment from repository", Err: err}
+		}
+
+		if parent == nil || parent.StoryID != query.StoryID {
+			return nil, &util.NotFoundError{Message: fmt.Sprintf("comment with ID %s not found on story with ID %s", query.ParentID, query.StoryID)}
+		}
+	}
+
+	comments, err := s.repo.FindComments(ctx, query)
+
+	if err != nil {
+		return nil, &util.InternalError{Message: "failed to retrieve comments", Err: err}
+	}
+
+	return comments, nil
+}
+
+// Handles the update of the body of a comment.
+func (s *CommentService) UpdateComment(ctx context.Context, id string, input *domain.UpdateCommentInput) (*domain.Comment, error) {
+	var comment *domain.Comment
+
+	err := s.uow.Execute(ctx, func(ctx context.Context, repos ports.Repositories) error {
+		found, err := repos.Comments.FindCommentByID(ctx, id)
+
+		if err != nil {
+			return &util.InternalError{Message: "failed to retrieve comment for update from repository", Err: err}
+		}
+
+		if found == nil {
+			return &util.NotFoundError{Message: fmt.Sprintf("comment with ID %s not found for update", id)}
+		}
+
+		found.Body = input.Body
+
+		// The updated_at column is automatically updated by the repository
+		if err := repos.Comments.UpdateComment(ctx, found); err != nil {
+			if errors.Is(err, domain.ErrCommentNotFound) {
+				return &util.NotFoundError{Message: fmt.Sprintf("comment with ID %s not found for update", id)}
+			}
+
+			return &util.InternalError{Message: "failed to update comment in repository", Err: err}
+		}
+
+		comment = found
+		return recordEvents(ctx, repos.Outbox, domain.CommentUpdated{Comment: *found})
+	})
+
+	if err != nil {
+		return nil, serviceError(err, "failed to update comment")
+	}
+
+	return comment, nil
+}
+
+// Handles the deletion of a comment, along with its replies.
+func (s *CommentService) DeleteComment(ctx context.Context, id string) error {
+	err := s.uow.Execute(ctx, func(ctx context.Context, repos ports.Repositories) error {
+		if err := repos.Comments.DeleteComment(ctx, id); err != nil {
+			if errors.Is(err, domain.ErrCommentNotFound) {
+				return &util.NotFoundError{Message: fmt.Sprintf("comment with ID %s not found for deletion", id)}
+			}
+
+			return &util.InternalError{Message: "failed to delete comment from repository", Err: err}
+		}
+
+		return recordEvents(ctx, repos.Outbox, domain.CommentDeleted{CommentID: id})
+	})
+
+	if err != nil {
+		return serviceError(err, "failed to delete comment")
+	}
+
+	return nil
+}
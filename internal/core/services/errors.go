@@ -0,0 +1,32 @@
+package services
+
+import (
+	"Gin/pkg/util"
+	"errors"
+	"fmt"
+)
+
+// Returns err unchanged if it is already a validation, not found, conflict, precondition or internal error,
+// otherwise wraps it in an InternalError with the given message.
+func serviceError(err error, message string) error {
+	var validationErr *util.ValidationError
+	var notFoundErr *util.NotFoundError
+	var conflictErr *util.ConflictError
+	var preconditionErr *util.PreconditionFailedError
+	var internalErr *util.InternalError
+
+	if errors.As(err, &validationErr) || errors.As(err, &notFoundErr) || errors.As(err, &conflictErr) || errors.As(err, &preconditionErr) || errors.As(err, &internalErr) {
+		return err
+	}
+
+	return &util.InternalError{Message: message, Err: err}
+}
+
+// Returns a PreconditionFailedError if an expected version was given and does not match the current one.
+func checkVersion(resource, id string, expected, current int64) error {
+	if expected != 0 && expected != current {
+		return &util.PreconditionFailedError{Message: fmt.Sprintf("%s with ID %s is at version %d, not %d", resource, id, current, expected)}
+	}
+
+	return nil
+}
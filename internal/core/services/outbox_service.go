@@ -0,0 +1,182 @@
+package services
+
+import (
+	"Gin/internal/core/domain"
+	"Gin/internal/core/ports"
+	"Gin/pkg/util"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// How long a claimed batch is hidden from the other relays while it is being delivered.
+// Entries claimed by a relay that stopped mid-batch are picked up again once it expires.
+const outboxLease = 5 * time.Minute
+
+// Implements the ports.OutboxDrivingPort interface for OutboxService.
+type OutboxService struct {
+	repo        ports.OutboxDrivenPort
+	sinks       []ports.EventSink // Receive every event, in order
+	batchSize   int
+	maxAttempts int
+}
+
+// Creates a new instance of OutboxService delivering batches of batchSize entries to the sinks,
+// each entry being attempted at most maxAttempts times.
+func NewOutboxService(repo ports.OutboxDrivenPort, sinks []ports.EventSink, batchSize, maxAttempts int) *OutboxService {
+	return &OutboxService{repo: repo, sinks: sinks, batchSize: batchSize, maxAttempts: maxAttempts}
+}
+
+// Records events in the outbox, in the transaction of the change they announce, so they are delivered
+// if and only if the change is committed.
+func recordEvents(ctx context.Context, outbox ports.OutboxDrivenPort, events ...domain.Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	entries := make([]*domain.OutboxEntry, 0, len(events))
+
+	for _, event := range events {
+		entry, err := domain.NewOutboxEntry(event)
+		if err != nil {
+			return &util.InternalError{Message: "failed to encode domain event", Err: err}
+		}
+
+		entries = append(entries, entry)
+	}
+
+	if err := outbox.SaveOutboxEntries(ctx, entries); err != nil {
+		return &util.InternalError{Message: "failed to record domain events in the outbox", Err: err}
+	}
+
+	return nil
+}
+
+// Handles the delivery of the next batch of due outbox entries to the sinks.
+// The batch spans every tenant: each entry is handed over in the tenant it was recorded in, so the sinks only
+// reach the data of that tenant. It is marked delivered, or scheduled for a retry with backoff, as soon as it has been handled.
+func (s *OutboxService) DeliverOutbox(ctx context.Context) (*domain.DeliveryRun, error) {
+	entries, err := s.repo.ClaimOutboxEntries(ctx, time.Now(), outboxLease, s.batchSize)
+
+	if err != nil {
+		return nil, &util.InternalError{Message: "failed to claim outbox entries", Err: err}
+	}
+
+	run := &domain.DeliveryRun{}
+
+	for _, entry := range entries {
+		ctx := domain.WithTenant(ctx, entry.TenantID)
+
+		if err := s.deliver(ctx, entry); err != nil {
+			entry.MarkAttemptFailed(err, time.Now(), s.maxAttempts)
+
+			if entry.FailedAt != nil {
+				run.Failed++
+			} else {
+				run.Retried++
+			}
+		} else {
+			entry.MarkDelivered(time.Now())
+			run.Delivered++
+		}
+
+		// Unsaved outcomes are retried once the lease expires
+		if err := s.repo.UpdateOutboxEntry(ctx, entry); err != nil {
+			return run, &util.InternalError{Message: fmt.Sprintf("failed to save the delivery of outbox entry %d", entry.ID), Err: err}
+		}
+	}
+
+	return run, nil
+}
+
+// Hands the event of an entry to every sink, returning the errors of the sinks that failed.
+func (s *OutboxService) deliver(ctx context.Context, entry *domain.OutboxEntry) error {
+	event, err := entry.Event()
+	if err != nil {
+		return err
+	}
+
+	ctx = domain.WithOutboxEntry(ctx, entry.ID)
+
+	var errs []error
+	for _, sink := range s.sinks {
+		if err := sink.Deliver(ctx, event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Handles the retrieval of a page of outbox entries, oldest first.
+func (s *OutboxService) GetOutboxEntries(ctx context.Context, query domain.OutboxQuery) (*domain.Page[domain.OutboxEntry], error) {
+	switch query.Status {
+	case "":
+		query.Status = domain.OutboxPending
+	case domain.OutboxPending, domain.OutboxFailed, domain.OutboxDelivered:
+	default:
+		return nil, &util.ValidationError{Message: fmt.Sprintf("status: must be %s, %s or %s", domain.OutboxPending, domain.OutboxFailed, domain.OutboxDelivered)}
+	}
+
+	if query.Page.Cursor != nil {
+		if _, err := query.Page.Cursor.OutboxValue(); err != nil {
+			return nil, &util.ValidationError{Message: err.Error()}
+		}
+	}
+
+	entries, err := s.repo.FindOutboxEntries(ctx, query)
+
+	if err != nil {
+		return nil, &util.InternalError{Message: "failed to retrieve outbox entries", Err: err}
+	}
+
+	return entries, nil
+}
+
+// Handles the retrieval of the number of pending and failed outbox entries.
+func (s *OutboxService) GetOutboxCounts(ctx context.Context) (*domain.OutboxCounts, error) {
+	counts, err := s.repo.CountOutboxEntries(ctx)
+
+	if err != nil {
+		return nil, &util.InternalError{Message: "failed to count outbox entries", Err: err}
+	}
+
+	return counts, nil
+}
+
+// Handles the retry of an outbox entry that ran out of attempts: it is delivered again by the next relay run.
+func (s *OutboxService) RetryOutboxEntry(ctx context.Context, id int64) (*domain.OutboxEntry, error) {
+	entry, err := s.repo.FindOutboxEntry(ctx, id)
+
+	if err != nil {
+		return nil, &util.InternalError{Message: "failed to retrieve outbox entry from repository", Err: err}
+	}
+
+	if entry == nil {
+		return nil, &util.NotFoundError{Message: fmt.Sprintf("outbox entry %d not found", id)}
+	}
+
+	if entry.Status() != domain.OutboxFailed {
+		return nil, &util.ConflictError{Message: fmt.Sprintf("outbox entry %d is %s, only failed entries can be retried", id, entry.Status())}
+	}
+
+	entry.Retry(time.Now())
+
+	if err := s.repo.UpdateOutboxEntry(ctx, entry); err != nil {
+		return nil, &util.InternalError{Message: "failed to update outbox entry in repository", Err: err}
+	}
+
+	return entry, nil
+}
+
+// Handles the removal of the outbox entries delivered longer ago than the retention period.
+func (s *OutboxService) PurgeDeliveredOutbox(ctx context.Context, retention time.Duration) (int64, error) {
+	purged, err := s.repo.PurgeDeliveredOutboxEntries(ctx, time.Now().Add(-retention))
+
+	if err != nil {
+		return 0, &util.InternalError{Message: "failed to purge delivered outbox entries", Err: err}
+	}
+
+	return purged, nil
+}
@@ -4,44 +4,267 @@ import (
 	"Gin/internal/core/domain"
 	"Gin/internal/core/ports"
 	"Gin/pkg/util"
+	"context"
 	"database/sql"
 
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 )
 
 // Implrsments the ports.StoryDrivingPort interface for StoryService.
 type StoryService struct {
-	repo ports.StoryDrivenPort
+	repo      ports.StoryDrivenPort
+	revisions ports.StoryRevisionDrivenPort
+	users     ports.UserDrivenPort // Looks up the authors of stories
+	uow       ports.UnitOfWork     // Runs multi-step use cases in a transaction, and records their events in the outbox
 }
 
 // Creates a new instance of StoryService.
-func NewStoryService(repo ports.StoryDrivenPort) *StoryService {
-	return &StoryService{repo: repo}
+func NewStoryService(repo ports.StoryDrivenPort, revisions ports.StoryRevisionDrivenPort, users ports.UserDrivenPort, uow ports.UnitOfWork) *StoryService {
+	return &StoryService{repo: repo, revisions: revisions, users: users, uow: uow}
 }
 
 // Handles the creation of a new story.
-func (s *StoryService) CreateStory(input *domain.NewStoryInput) (*domain.Story, error) {
-	// Here you can perform additional validations or checks before saving the story.
-	// For example, you can check if the title or content are empty or if the author is not empty.
+// With an author ID, the author must be an existing user and the story shows the user's name;
+// the lookup and the insert run in one transaction so the user cannot be deleted in between.
+func (s *StoryService) CreateStory(ctx context.Context, input *domain.NewStoryInput) (*domain.Story, error) {
+	var story *domain.Story
+
+	err := s.uow.Execute(ctx, func(ctx context.Context, repos ports.Repositories) error {
+		created, err := newStory(ctx, repos.Users, input)
+		if err != nil {
+			return err
+		}
+
+		if err := repos.Stories.SaveStory(ctx, created); err != nil {
+			return &util.InternalError{Message: "failed to save story", Err: err}
+		}
+
+		if err := recordAudit(ctx, repos.Audit, domain.AuditCreate, domain.AuditStory, created.ID, nil, created); err != nil {
+			return err
+		}
+
+		story = created
+		return recordEvents(ctx, repos.Outbox, domain.StoryCreated{Story: *created})
+	})
+
+	if err != nil {
+		return nil, serviceError(err, "failed to save story")
+	}
+
+	return story, nil
+}
+
+// Builds the story described by the input, normalizing its tags and resolving its author.
+// Returns a ValidationError if the tags are invalid or the author is not a user.
+func newStory(ctx context.Context, users ports.UserDrivenPort, input *domain.NewStoryInput) (*domain.Story, error) {
+	tags, err := domain.NormalizeTags(input.Tags, domain.MaxStoryTags)
+	if err != nil {
+		return nil, &util.ValidationError{Message: "tags: " + err.Error()}
+	}
 
 	story := &domain.Story{
 		Title:   input.Title,
 		Author:  input.Author,
 		Content: input.Content,
+		Tags:    tags,
 		// ID, CreatedAt, UpdatedAt are automatically set by the repository
 	}
 
-	if err := s.repo.SaveStory(story); err != nil {
-		return nil, &util.InternalError{Message: "failed to save story", Err: err}
+	if input.AuthorID != "" {
+		if err := setStoryAuthor(ctx, users, story, input.AuthorID); err != nil {
+			return nil, err
+		}
 	}
 
 	return story, nil
 }
 
+// Returned inside the transaction of an atomic import to roll it back because of invalid records.
+var errImportRejected = errors.New("import rejected")
+
+// Handles the import of many stories at once. Each record is checked like in CreateStory.
+// In atomic mode, every story is created in one transaction, and none if any record is invalid.
+// In best effort mode, stories are created in batches of domain.StoryImportBatchSize, each in its own transaction,
+// and invalid records are skipped; a batch that fails to save reports all its records as invalid.
+func (s *StoryService) ImportStories(ctx context.Context, records []domain.StoryImportRecord, mode domain.StoryImportMode) (*domain.StoryImportReport, error) {
+	if len(records) > domain.MaxStoryImportRecords {
+		return nil, &util.ValidationError{Message: fmt.Sprintf("at most %d records can be imported at once", domain.MaxStoryImportRecords)}
+	}
+
+	report := &domain.StoryImportReport{Mode: mode, Results: make([]domain.StoryImportResult, len(records))}
+
+	batchSize := domain.StoryImportBatchSize
+	if mode == domain.StoryImportAtomic {
+		batchSize = max(len(records), 1)
+	}
+
+	for start := 0; start < len(records); start += batchSize {
+		end := min(start+batchSize, len(records))
+		results := report.Results[start:end]
+
+		err := s.importBatch(ctx, records[start:end], results, mode)
+
+		if err != nil && mode == domain.StoryImportAtomic {
+			return nil, err
+		}
+
+		if err != nil {
+			for i := range results {
+				if results[i].Status != domain.StoryImportInvalid {
+					results[i] = domain.StoryImportResult{Line: results[i].Line, Status: domain.StoryImportInvalid, Error: err.Error()}
+				}
+			}
+		}
+	}
+
+	for _, result := range report.Results {
+		switch result.Status {
+		case domain.StoryImportCreated:
+			report.Created++
+		case domain.StoryImportInvalid:
+			report.Invalid++
+		}
+	}
+
+	return report, nil
+}
+
+// Creates the valid stories of a batch of import records in one transaction, writing the outcome of each record to results.
+func (s *StoryService) importBatch(ctx context.Context, records []domain.StoryImportRecord, results []domain.StoryImportResult, mode domain.StoryImportMode) error {
+	var created []domain.Event
+
+	err := s.uow.Execute(ctx, func(ctx context.Context, repos ports.Repositories) error {
+		stories := make([]*domain.Story, len(records))
+		created = created[:0]
+		invalid := 0
+
+		// Records often share their authors, which are then read once per transaction
+		authors := &importAuthors{UserDrivenPort: repos.Users, found: make(map[string]*domain.User)}
+
+		// Results are filled from scratch, as the transaction may be retried
+		for i, record := range records {
+			results[i] = domain.StoryImportResult{Line: record.Line}
+
+			if record.Err != nil {
+				results[i].Status, results[i].Error = domain.StoryImportInvalid, record.Err.Error()
+				invalid++
+				continue
+			}
+
+			story, err := newStory(ctx, authors, &record.Input)
+
+			var validationErr *util.ValidationError
+			if errors.As(err, &validationErr) {
+				results[i].Status, results[i].Error = domain.StoryImportInvalid, validationErr.Message
+				invalid++
+				continue
+			}
+
+			if err != nil {
+				return err
+			}
+
+			stories[i] = story
+		}
+
+		if mode == domain.StoryImportAtomic && invalid > 0 {
+			for i, story := range stories {
+				if story != nil {
+					results[i].Status = domain.StoryImportSkipped
+				}
+			}
+
+			return errImportRejected
+		}
+
+		valid := make([]*domain.Story, 0, len(stories)-invalid)
+		for _, story := range stories {
+			if story != nil {
+				valid = append(valid, story)
+			}
+		}
+
+		if err := repos.Stories.SaveStories(ctx, valid); err != nil {
+			return &util.InternalError{Message: "failed to save the imported stories", Err: err}
+		}
+
+		audited := make([]*domain.AuditEvent, 0, len(valid))
+
+		for i, story := range stories {
+			if story == nil {
+				continue
+			}
+
+			event, err := domain.NewAuditEvent(ctx, domain.AuditCreate, domain.AuditStory, story.ID, nil, story)
+			if err != nil {
+				return &util.InternalError{Message: "failed to encode audit event", Err: err}
+			}
+
+			audited = append(audited, event)
+			results[i].Status, results[i].ID = domain.StoryImportCreated, story.ID
+			created = append(created, domain.StoryCreated{Story: *story})
+		}
+
+		if err := recordAuditEvents(ctx, repos.Audit, audited...); err != nil {
+			return err
+		}
+
+		return recordEvents(ctx, repos.Outbox, created...)
+	})
+
+	if err != nil && !errors.Is(err, errImportRejected) {
+		return serviceError(err, "failed to import stories")
+	}
+
+	return nil
+}
+
+// Reads the authors of the records of an import, remembering each user, or its absence, for the rest of the transaction.
+type importAuthors struct {
+	ports.UserDrivenPort
+	found map[string]*domain.User
+}
+
+// Finds a user by ID, reading each one at most once.
+func (a *importAuthors) FindUserByID(ctx context.Context, id string) (*domain.User, error) {
+	if user, ok := a.found[id]; ok {
+		return user, nil
+	}
+
+	user, err := a.UserDrivenPort.FindUserByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	a.found[id] = user
+	return user, nil
+}
+
+// Links the story to the user with the given ID and copies the user's name into the author field.
+// Returns a ValidationError if there is no such user.
+func setStoryAuthor(ctx context.Context, users ports.UserDrivenPort, story *domain.Story, authorID string) error {
+	user, err := users.FindUserByID(ctx, authorID)
+
+	if err != nil {
+		return &util.InternalError{Message: "failed to retrieve story author from repository", Err: err}
+	}
+
+	if user == nil {
+		return &util.ValidationError{Message: fmt.Sprintf("author_id: user with ID %s not found", authorID)}
+	}
+
+	story.AuthorID = &user.ID
+	story.Author = user.Name
+
+	return nil
+}
+
 // Handles the retrieval of a story by ID.
-func (s *StoryService) GetStoryByID(id string) (*domain.Story, error) {
-	story, err := s.repo.FindStoryByID(id)
+func (s *StoryService) GetStoryByID(ctx context.Context, id string) (*domain.Story, error) {
+	story, err := s.repo.FindStoryByID(ctx, id)
 
 	if err != nil {
 		// If the error is sql.ErrNoRows, it means the story was not found
@@ -61,9 +284,14 @@ func (s *StoryService) GetStoryByID(id string) (*domain.Story, error) {
 	return story, nil
 }
 
-// Handles the retrieval of all stories.
-func (s *StoryService) GetAllStories() ([]domain.Story, error) {
-	stories, err := s.repo.FindAllStories()
+// Handles the retrieval of a filtered, sorted page of stories.
+func (s *StoryService) GetAllStories(ctx context.Context, query domain.StoryQuery) (*domain.Page[domain.Story], error) {
+	query, err := normalizeStoryQuery(query)
+	if err != nil {
+		return nil, err
+	}
+
+	stories, err := s.repo.FindAllStories(ctx, query)
 
 	if err != nil {
 		return nil, &util.InternalError{Message: "failed to retrieve all stories", Err: err}
@@ -72,61 +300,543 @@ func (s *StoryService) GetAllStories() ([]domain.Story, error) {
 	return stories, nil
 }
 
+// Handles the retrieval of a filtered, sorted page of the stories written by a user.
+func (s *StoryService) GetStoriesByAuthor(ctx context.Context, userID string, query domain.StoryQuery) (*domain.Page[domain.Story], error) {
+	user, err := s.users.FindUserByID(ctx, userID)
+
+	if err != nil {
+		return nil, &util.InternalError{Message: "failed to retrieve user from repository", Err: err}
+	}
+
+	if user == nil {
+		return nil, &util.NotFoundError{Message: fmt.Sprintf("user with ID %s not found", userID)}
+	}
+
+	query.AuthorID = user.ID
+
+	return s.GetAllStories(ctx, query)
+}
+
+// Handles the export of every story of a listing, handing them to fn as they are read.
+// The pagination of the query is ignored.
+func (s *StoryService) ExportStories(ctx context.Context, query domain.StoryQuery, fn func(*domain.Story) error) error {
+	query, err := normalizeStoryQuery(query)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.StreamStories(ctx, query, fn); err != nil {
+		return &util.InternalError{Message: "failed to export stories", Err: err}
+	}
+
+	return nil
+}
+
+// Validates a story listing query and normalizes its tags.
+func normalizeStoryQuery(query domain.StoryQuery) (domain.StoryQuery, error) {
+	if err := query.Validate(); err != nil {
+		return query, &util.ValidationError{Message: err.Error()}
+	}
+
+	tags, err := domain.NormalizeTags(query.Tags, domain.MaxQueryTags)
+	if err != nil {
+		return query, &util.ValidationError{Message: "tag: " + err.Error()}
+	}
+	query.Tags = tags
+
+	return query, nil
+}
+
+// Handles the full-text search of stories.
+func (s *StoryService) SearchStories(ctx context.Context, query domain.StorySearchQuery) ([]domain.StorySearchResult, error) {
+	if strings.TrimSpace(query.Text) == "" {
+		return nil, &util.ValidationError{Message: "search text is required"}
+	}
+
+	if query.Limit <= 0 || query.Limit > domain.MaxPageLimit {
+		query.Limit = domain.DefaultPageLimit
+	}
+
+	if query.Offset < 0 {
+		query.Offset = 0
+	}
+
+	results, err := s.repo.SearchStories(ctx, query)
+
+	if err != nil {
+		return nil, &util.InternalError{Message: "failed to search stories", Err: err}
+	}
+
+	return results, nil
+}
+
+// Handles the retrieval of the tags used by stories, with the number of stories using each one.
+func (s *StoryService) GetTags(ctx context.Context) ([]domain.TagUsage, error) {
+	tags, err := s.repo.FindTags(ctx)
+
+	if err != nil {
+		return nil, &util.InternalError{Message: "failed to retrieve tags", Err: err}
+	}
+
+	return tags, nil
+}
+
 // Handles the update of a story.
-func (s *StoryService) UpdateStory(id string, input *domain.UpdateStoryInput) (*domain.Story, error) {
-	// First, retrieve the story from the repository.
-	story, err := s.repo.FindStoryByID(id)
+// The read and the write run in one transaction so concurrent updates cannot interleave,
+// and the write only applies to the version that was read.
+func (s *StoryService) UpdateStory(ctx context.Context, id string, input *domain.UpdateStoryInput, expectedVersion int64) (*domain.Story, error) {
+	var story *domain.Story
+	var tags []string
+
+	if input.Tags != nil {
+		normalized, err := domain.NormalizeTags(*input.Tags, domain.MaxStoryTags)
+		if err != nil {
+			return nil, &util.ValidationError{Message: "tags: " + err.Error()}
+		}
+		tags = normalized
+	}
+
+	err := s.uow.Execute(ctx, func(ctx context.Context, repos ports.Repositories) error {
+		// First, retrieve the story from the repository.
+		found, err := repos.Stories.FindStoryByID(ctx, id)
+
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return &util.NotFoundError{Message: fmt.Sprintf("story with ID %s not found for update", id)}
+			}
+
+			return &util.InternalError{Message: "failed to retrieve story for update from repository", Err: err}
+		}
+
+		if found == nil {
+			return &util.NotFoundError{Message: fmt.Sprintf("story with ID %s not found for update", id)}
+		}
+
+		if err := checkVersion("story", id, expectedVersion, found.Version); err != nil {
+			return err
+		}
+
+		// Keep the current version before applying the changes
+		previous := domain.NewStoryRevision(found)
+		before := *found
+
+		// Apply the changes to the story
+		if input.Title != nil {
+			found.Title = *input.Title
+		}
+
+		if input.AuthorID != nil {
+			if err := setStoryAuthor(ctx, repos.Users, found, *input.AuthorID); err != nil {
+				return err
+			}
+		} else if input.Author != nil {
+			// The name of a linked author follows the user
+			if found.AuthorID != nil && *input.Author != found.Author {
+				return &util.ValidationError{Message: "author: the story is linked to a user, set author_id instead"}
+			}
+
+			found.Author = *input.Author
+		}
+
+		if input.Content != nil {
+			found.Content = *input.Content
+		}
+
+		// Tags are not part of revisions
+		if tags != nil {
+			found.Tags = tags
+		}
+
+		// An update that changes nothing does not need a revision
+		if found.Title != previous.Title || found.Author != previous.Author || !sameAuthorID(found.AuthorID, previous.AuthorID) || found.Content != previous.Content {
+			if err := repos.StoryRevisions.SaveRevision(ctx, previous); err != nil {
+				return &util.InternalError{Message: "failed to save story revision", Err: err}
+			}
+		}
+
+		// The updated_at column is automatically updated by the repository
+		if err := repos.Stories.UpdateStory(ctx, found); err != nil {
+
+			if errors.Is(err, domain.ErrStoryNotFound) {
+				return &util.NotFoundError{Message: fmt.Sprintf("story with ID %s not found for update (or no changes)", id)}
+			}
+
+			if errors.Is(err, domain.ErrVersionConflict) {
+				return &util.PreconditionFailedError{Message: fmt.Sprintf("story with ID %s was changed concurrently", id)}
+			}
+
+			return &util.InternalError{Message: "failed to update story in repository", Err: err}
+		}
+
+		if err := recordAudit(ctx, repos.Audit, domain.AuditUpdate, domain.AuditStory, id, &before, found); err != nil {
+			return err
+		}
+
+		story = found
+		return recordEvents(ctx, repos.Outbox, domain.StoryUpdated{Story: *found, Changes: domain.StoryChanges(&before, found)})
+	})
 
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return nil, &util.NotFoundError{Message: fmt.Sprintf("story with ID %s not found for update", id)}
+		return nil, serviceError(err, "failed to update story")
+	}
+
+	return story, nil
+}
+
+// Handles the soft deletion of a story. The story can be restored until it is purged.
+// With an expected version, the story is only deleted if it is still at that version.
+func (s *StoryService) DeleteStory(ctx context.Context, id string, expectedVersion int64) error {
+	err := s.uow.Execute(ctx, func(ctx context.Context, repos ports.Repositories) error {
+		found, err := repos.Stories.FindStoryByID(ctx, id)
+
+		if err != nil {
+			return &util.InternalError{Message: "failed to retrieve story for deletion from repository", Err: err}
+		}
+
+		if found == nil {
+			return &util.NotFoundError{Message: fmt.Sprintf("story with ID %s not found for deletion", id)}
 		}
 
-		return nil, &util.InternalError{Message: "failed to retrieve story for update from repository", Err: err}
+		if err := checkVersion("story", id, expectedVersion, found.Version); err != nil {
+			return err
+		}
+
+		if err := repos.Stories.DeleteStory(ctx, id); err != nil {
+			if errors.Is(err, domain.ErrStoryNotFound) {
+				return &util.NotFoundError{Message: fmt.Sprintf("story with ID %s not found for deletion", id)}
+			}
+
+			return &util.InternalError{Message: "failed to delete story from repository", Err: err}
+		}
+
+		if err := recordAudit(ctx, repos.Audit, domain.AuditDelete, domain.AuditStory, id, found, nil); err != nil {
+			return err
+		}
+
+		return recordEvents(ctx, repos.Outbox, domain.StoryDeleted{StoryID: id})
+	})
+
+	if err != nil {
+		return serviceError(err, "failed to delete story")
 	}
 
-	if story == nil {
-		return nil, &util.NotFoundError{Message: fmt.Sprintf("story with ID %s not found for update", id)}
+	return nil
+}
+
+// Handles the restoration of a soft-deleted story.
+func (s *StoryService) RestoreStory(ctx context.Context, id string) (*domain.Story, error) {
+	var story *domain.Story
+
+	err := s.uow.Execute(ctx, func(ctx context.Context, repos ports.Repositories) error {
+		if err := repos.Stories.RestoreStory(ctx, id); err != nil {
+			if errors.Is(err, domain.ErrStoryNotFound) {
+				return &util.NotFoundError{Message: fmt.Sprintf("deleted story with ID %s not found", id)}
+			}
+
+			return &util.InternalError{Message: "failed to restore story in repository", Err: err}
+		}
+
+		found, err := repos.Stories.FindStoryByID(ctx, id)
+
+		if err != nil {
+			return &util.InternalError{Message: "failed to retrieve restored story from repository", Err: err}
+		}
+
+		// A story cannot come back while its author is deleted
+		var author *domain.User
+		if found.AuthorID != nil {
+			author, err = repos.Users.FindUserByID(ctx, *found.AuthorID)
+
+			if err != nil {
+				return &util.InternalError{Message: "failed to retrieve story author from repository", Err: err}
+			}
+
+			if author == nil {
+				return &util.ConflictError{Message: fmt.Sprintf("cannot restore story with ID %s: its author with ID %s is deleted", id, *found.AuthorID)}
+			}
+		}
+
+		if err := recordAudit(ctx, repos.Audit, domain.AuditRestore, domain.AuditStory, id, nil, found); err != nil {
+			return err
+		}
+
+		if err := recordEvents(ctx, repos.Outbox, domain.StoryRestored{Story: *found}); err != nil {
+			return err
+		}
+
+		story = found
+
+		// The author may have been renamed while the story was deleted
+		if author != nil {
+			return renameStoryAuthor(ctx, repos, found, author.Name)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, serviceError(err, "failed to restore story")
+	}
+
+	return story, nil
+}
+
+// Handles the permanent removal of the stories soft-deleted longer ago than the retention period.
+func (s *StoryService) PurgeDeletedStories(ctx context.Context, retention time.Duration) (int64, error) {
+	deletedBefore := time.Now().Add(-retention)
+	var purged int64
+
+	err := s.uow.Execute(ctx, func(ctx context.Context, repos ports.Repositories) error {
+		count, err := repos.Stories.PurgeDeletedStories(ctx, deletedBefore)
+
+		if err != nil {
+			return &util.InternalError{Message: "failed to purge deleted stories", Err: err}
+		}
+
+		if purged = count; purged == 0 {
+			return nil
+		}
+
+		event := domain.StoriesPurged{DeletedBefore: deletedBefore, Count: purged}
+		if err := recordAudit(ctx, repos.Audit, domain.AuditPurge, domain.AuditStory, "", event, nil); err != nil {
+			return err
+		}
+
+		return recordEvents(ctx, repos.Outbox, event)
+	})
+
+	if err != nil {
+		return 0, serviceError(err, "failed to purge deleted stories")
 	}
 
-	// Apply the changes to the story
-	if input.Title != nil {
-		story.Title = *input.Title
+	return purged, nil
+}
+
+// Handles the retrieval of a page of the previous versions of a story, newest first.
+func (s *StoryService) GetStoryRevisions(ctx context.Context, id string, page domain.PageRequest) (*domain.Page[domain.StoryRevision], error) {
+	if page.Cursor != nil {
+		if _, err := page.Cursor.RevisionValue(id); err != nil {
+			return nil, &util.ValidationError{Message: err.Error()}
+		}
 	}
 
-	if input.Author != nil {
-		story.Author = *input.Author
+	if _, err := s.GetStoryByID(ctx, id); err != nil {
+		return nil, err
 	}
 
-	if input.Content != nil {
-		story.Content = *input.Content
+	revisions, err := s.revisions.FindRevisions(ctx, id, page)
+
+	if err != nil {
+		return nil, &util.InternalError{Message: "failed to retrieve story revisions", Err: err}
 	}
 
-	// The updated_at column is automatically updated by the repository
-	if err := s.repo.UpdateStory(story); err != nil {
+	return revisions, nil
+}
+
+// Handles the retrieval of a previous version of a story.
+func (s *StoryService) GetStoryRevision(ctx context.Context, id string, revision int) (*domain.StoryRevision, error) {
+	if _, err := s.GetStoryByID(ctx, id); err != nil {
+		return nil, err
+	}
+
+	found, err := s.revisions.FindRevision(ctx, id, revision)
+
+	if err != nil {
+		return nil, &util.InternalError{Message: "failed to retrieve story revision", Err: err}
+	}
 
-		if errors.Is(err, errors.New("story not found or no changes made")) {
-			return nil, &util.NotFoundError{Message: fmt.Sprintf("story with ID %s not found for update (or no changes)", id)}
+	if found == nil {
+		return nil, &util.NotFoundError{Message: fmt.Sprintf("revision %d of story with ID %s not found", revision, id)}
+	}
+
+	return found, nil
+}
+
+// Handles the line-level comparison of two versions of a story.
+// The current story counts as the version after the latest revision; to = 0 also selects it.
+func (s *StoryService) DiffStoryRevisions(ctx context.Context, id string, from, to int) (*domain.StoryDiff, error) {
+	story, err := s.GetStoryByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	latest, err := s.revisions.LatestRevision(ctx, id)
+
+	if err != nil {
+		return nil, &util.InternalError{Message: "failed to retrieve latest story revision", Err: err}
+	}
+
+	// The current story as a revision, so both sides are compared the same way
+	current := domain.NewStoryRevision(story)
+	current.Revision = latest + 1
+	current.CreatedAt = story.UpdatedAt
+
+	if to == 0 {
+		to = current.Revision
+	}
+
+	version := func(revision int) (*domain.StoryRevision, error) {
+		if revision == current.Revision {
+			return current, nil
+		}
+
+		return s.GetStoryRevision(ctx, id, revision)
+	}
+
+	fromVersion, err := version(from)
+	if err != nil {
+		return nil, err
+	}
+
+	toVersion, err := version(to)
+	if err != nil {
+		return nil, err
+	}
+
+	diff, err := domain.NewStoryDiff(fromVersion, toVersion)
+	if errors.Is(err, domain.ErrDiffTooLarge) {
+		return nil, &util.ValidationError{Message: fmt.Sprintf("the versions differ in more than %d lines, which is too many to compare", domain.MaxDiffLines)}
+	}
+
+	if err != nil {
+		return nil, &util.InternalError{Message: "failed to compare story revisions", Err: err}
+	}
+
+	return diff, nil
+}
+
+// Handles the rollback of a story to a previous version.
+// The rollback is an update like any other: the version it replaces is kept as a new revision.
+func (s *StoryService) RestoreStoryRevision(ctx context.Context, id string, revision int) (*domain.Story, error) {
+	var story *domain.Story
+
+	err := s.uow.Execute(ctx, func(ctx context.Context, repos ports.Repositories) error {
+		found, err := repos.Stories.FindStoryByID(ctx, id)
+
+		if err != nil {
+			return &util.InternalError{Message: "failed to retrieve story for rollback from repository", Err: err}
+		}
+
+		if found == nil {
+			return &util.NotFoundError{Message: fmt.Sprintf("story with ID %s not found", id)}
+		}
+
+		target, err := repos.StoryRevisions.FindRevision(ctx, id, revision)
+
+		if err != nil {
+			return &util.InternalError{Message: "failed to retrieve story revision from repository", Err: err}
 		}
 
-		return nil, &util.InternalError{Message: "failed to update story in repository", Err: err}
+		if target == nil {
+			return &util.NotFoundError{Message: fmt.Sprintf("revision %d of story with ID %s not found", revision, id)}
+		}
+
+		if err := repos.StoryRevisions.SaveRevision(ctx, domain.NewStoryRevision(found)); err != nil {
+			return &util.InternalError{Message: "failed to save story revision", Err: err}
+		}
+
+		before := *found
+		found.Title = target.Title
+		found.Author = target.Author
+		found.AuthorID = target.AuthorID
+		found.Content = target.Content
+
+		// The author of the revision must still exist, and is shown under the current name
+		if target.AuthorID != nil {
+			if err := setStoryAuthor(ctx, repos.Users, found, *target.AuthorID); err != nil {
+				var validationErr *util.ValidationError
+				if errors.As(err, &validationErr) {
+					return &util.ConflictError{Message: fmt.Sprintf("cannot restore revision %d of story with ID %s: its author with ID %s is deleted", revision, id, *target.AuthorID)}
+				}
+
+				return err
+			}
+		}
+
+		if err := repos.Stories.UpdateStory(ctx, found); err != nil {
+			if errors.Is(err, domain.ErrStoryNotFound) {
+				return &util.NotFoundError{Message: fmt.Sprintf("story with ID %s not found", id)}
+			}
+
+			if errors.Is(err, domain.ErrVersionConflict) {
+				return &util.PreconditionFailedError{Message: fmt.Sprintf("story with ID %s was changed concurrently", id)}
+			}
+
+			return &util.InternalError{Message: "failed to update story in repository", Err: err}
+		}
+
+		if err := recordAudit(ctx, repos.Audit, domain.AuditUpdate, domain.AuditStory, id, &before, found); err != nil {
+			return err
+		}
+
+		story = found
+		return recordEvents(ctx, repos.Outbox, domain.StoryUpdated{Story: *found, Changes: domain.StoryChanges(&before, found)})
+	})
+
+	if err != nil {
+		return nil, serviceError(err, "failed to restore story revision")
 	}
 
 	return story, nil
 }
 
-// Handles the deletion of a story.
-func (s *StoryService) DeleteStory(id string) error {
-	err := s.repo.DeleteStory(id)
+// Shows the active stories of a user under their new name. Deleted stories are renamed when they are restored.
+func renameAuthor(ctx context.Context, repos ports.Repositories, user *domain.User) error {
+	var stories []*domain.Story
+
+	// Collected first, as the updates cannot run while the stories are read
+	err := repos.Stories.StreamStories(ctx, domain.StoryQuery{AuthorID: user.ID, Sort: domain.DefaultStorySort}, func(story *domain.Story) error {
+		stories = append(stories, story)
+		return nil
+	})
 
 	if err != nil {
-		// Assume that DeleteStory from the repository can return a specific error if not found
-		if errors.Is(err, errors.New("story not found for deletion")) {
-			return &util.NotFoundError{Message: fmt.Sprintf("story with ID %s not found for deletion", id)}
-		}
+		return &util.InternalError{Message: "failed to retrieve the stories of the user from repository", Err: err}
+	}
 
-		return &util.InternalError{Message: "failed to delete story from repository", Err: err}
+	for _, story := range stories {
+		if err := renameStoryAuthor(ctx, repos, story, user.Name); err != nil {
+			return err
+		}
 	}
 
 	return nil
 }
+
+// Shows a story under the given name of its author. Like any other update, the replaced version is kept as a revision,
+// and the update is recorded in the audit log and the outbox.
+func renameStoryAuthor(ctx context.Context, repos ports.Repositories, story *domain.Story, name string) error {
+	if story.Author == name {
+		return nil
+	}
+
+	if err := repos.StoryRevisions.SaveRevision(ctx, domain.NewStoryRevision(story)); err != nil {
+		return &util.InternalError{Message: "failed to save story revision", Err: err}
+	}
+
+	before := *story
+	story.Author = name
+
+	if err := repos.Stories.UpdateStory(ctx, story); err != nil {
+		if errors.Is(err, domain.ErrStoryNotFound) || errors.Is(err, domain.ErrVersionConflict) {
+			return &util.PreconditionFailedError{Message: fmt.Sprintf("story with ID %s was changed concurrently", story.ID)}
+		}
+
+		return &util.InternalError{Message: "failed to update the author name of story in repository", Err: err}
+	}
+
+	if err := recordAudit(ctx, repos.Audit, domain.AuditUpdate, domain.AuditStory, story.ID, &before, story); err != nil {
+		return err
+	}
+
+	return recordEvents(ctx, repos.Outbox, domain.StoryUpdated{Story: *story, Changes: domain.StoryChanges(&before, story)})
+}
+
+// Reports whether two optional author IDs are equal.
+func sameAuthorID(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return *a == *b
+}
@@ -5,6 +5,7 @@ import (
 	"Gin/internal/core/domain"
 	"Gin/internal/core/ports"
 	"Gin/pkg/util"
+	"context"
 	"database/sql"
 	"errors"
 	"fmt"
@@ -16,15 +17,16 @@ import (
 // UserService implements the UserDriverPort interface.
 type UserService struct {
 	userRepo ports.UserDrivenPort // Dependency on the Driven Port (Repository)
+	uow      ports.UnitOfWork     // Runs multi-step use cases in a transaction, and records their events in the outbox
 }
 
 // NewUserService creates a new instance of UserService.
-func NewUserService(userRepo ports.UserDrivenPort) *UserService {
-	return &UserService{userRepo: userRepo}
+func NewUserService(userRepo ports.UserDrivenPort, uow ports.UnitOfWork) *UserService {
+	return &UserService{userRepo: userRepo, uow: uow}
 }
 
 // CreateUser implements the use case for creating a new user.
-func (s *UserService) CreateUser(email, name string) (*domain.User, error) {
+func (s *UserService) CreateUser(ctx context.Context, email, name string) (*domain.User, error) {
 	user, err := domain.NewUser(email, name)
 	if err != nil {
 		if !errors.Is(err, sql.ErrNoRows) {
@@ -35,17 +37,29 @@ func (s *UserService) CreateUser(email, name string) (*domain.User, error) {
 	user.ID = uuid.New().String() // Generate a unique ID
 	// CreatedAt and UpdatedAt are set in domain.NewUser
 
-	// Save the user using the repository (driven port)
-	if err := s.userRepo.SaveUser(user); err != nil {
-		return nil, &util.InternalError{Message: "failed to save user", Err: err}
+	// Save the user using the repository (driven port), along with its event
+	err = s.uow.Execute(ctx, func(ctx context.Context, repos ports.Repositories) error {
+		if err := repos.Users.SaveUser(ctx, user); err != nil {
+			return &util.InternalError{Message: "failed to save user", Err: err}
+		}
+
+		if err := recordAudit(ctx, repos.Audit, domain.AuditCreate, domain.AuditUser, user.ID, nil, user); err != nil {
+			return err
+		}
+
+		return recordEvents(ctx, repos.Outbox, domain.UserCreated{User: *user})
+	})
+
+	if err != nil {
+		return nil, serviceError(err, "failed to save user")
 	}
 
 	return user, nil
 }
 
 // GetUserByID implements the use case for getting a user by ID.
-func (s *UserService) GetUserByID(id string) (*domain.User, error) {
-	user, err := s.userRepo.FindUserByID(id)
+func (s *UserService) GetUserByID(ctx context.Context, id string) (*domain.User, error) {
+	user, err := s.userRepo.FindUserByID(ctx, id)
 
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -62,9 +76,9 @@ func (s *UserService) GetUserByID(id string) (*domain.User, error) {
 	return user, nil
 }
 
-// GetAllUsers implements the use case for getting all users.
-func (s *UserService) GetAllUsers() ([]domain.User, error) {
-	users, err := s.userRepo.FindAllUsers()
+// GetAllUsers implements the use case for getting a page of users.
+func (s *UserService) GetAllUsers(ctx context.Context, query domain.UserQuery) (*domain.Page[domain.User], error) {
+	users, err := s.userRepo.FindAllUsers(ctx, query)
 
 	if err != nil {
 		return nil, &util.InternalError{Message: "failed to retrieve all users", Err: err}
@@ -73,49 +87,206 @@ func (s *UserService) GetAllUsers() ([]domain.User, error) {
 	return users, nil
 }
 
+// ExportUsers implements the use case for exporting every user, handing them to fn as they are read.
+func (s *UserService) ExportUsers(ctx context.Context, query domain.UserQuery, fn func(*domain.User) error) error {
+	if err := s.userRepo.StreamUsers(ctx, query, fn); err != nil {
+		return &util.InternalError{Message: "failed to export users", Err: err}
+	}
+
+	return nil
+}
+
 // UpdateUser implements the use case for updating an existing user.
-func (s *UserService) UpdateUser(id, email, name string) (*domain.User, error) {
-	user, err := s.userRepo.FindUserByID(id)
+// The read and the write run in one transaction so concurrent updates cannot interleave,
+// and the write only applies to the version that was read. A new name is also an update of each active story of the user.
+func (s *UserService) UpdateUser(ctx context.Context, id, email, name string, expectedVersion int64) (*domain.User, error) {
+	var user *domain.User
 
-	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return nil, &util.NotFoundError{Message: fmt.Sprintf("user with ID %s not found for update", id)}
+	err := s.uow.Execute(ctx, func(ctx context.Context, repos ports.Repositories) error {
+		found, err := repos.Users.FindUserByID(ctx, id)
+
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return &util.NotFoundError{Message: fmt.Sprintf("user with ID %s not found for update", id)}
+			}
+
+			return &util.InternalError{Message: "failed to retrieve user for update from repository", Err: err}
 		}
 
-		return nil, &util.InternalError{Message: "failed to retrieve user for update from repository", Err: err}
-	}
+		if found == nil {
+			return &util.NotFoundError{Message: fmt.Sprintf("user with ID %s not found for update", id)}
+		}
 
-	if user == nil {
-		return nil, &util.NotFoundError{Message: fmt.Sprintf("user with ID %s not found for update", id)}
-	}
+		if err := checkVersion("user", id, expectedVersion, found.Version); err != nil {
+			return err
+		}
 
-	// Update fields if provided
-	if email != "" {
-		user.Email = email
-	}
+		before := *found
 
-	if name != "" {
-		user.Name = name
-	}
+		// Update fields if provided
+		if email != "" {
+			found.Email = email
+		}
+
+		if name != "" {
+			found.Name = name
+		}
+
+		found.UpdatedAt = time.Now() // Update timestamp
+
+		if err := repos.Users.UpdateUser(ctx, found); err != nil {
+			if errors.Is(err, domain.ErrUserNotFound) {
+				return &util.NotFoundError{Message: fmt.Sprintf("user with ID %s not found for update", id)}
+			}
+
+			if errors.Is(err, domain.ErrVersionConflict) {
+				return &util.PreconditionFailedError{Message: fmt.Sprintf("user with ID %s was changed concurrently", id)}
+			}
 
-	user.UpdatedAt = time.Now() // Update timestamp
+			return &util.InternalError{Message: "failed to update user in repository", Err: err}
+		}
+
+		if err := recordAudit(ctx, repos.Audit, domain.AuditUpdate, domain.AuditUser, id, &before, found); err != nil {
+			return err
+		}
+
+		if err := recordEvents(ctx, repos.Outbox, domain.UserUpdated{User: *found, Changes: domain.UserChanges(&before, found)}); err != nil {
+			return err
+		}
 
-	if err := s.userRepo.UpdateUser(user); err != nil {
-		return nil, &util.InternalError{Message: "failed to update user in repository", Err: err}
+		user = found
+
+		// Stories show the name of their author
+		if found.Name != before.Name {
+			return renameAuthor(ctx, repos, found)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, serviceError(err, "failed to update user")
 	}
 
 	return user, nil
 }
 
-// DeleteUser implements the use case for deleting a user.
-func (s *UserService) DeleteUser(id string) error {
-	if err := s.userRepo.DeleteUser(id); err != nil {
-		if errors.Is(err, errors.New("user not found for deletion")) { // Este error debe provenir del repo
+// DeleteUser implements the use case for soft-deleting a user. The user can be restored until it is purged.
+// Users who still author stories cannot be deleted: their stories must be deleted or reassigned first.
+// With an expected version, the user is only deleted if it is still at that version.
+func (s *UserService) DeleteUser(ctx context.Context, id string, expectedVersion int64) error {
+	err := s.uow.Execute(ctx, func(ctx context.Context, repos ports.Repositories) error {
+		found, err := repos.Users.FindUserByID(ctx, id)
+
+		if err != nil {
+			return &util.InternalError{Message: "failed to retrieve user for deletion from repository", Err: err}
+		}
+
+		if found == nil {
 			return &util.NotFoundError{Message: fmt.Sprintf("user with ID %s not found for deletion", id)}
 		}
 
-		return &util.InternalError{Message: "failed to delete user from repository", Err: err}
+		if err := checkVersion("user", id, expectedVersion, found.Version); err != nil {
+			return err
+		}
+
+		stories, err := repos.Stories.FindAllStories(ctx, domain.StoryQuery{AuthorID: id, Sort: domain.DefaultStorySort, Page: domain.PageRequest{Limit: 1}})
+
+		if err != nil {
+			return &util.InternalError{Message: "failed to retrieve the stories of the user from repository", Err: err}
+		}
+
+		if len(stories.Items) > 0 {
+			return &util.ConflictError{Message: fmt.Sprintf("user with ID %s still authors stories", id)}
+		}
+
+		if err := repos.Users.DeleteUser(ctx, id); err != nil {
+			if errors.Is(err, domain.ErrUserNotFound) {
+				return &util.NotFoundError{Message: fmt.Sprintf("user with ID %s not found for deletion", id)}
+			}
+
+			return &util.InternalError{Message: "failed to delete user from repository", Err: err}
+		}
+
+		if err := recordAudit(ctx, repos.Audit, domain.AuditDelete, domain.AuditUser, id, found, nil); err != nil {
+			return err
+		}
+
+		return recordEvents(ctx, repos.Outbox, domain.UserDeleted{UserID: id})
+	})
+
+	if err != nil {
+		return serviceError(err, "failed to delete user")
 	}
 
 	return nil
 }
+
+// RestoreUser implements the use case for restoring a soft-deleted user.
+func (s *UserService) RestoreUser(ctx context.Context, id string) (*domain.User, error) {
+	var user *domain.User
+
+	err := s.uow.Execute(ctx, func(ctx context.Context, repos ports.Repositories) error {
+		if err := repos.Users.RestoreUser(ctx, id); err != nil {
+			if errors.Is(err, domain.ErrUserNotFound) {
+				return &util.NotFoundError{Message: fmt.Sprintf("deleted user with ID %s not found", id)}
+			}
+
+			if errors.Is(err, domain.ErrEmailInUse) {
+				return &util.ConflictError{Message: fmt.Sprintf("cannot restore user with ID %s: email is used by another user", id)}
+			}
+
+			return &util.InternalError{Message: "failed to restore user in repository", Err: err}
+		}
+
+		found, err := repos.Users.FindUserByID(ctx, id)
+
+		if err != nil {
+			return &util.InternalError{Message: "failed to retrieve restored user from repository", Err: err}
+		}
+
+		if err := recordAudit(ctx, repos.Audit, domain.AuditRestore, domain.AuditUser, id, nil, found); err != nil {
+			return err
+		}
+
+		user = found
+		return recordEvents(ctx, repos.Outbox, domain.UserRestored{User: *found})
+	})
+
+	if err != nil {
+		return nil, serviceError(err, "failed to restore user")
+	}
+
+	return user, nil
+}
+
+// PurgeDeletedUsers implements the use case for permanently removing the users soft-deleted longer ago than the retention period.
+func (s *UserService) PurgeDeletedUsers(ctx context.Context, retention time.Duration) (int64, error) {
+	deletedBefore := time.Now().Add(-retention)
+	var purged int64
+
+	err := s.uow.Execute(ctx, func(ctx context.Context, repos ports.Repositories) error {
+		count, err := repos.Users.PurgeDeletedUsers(ctx, deletedBefore)
+
+		if err != nil {
+			return &util.InternalError{Message: "failed to purge deleted users", Err: err}
+		}
+
+		if purged = count; purged == 0 {
+			return nil
+		}
+
+		event := domain.UsersPurged{DeletedBefore: deletedBefore, Count: purged}
+		if err := recordAudit(ctx, repos.Audit, domain.AuditPurge, domain.AuditUser, "", event, nil); err != nil {
+			return err
+		}
+
+		return recordEvents(ctx, repos.Outbox, event)
+	})
+
+	if err != nil {
+		return 0, serviceError(err, "failed to purge deleted users")
+	}
+
+	return purged, nil
+}
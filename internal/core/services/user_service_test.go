@@ -0,0 +1,104 @@
+package services_test
+
+import (
+	"Gin/internal/adapters/db/memory"
+	"Gin/internal/core/domain"
+	"Gin/internal/core/services"
+	"context"
+	"slices"
+	"testing"
+)
+
+func TestUpdateUserRenamesTheirStoriesAsStoryUpdates(t *testing.T) {
+	ctx := context.Background()
+
+	stories, revisions, users := memory.NewStoryRepository(), memory.NewStoryRevisionRepository(), memory.NewUserRepository()
+	outbox, audit := memory.NewOutboxRepository(), memory.NewAuditRepository()
+	uow := memory.NewUnitOfWork(stories, revisions, users, memory.NewCommentRepository(), outbox, audit)
+
+	userService := services.NewUserService(users, uow)
+	storyService := services.NewStoryService(stories, revisions, users, uow)
+
+	user, err := userService.CreateUser(ctx, "jane@example.com", "Jane")
+	if err != nil {
+		t.Fatalf("CreateUser failed: %v", err)
+	}
+
+	active, err := storyService.CreateStory(ctx, &domain.NewStoryInput{Title: "Active", AuthorID: user.ID, Content: "Some content"})
+	if err != nil {
+		t.Fatalf("CreateStory failed: %v", err)
+	}
+	deleted, err := storyService.CreateStory(ctx, &domain.NewStoryInput{Title: "Deleted", AuthorID: user.ID, Content: "Some content"})
+	if err != nil {
+		t.Fatalf("CreateStory failed: %v", err)
+	}
+	if err := storyService.DeleteStory(ctx, deleted.ID, 0); err != nil {
+		t.Fatalf("DeleteStory failed: %v", err)
+	}
+
+	if _, err := userService.UpdateUser(ctx, user.ID, "", "Janet", 0); err != nil {
+		t.Fatalf("UpdateUser failed: %v", err)
+	}
+
+	renamed, err := storyService.GetStoryByID(ctx, active.ID)
+	if err != nil {
+		t.Fatalf("GetStoryByID failed: %v", err)
+	}
+	if renamed.Author != "Janet" || renamed.Version != active.Version+1 {
+		t.Errorf("story = %+v, want renamed in version %d", renamed, active.Version+1)
+	}
+
+	// The name it had before is kept as a revision
+	history, err := storyService.GetStoryRevisions(ctx, active.ID, domain.PageRequest{})
+	if err != nil {
+		t.Fatalf("GetStoryRevisions failed: %v", err)
+	}
+	if len(history.Items) != 1 || history.Items[0].Author != "Jane" {
+		t.Errorf("revisions = %+v, want the version by Jane", history.Items)
+	}
+
+	if got := storyUpdates(t, outbox, active.ID); got != 1 {
+		t.Errorf("got %d story.updated events, want 1", got)
+	}
+
+	events, err := audit.FindAuditEvents(ctx, domain.AuditQuery{EntityType: domain.AuditStory, EntityID: active.ID})
+	if err != nil {
+		t.Fatalf("FindAuditEvents failed: %v", err)
+	}
+	if len(events.Items) != 2 || events.Items[0].Action != domain.AuditUpdate {
+		t.Errorf("audit events = %+v, want the creation and the rename", events.Items)
+	}
+
+	// A deleted story takes the name when it is restored
+	restored, err := storyService.RestoreStory(ctx, deleted.ID)
+	if err != nil {
+		t.Fatalf("RestoreStory failed: %v", err)
+	}
+	if restored.Author != "Janet" {
+		t.Errorf("restored author = %q, want Janet", restored.Author)
+	}
+	if got := storyUpdates(t, outbox, deleted.ID); got != 1 {
+		t.Errorf("got %d story.updated events for the restored story, want 1", got)
+	}
+}
+
+// Returns the number of story.updated events of a story in the outbox.
+func storyUpdates(t *testing.T, outbox *memory.OutboxRepository, storyID string) int {
+	t.Helper()
+
+	page, err := outbox.FindOutboxEntries(context.Background(), domain.OutboxQuery{Status: domain.OutboxPending, Page: domain.PageRequest{Limit: domain.MaxPageLimit}})
+	if err != nil {
+		t.Fatalf("FindOutboxEntries failed: %v", err)
+	}
+
+	updates := slices.DeleteFunc(page.Items, func(entry domain.OutboxEntry) bool {
+		if entry.EventType != domain.EventStoryUpdated {
+			return true
+		}
+
+		event, err := entry.Event()
+		return err != nil || event.(domain.StoryUpdated).Story.ID != storyID
+	})
+
+	return len(updates)
+}
@@ -0,0 +1,256 @@
+package services
+
+import (
+	"Gin/internal/core/domain"
+	"Gin/internal/core/ports"
+	"Gin/pkg/util"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"time"
+)
+
+// Time left for the repository calls of a batch on top of its requests, in the lease of the batch.
+const webhookLeaseMargin = time.Minute
+
+// Implements the ports.WebhookDrivingPort interface for WebhookService.
+// It is also an event sink of the outbox: each event becomes a delivery to every webhook subscribed to it.
+type WebhookService struct {
+	repo        ports.WebhookDrivenPort
+	sender      ports.WebhookSenderPort
+	batchSize   int
+	maxAttempts int
+	lease       time.Duration // How long a claimed batch is hidden from the other dispatchers while it is being sent
+}
+
+// Creates a new instance of WebhookService sending batches of batchSize deliveries,
+// each delivery being attempted at most maxAttempts times by a sender giving up on a request after timeout.
+// The deliveries are sent one after the other, so a batch is leased for batchSize requests timing out, plus a margin:
+// a dispatcher that stopped mid-batch has its deliveries picked up again once it expires.
+func NewWebhookService(repo ports.WebhookDrivenPort, sender ports.WebhookSenderPort, batchSize, maxAttempts int, timeout time.Duration) *WebhookService {
+	lease := time.Duration(batchSize)*timeout + webhookLeaseMargin
+	return &WebhookService{repo: repo, sender: sender, batchSize: batchSize, maxAttempts: maxAttempts, lease: lease}
+}
+
+// Handles the registration of a webhook. A secret is generated unless one is given.
+func (s *WebhookService) CreateWebhook(ctx context.Context, input *domain.NewWebhookInput) (*domain.CreatedWebhook, error) {
+	target, err := url.Parse(input.URL)
+	if err != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Host == "" {
+		return nil, &util.ValidationError{Message: "url: must be an absolute http or https URL"}
+	}
+
+	events := make([]domain.EventType, 0, len(input.Events))
+	for _, name := range input.Events {
+		if !domain.IsEventType(name) {
+			return nil, &util.ValidationError{Message: fmt.Sprintf("events: unknown event type %q", name)}
+		}
+		events = append(events, domain.EventType(name))
+	}
+	slices.Sort(events)
+
+	secret := input.Secret
+	if secret == "" {
+		raw := make([]byte, 32)
+		rand.Read(raw)
+		secret = hex.EncodeToString(raw)
+	}
+
+	webhook := &domain.Webhook{
+		URL:    target.String(),
+		Events: slices.Compact(events),
+		Secret: secret,
+		// ID and CreatedAt are set by the repository
+	}
+
+	if err := s.repo.SaveWebhook(ctx, webhook); err != nil {
+		return nil, &util.InternalError{Message: "failed to save webhook", Err: err}
+	}
+
+	return &domain.CreatedWebhook{Webhook: *webhook, Secret: webhook.Secret}, nil
+}
+
+// Handles the retrieval of every webhook.
+func (s *WebhookService) GetWebhooks(ctx context.Context) ([]domain.Webhook, error) {
+	webhooks, err := s.repo.FindWebhooks(ctx)
+
+	if err != nil {
+		return nil, &util.InternalError{Message: "failed to retrieve webhooks", Err: err}
+	}
+
+	return webhooks, nil
+}
+
+// Handles the retrieval of a webhook.
+func (s *WebhookService) GetWebhook(ctx context.Context, id string) (*domain.Webhook, error) {
+	webhook, err := s.repo.FindWebhookByID(ctx, id)
+
+	if err != nil {
+		return nil, &util.InternalError{Message: "failed to retrieve webhook from repository", Err: err}
+	}
+
+	if webhook == nil {
+		return nil, &util.NotFoundError{Message: fmt.Sprintf("webhook with ID %s not found", id)}
+	}
+
+	return webhook, nil
+}
+
+// Handles the removal of a webhook, along with its deliveries.
+func (s *WebhookService) DeleteWebhook(ctx context.Context, id string) error {
+	if err := s.repo.DeleteWebhook(ctx, id); err != nil {
+		if errors.Is(err, domain.ErrWebhookNotFound) {
+			return &util.NotFoundError{Message: fmt.Sprintf("webhook with ID %s not found for deletion", id)}
+		}
+
+		return &util.InternalError{Message: "failed to delete webhook from repository", Err: err}
+	}
+
+	return nil
+}
+
+// Handles the retrieval of a page of the deliveries of a webhook, newest first.
+func (s *WebhookService) GetWebhookDeliveries(ctx context.Context, webhookID string, page domain.PageRequest) (*domain.Page[domain.WebhookDelivery], error) {
+	if page.Cursor != nil {
+		if _, err := page.Cursor.TimeValue(); err != nil {
+			return nil, &util.ValidationError{Message: err.Error()}
+		}
+	}
+
+	if _, err := s.GetWebhook(ctx, webhookID); err != nil {
+		return nil, err
+	}
+
+	deliveries, err := s.repo.FindWebhookDeliveries(ctx, webhookID, page)
+
+	if err != nil {
+		return nil, &util.InternalError{Message: "failed to retrieve webhook deliveries", Err: err}
+	}
+
+	return deliveries, nil
+}
+
+// Handles a request to send a delivery again, whatever its outcome: a new delivery of the same payload is queued.
+func (s *WebhookService) RedeliverWebhookDelivery(ctx context.Context, webhookID, deliveryID string) (*domain.WebhookDelivery, error) {
+	delivery, err := s.repo.FindWebhookDelivery(ctx, webhookID, deliveryID)
+
+	if err != nil {
+		return nil, &util.InternalError{Message: "failed to retrieve webhook delivery from repository", Err: err}
+	}
+
+	if delivery == nil {
+		return nil, &util.NotFoundError{Message: fmt.Sprintf("delivery with ID %s of webhook with ID %s not found", deliveryID, webhookID)}
+	}
+
+	redelivery := delivery.Redelivery()
+
+	if err := s.repo.SaveWebhookDeliveries(ctx, []*domain.WebhookDelivery{redelivery}); err != nil {
+		return nil, &util.InternalError{Message: "failed to save webhook delivery", Err: err}
+	}
+
+	return redelivery, nil
+}
+
+// Implements the ports.EventSink interface: queues a delivery of the event to every webhook subscribed to it.
+// Deliveries are keyed on the outbox entry and the webhook, and the repository skips the keys it already holds,
+// so an entry handed over again after a failure of another sink does not queue a second delivery.
+func (s *WebhookService) Deliver(ctx context.Context, event domain.Event) error {
+	entryID, ok := domain.OutboxEntryFrom(ctx)
+	if !ok {
+		return errors.New("webhook deliveries are only queued by the outbox relay")
+	}
+
+	webhooks, err := s.repo.FindWebhooks(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve webhooks: %w", err)
+	}
+
+	now := time.Now()
+	var deliveries []*domain.WebhookDelivery
+
+	for i := range webhooks {
+		if !webhooks[i].Matches(event.EventType()) {
+			continue
+		}
+
+		delivery, err := domain.NewWebhookDelivery(&webhooks[i], entryID, event, now)
+		if err != nil {
+			return err
+		}
+		deliveries = append(deliveries, delivery)
+	}
+
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	if err := s.repo.SaveWebhookDeliveries(ctx, deliveries); err != nil {
+		return fmt.Errorf("failed to save webhook deliveries: %w", err)
+	}
+
+	return nil
+}
+
+// Handles the sending of the next batch of due webhook deliveries, of every tenant, each one in the tenant of its webhook.
+// Each delivery records the status code and duration of the attempt, and is marked delivered on a 2xx response
+// or scheduled for a retry with backoff otherwise.
+func (s *WebhookService) DispatchWebhooks(ctx context.Context) (*domain.DeliveryRun, error) {
+	deliveries, err := s.repo.ClaimWebhookDeliveries(ctx, time.Now(), s.lease, s.batchSize)
+
+	if err != nil {
+		return nil, &util.InternalError{Message: "failed to claim webhook deliveries", Err: err}
+	}
+
+	run := &domain.DeliveryRun{}
+	webhooks := make(map[string]*domain.Webhook)
+
+	for _, delivery := range deliveries {
+		ctx := domain.WithTenant(ctx, delivery.TenantID)
+
+		webhook, ok := webhooks[delivery.WebhookID]
+		if !ok {
+			if webhook, err = s.repo.FindWebhookByID(ctx, delivery.WebhookID); err != nil {
+				return run, &util.InternalError{Message: "failed to retrieve webhook from repository", Err: err}
+			}
+			webhooks[delivery.WebhookID] = webhook
+		}
+
+		// Deleted since the delivery was claimed, along with the delivery
+		if webhook == nil {
+			continue
+		}
+
+		response, err := s.sender.Send(ctx, webhook, delivery)
+		delivery.RecordAttempt(response, err, time.Now(), s.maxAttempts)
+
+		switch delivery.Status() {
+		case domain.OutboxDelivered:
+			run.Delivered++
+		case domain.OutboxFailed:
+			run.Failed++
+		default:
+			run.Retried++
+		}
+
+		// Unsaved outcomes are retried once the lease expires; deleted deliveries went with their webhook
+		if err := s.repo.UpdateWebhookDelivery(ctx, delivery); err != nil && !errors.Is(err, domain.ErrDeliveryNotFound) {
+			return run, &util.InternalError{Message: fmt.Sprintf("failed to save the outcome of webhook delivery %s", delivery.ID), Err: err}
+		}
+	}
+
+	return run, nil
+}
+
+// Handles the removal of the finished webhook deliveries created longer ago than the retention period.
+func (s *WebhookService) PurgeWebhookDeliveries(ctx context.Context, retention time.Duration) (int64, error) {
+	purged, err := s.repo.PurgeWebhookDeliveries(ctx, time.Now().Add(-retention))
+
+	if err != nil {
+		return 0, &util.InternalError{Message: "failed to purge webhook deliveries", Err: err}
+	}
+
+	return purged, nil
+}
@@ -0,0 +1,290 @@
+package services_test
+
+import (
+	"Gin/internal/adapters/db/memory"
+	"Gin/internal/adapters/webhook"
+	"Gin/internal/core/domain"
+	"Gin/internal/core/services"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+const testWebhookSecret = "0123456789abcdef"
+
+// Records the requests of the deliveries and answers them with the status codes given, then 204.
+type receiver struct {
+	*httptest.Server
+	mu       sync.Mutex
+	requests []receivedRequest
+	statuses []int
+}
+
+type receivedRequest struct {
+	header http.Header
+	body   []byte
+}
+
+func newReceiver(t *testing.T, statuses ...int) *receiver {
+	r := &receiver{statuses: statuses}
+	r.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, _ := io.ReadAll(req.Body)
+
+		r.mu.Lock()
+		r.requests = append(r.requests, receivedRequest{header: req.Header.Clone(), body: body})
+		status := http.StatusNoContent
+		if len(r.statuses) > 0 {
+			status, r.statuses = r.statuses[0], r.statuses[1:]
+		}
+		r.mu.Unlock()
+
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(r.Close)
+
+	return r
+}
+
+func (r *receiver) received() []receivedRequest {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]receivedRequest(nil), r.requests...)
+}
+
+// Returns a webhook service over a memory repository, with a webhook subscribed to every event and sent to target.
+func newWebhookService(t *testing.T, target string, maxAttempts int) (*services.WebhookService, *domain.CreatedWebhook) {
+	t.Helper()
+
+	service := services.NewWebhookService(memory.NewWebhookRepository(), webhook.NewHTTPSender(time.Second), 10, maxAttempts, time.Second)
+
+	created, err := service.CreateWebhook(context.Background(), &domain.NewWebhookInput{URL: target, Secret: testWebhookSecret})
+	if err != nil {
+		t.Fatalf("CreateWebhook failed: %v", err)
+	}
+
+	return service, created
+}
+
+// Queues the deliveries of an event as the outbox relay does for the entry with the given ID.
+func deliver(t *testing.T, service *services.WebhookService, entryID int64, event domain.Event) {
+	t.Helper()
+
+	if err := service.Deliver(domain.WithOutboxEntry(context.Background(), entryID), event); err != nil {
+		t.Fatalf("Deliver failed: %v", err)
+	}
+}
+
+// Returns the deliveries of a webhook, newest first.
+func deliveries(t *testing.T, service *services.WebhookService, webhookID string) []domain.WebhookDelivery {
+	t.Helper()
+
+	page, err := service.GetWebhookDeliveries(context.Background(), webhookID, domain.PageRequest{Limit: 100})
+	if err != nil {
+		t.Fatalf("GetWebhookDeliveries failed: %v", err)
+	}
+
+	return page.Items
+}
+
+func dispatch(t *testing.T, service *services.WebhookService) *domain.DeliveryRun {
+	t.Helper()
+
+	run, err := service.DispatchWebhooks(context.Background())
+	if err != nil {
+		t.Fatalf("DispatchWebhooks failed: %v", err)
+	}
+
+	return run
+}
+
+func TestDispatchWebhooksSendsSignedDeliveries(t *testing.T) {
+	target := newReceiver(t)
+	service, created := newWebhookService(t, target.URL, 3)
+
+	deliver(t, service, 1, domain.StoryDeleted{StoryID: "story"})
+
+	if run := dispatch(t, service); run.Delivered != 1 || run.Retried != 0 || run.Failed != 0 {
+		t.Fatalf("run = %+v, want 1 delivered", run)
+	}
+
+	requests := target.received()
+	if len(requests) != 1 {
+		t.Fatalf("got %d requests, want 1", len(requests))
+	}
+	request := requests[0]
+
+	timestamp := request.header.Get(webhook.TimestampHeader)
+	if got, want := request.header.Get(webhook.SignatureHeader), webhook.Sign(testWebhookSecret, timestamp, request.body); got != want {
+		t.Errorf("%s = %q, want %q", webhook.SignatureHeader, got, want)
+	}
+
+	var payload struct {
+		Event domain.EventType    `json:"event"`
+		Data  domain.StoryDeleted `json:"data"`
+	}
+	if err := json.Unmarshal(request.body, &payload); err != nil || payload.Event != domain.EventStoryDeleted || payload.Data.StoryID != "story" {
+		t.Errorf("body = %s, want the story.deleted event of story", request.body)
+	}
+
+	delivered := deliveries(t, service, created.ID)
+	if len(delivered) != 1 || delivered[0].DeliveredAt == nil || delivered[0].Attempts != 1 {
+		t.Fatalf("deliveries = %+v, want one delivered on its first attempt", delivered)
+	}
+	if got := request.header.Get(webhook.DeliveryHeader); got != delivered[0].ID {
+		t.Errorf("%s = %q, want %q", webhook.DeliveryHeader, got, delivered[0].ID)
+	}
+}
+
+func TestDeliverQueuesOneDeliveryPerOutboxEntry(t *testing.T) {
+	target := newReceiver(t)
+	service, created := newWebhookService(t, target.URL, 3)
+
+	// The relay hands an entry over again when another sink failed on it
+	deliver(t, service, 1, domain.StoryDeleted{StoryID: "story"})
+	deliver(t, service, 1, domain.StoryDeleted{StoryID: "story"})
+	deliver(t, service, 2, domain.StoryDeleted{StoryID: "other"})
+
+	if got := len(deliveries(t, service, created.ID)); got != 2 {
+		t.Errorf("got %d deliveries, want 2", got)
+	}
+}
+
+func TestDeliverRequiresAnOutboxEntry(t *testing.T) {
+	service, _ := newWebhookService(t, "http://example.com", 3)
+
+	if err := service.Deliver(context.Background(), domain.StoryDeleted{StoryID: "story"}); err == nil {
+		t.Error("Deliver succeeded without an outbox entry")
+	}
+}
+
+func TestDispatchWebhooksRetriesWithBackoff(t *testing.T) {
+	target := newReceiver(t, http.StatusServiceUnavailable)
+	service, created := newWebhookService(t, target.URL, 3)
+
+	deliver(t, service, 1, domain.StoryDeleted{StoryID: "story"})
+
+	before := time.Now()
+	if run := dispatch(t, service); run.Retried != 1 || run.Delivered != 0 || run.Failed != 0 {
+		t.Fatalf("run = %+v, want 1 retried", run)
+	}
+
+	retried := deliveries(t, service, created.ID)[0]
+	if retried.Attempts != 1 || retried.DeliveredAt != nil || retried.FailedAt != nil {
+		t.Errorf("delivery = %+v, want pending after one attempt", retried)
+	}
+	if retried.StatusCode == nil || *retried.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("status code = %v, want %d", retried.StatusCode, http.StatusServiceUnavailable)
+	}
+	if retried.LastError == nil {
+		t.Error("last error is not set")
+	}
+	if earliest := before.Add(domain.RetryDelay(1)); retried.NextAttemptAt.Before(earliest) {
+		t.Errorf("next attempt at %v, want not before %v", retried.NextAttemptAt, earliest)
+	}
+
+	// Not due again before its backoff
+	if run := dispatch(t, service); *run != (domain.DeliveryRun{}) {
+		t.Errorf("run = %+v, want nothing sent before the backoff", run)
+	}
+	if got := len(target.received()); got != 1 {
+		t.Errorf("got %d requests, want 1", got)
+	}
+}
+
+func TestDispatchWebhooksFailsAfterMaxAttempts(t *testing.T) {
+	target := newReceiver(t, http.StatusInternalServerError)
+	service, created := newWebhookService(t, target.URL, 1)
+
+	deliver(t, service, 1, domain.StoryDeleted{StoryID: "story"})
+
+	if run := dispatch(t, service); run.Failed != 1 || run.Delivered != 0 || run.Retried != 0 {
+		t.Fatalf("run = %+v, want 1 failed", run)
+	}
+
+	failed := deliveries(t, service, created.ID)[0]
+	if failed.FailedAt == nil || failed.DeliveredAt != nil || failed.Attempts != 1 {
+		t.Errorf("delivery = %+v, want failed after one attempt", failed)
+	}
+	if failed.Status() != domain.OutboxFailed {
+		t.Errorf("status = %s, want %s", failed.Status(), domain.OutboxFailed)
+	}
+}
+
+func TestRedeliverWebhookDeliverySendsThePayloadAgain(t *testing.T) {
+	target := newReceiver(t, http.StatusInternalServerError)
+	service, created := newWebhookService(t, target.URL, 1)
+
+	deliver(t, service, 1, domain.StoryDeleted{StoryID: "story"})
+	dispatch(t, service)
+	failed := deliveries(t, service, created.ID)[0]
+
+	redelivery, err := service.RedeliverWebhookDelivery(context.Background(), created.ID, failed.ID)
+	if err != nil {
+		t.Fatalf("RedeliverWebhookDelivery failed: %v", err)
+	}
+	if redelivery.ID == failed.ID || redelivery.RedeliveryOf == nil || *redelivery.RedeliveryOf != failed.ID {
+		t.Errorf("redelivery = %+v, want a new delivery of %s", redelivery, failed.ID)
+	}
+
+	if run := dispatch(t, service); run.Delivered != 1 {
+		t.Fatalf("run = %+v, want 1 delivered", run)
+	}
+
+	requests := target.received()
+	if len(requests) != 2 {
+		t.Fatalf("got %d requests, want 2", len(requests))
+	}
+	if string(requests[1].body) != string(requests[0].body) {
+		t.Errorf("redelivered body = %s, want %s", requests[1].body, requests[0].body)
+	}
+	if got := requests[1].header.Get(webhook.DeliveryHeader); got != redelivery.ID {
+		t.Errorf("%s = %q, want %q", webhook.DeliveryHeader, got, redelivery.ID)
+	}
+
+	if _, err := service.RedeliverWebhookDelivery(context.Background(), created.ID, "missing"); err == nil {
+		t.Error("RedeliverWebhookDelivery of a missing delivery succeeded")
+	}
+}
+
+func TestDeliverOnlyQueuesToTheWebhooksOfTheTenant(t *testing.T) {
+	target := newReceiver(t)
+	service := services.NewWebhookService(memory.NewWebhookRepository(), webhook.NewHTTPSender(time.Second), 10, 3, time.Second)
+
+	acme := domain.WithTenant(context.Background(), "acme")
+	globex := domain.WithTenant(context.Background(), "globex")
+
+	acmeWebhook, err := service.CreateWebhook(acme, &domain.NewWebhookInput{URL: target.URL, Secret: testWebhookSecret})
+	if err != nil {
+		t.Fatalf("CreateWebhook failed: %v", err)
+	}
+	if _, err := service.CreateWebhook(globex, &domain.NewWebhookInput{URL: target.URL, Secret: testWebhookSecret}); err != nil {
+		t.Fatalf("CreateWebhook failed: %v", err)
+	}
+
+	if err := service.Deliver(domain.WithOutboxEntry(acme, 1), domain.StoryDeleted{StoryID: "story"}); err != nil {
+		t.Fatalf("Deliver failed: %v", err)
+	}
+
+	// The dispatcher sends the deliveries of every tenant
+	if run := dispatch(t, service); run.Delivered != 1 {
+		t.Fatalf("run = %+v, want 1 delivered", run)
+	}
+
+	page, err := service.GetWebhookDeliveries(acme, acmeWebhook.ID, domain.PageRequest{Limit: 100})
+	if err != nil {
+		t.Fatalf("GetWebhookDeliveries failed: %v", err)
+	}
+	if len(page.Items) != 1 || page.Items[0].DeliveredAt == nil {
+		t.Errorf("deliveries of acme = %+v, want one delivered", page.Items)
+	}
+
+	if _, err := service.GetWebhookDeliveries(globex, acmeWebhook.ID, domain.PageRequest{Limit: 100}); err == nil {
+		t.Error("globex listed the deliveries of a webhook of acme")
+	}
+}
@@ -0,0 +1,40 @@
+package platform
+
+import (
+	"Gin/internal/core/domain"
+	"Gin/internal/core/ports"
+	"context"
+	"log"
+	"time"
+)
+
+// Signs a checkpoint of the audit chain of every tenant until ctx is cancelled, every interval, when events were recorded
+// since the previous one. Several instances of the API may sign the same event: the duplicates are harmless.
+func RunAuditCheckpoints(ctx context.Context, audit ports.AuditDrivingPort, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		tenants, err := audit.GetAuditTenants(ctx)
+		if err != nil {
+			log.Printf("Audit checkpointer: %v", err)
+			continue
+		}
+
+		for _, tenant := range tenants {
+			checkpoint, err := audit.CreateAuditCheckpoint(domain.WithTenant(ctx, tenant))
+
+			if err != nil {
+				log.Printf("Audit checkpointer: tenant %s: %v", tenant, err)
+			} else if checkpoint != nil {
+				log.Printf("Audit checkpointer: signed the chain of tenant %s up to event %d", tenant, checkpoint.EventID)
+			}
+		}
+	}
+}
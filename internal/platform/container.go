@@ -1,8 +1,15 @@
 package platform
 
 import (
+	"Gin/internal/adapters/cache"
+	"Gin/internal/adapters/db/memory"
 	"Gin/internal/adapters/db/postgresql"
+	"Gin/internal/adapters/db/sqlite"
+	"Gin/internal/adapters/events"
 	"Gin/internal/adapters/http"
+	"Gin/internal/adapters/webhook"
+	"Gin/internal/config"
+	"Gin/internal/core/ports"
 	"Gin/internal/core/services"
 
 	"database/sql"
@@ -10,27 +17,133 @@ import (
 
 // Represents the container for the application.
 type Container struct {
-	UserHandler  *http.UserHandler
-	StoryHandler *http.StoryHandler
+	UserHandler    *http.UserHandler
+	StoryHandler   *http.StoryHandler
+	CommentHandler *http.CommentHandler
+	AdminHandler   *http.AdminHandler
+	WebhookHandler *http.WebhookHandler
+	AuditHandler   *http.AuditHandler
+	Outbox         ports.OutboxDrivingPort  // Run by the outbox relay
+	Webhooks       ports.WebhookDrivingPort // Run by the webhook dispatcher
+	Audit          ports.AuditDrivingPort   // Run by the audit checkpointer
+}
+
+// Represents the application services, shared by the HTTP handlers and the CLI subcommands.
+type Services struct {
+	Users    ports.UserDriverPort
+	Stories  ports.StoryDrivingPort
+	Comments ports.CommentDrivingPort
+	Outbox   ports.OutboxDrivingPort  // Delivers the domain events recorded by the other services
+	Webhooks ports.WebhookDrivingPort // Sends the delivered events to the subscribed URLs
+	Audit    ports.AuditDrivingPort   // Reads and verifies the audit log written by the user and story services
+	Events   *events.Bus              // Receives the delivered events; Close it to flush the asynchronous subscribers
 }
 
 // Creates a new instance of Container.
-func SetupContainer(db *sql.DB) *Container {
+func SetupContainer(cfg *config.Config, db *sql.DB, replicas *postgresql.ReplicaRouter) *Container {
+	services := SetupServices(cfg, db, replicas)
 
-	// Repositories are used to interact with the database.
-	userRepo := postgresql.NewUserRepository(db)
-	storyRepo := postgresql.NewStoryRepository(db)
+	var dbStats ports.DatabaseStatsPort
+	if db != nil {
+		dbStats = NewDatabaseStats(db, replicas)
+	}
 
-	// Services are used to interact with the domain.
-	userService := services.NewUserService(userRepo)
-	storyService := services.NewStoryService(storyRepo)
+	// Serve story reads from an in-process cache, kept fresh by the story and user writes
+	var cacheStats ports.CacheStatsPort
+	if cfg.CacheEnabled {
+		storyCache := cache.NewStoryCache(services.Stories, cfg.CacheSize, cfg.CacheTTL)
+		services.Stories = storyCache
+		services.Users = storyCache.WrapUsers(services.Users)
+		cacheStats = storyCache
+	}
 
 	// Adapters are used to interact with the ports.
-	userHandler := http.NewUserHandler(userService)
-	storyHandler := http.NewStoryHandler(storyService)
+	userHandler := http.NewUserHandler(services.Users)
+	storyHandler := http.NewStoryHandler(services.Stories)
+	commentHandler := http.NewCommentHandler(services.Comments)
+	adminHandler := http.NewAdminHandler(dbStats, cacheStats, services.Outbox)
+	webhookHandler := http.NewWebhookHandler(services.Webhooks)
+	auditHandler := http.NewAuditHandler(services.Audit)
 
 	return &Container{
-		UserHandler:  userHandler,
-		StoryHandler: storyHandler,
+		UserHandler:    userHandler,
+		StoryHandler:   storyHandler,
+		CommentHandler: commentHandler,
+		AdminHandler:   adminHandler,
+		WebhookHandler: webhookHandler,
+		AuditHandler:   auditHandler,
+		Outbox:         services.Outbox,
+		Webhooks:       services.Webhooks,
+		Audit:          services.Audit,
+	}
+}
+
+// Creates the application services over the storage adapters.
+// The storage adapters are selected by cfg.StorageDriver; db is used by the SQL adapters.
+// When replicas is not nil, the PostgreSQL repositories read through it; transactions always run on db.
+func SetupServices(cfg *config.Config, db *sql.DB, replicas *postgresql.ReplicaRouter) *Services {
+
+	// Repositories are used to interact with the database.
+	// The unit of work runs multi-repository operations in a transaction.
+	var userRepo ports.UserDrivenPort
+	var storyRepo ports.StoryDrivenPort
+	var storyRevisionRepo ports.StoryRevisionDrivenPort
+	var commentRepo ports.CommentDrivenPort
+	var outboxRepo ports.OutboxDrivenPort
+	var webhookRepo ports.WebhookDrivenPort
+	var auditRepo ports.AuditDrivenPort
+	var uow ports.UnitOfWork
+
+	switch cfg.StorageDriver {
+	case config.StorageMemory:
+		memoryUsers := memory.NewUserRepository()
+		memoryStories := memory.NewStoryRepository()
+		memoryStoryRevisions := memory.NewStoryRevisionRepository()
+		memoryComments := memory.NewCommentRepository()
+		memoryOutbox := memory.NewOutboxRepository()
+		memoryAudit := memory.NewAuditRepository()
+		userRepo, storyRepo, storyRevisionRepo, commentRepo, outboxRepo, auditRepo = memoryUsers, memoryStories, memoryStoryRevisions, memoryComments, memoryOutbox, memoryAudit
+		uow = memory.NewUnitOfWork(memoryStories, memoryStoryRevisions, memoryUsers, memoryComments, memoryOutbox, memoryAudit)
+		webhookRepo = memory.NewWebhookRepository()
+	case config.StorageSQLite:
+		userRepo = sqlite.NewUserRepository(db)
+		storyRepo = sqlite.NewStoryRepository(db)
+		storyRevisionRepo = sqlite.NewStoryRevisionRepository(db)
+		commentRepo = sqlite.NewCommentRepository(db)
+		outboxRepo = sqlite.NewOutboxRepository(db)
+		webhookRepo = sqlite.NewWebhookRepository(db)
+		auditRepo = sqlite.NewAuditRepository(db)
+		uow = sqlite.NewUnitOfWork(db)
+	default:
+		var conn postgresql.DBTX = db
+		if replicas != nil {
+			conn = replicas
+		}
+
+		userRepo = postgresql.NewUserRepository(conn)
+		storyRepo = postgresql.NewStoryRepository(conn)
+		storyRevisionRepo = postgresql.NewStoryRevisionRepository(conn)
+		commentRepo = postgresql.NewCommentRepository(conn)
+		outboxRepo = postgresql.NewOutboxRepository(conn)
+		webhookRepo = postgresql.NewWebhookRepository(conn)
+		auditRepo = postgresql.NewAuditRepository(conn)
+		uow = postgresql.NewUnitOfWork(db)
+	}
+
+	// The services record their events in the outbox, from which they are delivered to the webhooks
+	// and to the bus, where the other features subscribe
+	bus := events.NewBus()
+	subscribeEvents(cfg, bus)
+	webhooks := services.NewWebhookService(webhookRepo, webhook.NewHTTPSender(cfg.WebhookTimeout), cfg.OutboxBatchSize, cfg.WebhookMaxAttempts, cfg.WebhookTimeout)
+
+	// Services are used to interact with the domain.
+	return &Services{
+		Users:    services.NewUserService(userRepo, uow),
+		Stories:  services.NewStoryService(storyRepo, storyRevisionRepo, userRepo, uow),
+		Comments: services.NewCommentService(commentRepo, storyRepo, uow),
+		Outbox:   services.NewOutboxService(outboxRepo, []ports.EventSink{webhooks, bus}, cfg.OutboxBatchSize, cfg.OutboxMaxAttempts),
+		Webhooks: webhooks,
+		Audit:    services.NewAuditService(auditRepo, cfg.AuditSigningKey),
+		Events:   bus,
 	}
 }
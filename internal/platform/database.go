@@ -1,36 +1,195 @@
 package platform
 
 import (
+	"Gin/internal/adapters/db/postgresql"
+	"Gin/internal/adapters/db/sqlite"
+	"Gin/internal/config"
+	"Gin/internal/platform/migrate"
+	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"log"
-	"os"
+	"strings"
+	"time"
 
 	_ "github.com/lib/pq"
 )
 
-// Initializes and returns a database connection.
-func InitDB() (*sql.DB, error) {
-	connStr := os.Getenv("DB_CONNECTION_STRING")
+// Initializes and returns a database connection, applying pending migrations when DB_AUTO_MIGRATE is enabled.
+func InitDB(cfg *config.Config) (*sql.DB, error) {
+	db, err := OpenDB(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	// Opt-in: apply pending migrations before serving requests
+	if cfg.DBAutoMigrate {
+		if err := RunMigrations(cfg, db); err != nil {
+			db.Close()
+			return nil, err
+		}
+	}
+
+	return db, nil
+}
+
+// Opens and verifies a database connection without touching the schema.
+// The database may still be starting (e.g. under docker-compose), so the first ping is retried
+// with exponential backoff for up to DB_CONNECT_TIMEOUT.
+func OpenDB(cfg *config.Config) (*sql.DB, error) {
+	connStr := cfg.DBConnectionString
 	if connStr == "" {
-		log.Fatal("DB_CONNECTION_STRING environment variable not set.")
+		return nil, errors.New("DB_CONNECTION_STRING environment variable not set")
+	}
+
+	driverName, dsn := "postgres", connStr
+	if cfg.StorageDriver == config.StorageSQLite {
+		// Wait on locks instead of failing with SQLITE_BUSY, let readers run alongside the writer,
+		// and take the write lock when a transaction begins so it never has to upgrade mid-way
+		driverName, dsn = "sqlite", sqliteDSN(connStr)
 	}
 
-	// Use "postgres" as the driver name
-	db, err := sql.Open("postgres", connStr)
+	db, err := sql.Open(driverName, dsn)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open database connection: %w", err)
 	}
 
+	configurePool(cfg, db)
+
 	// Ping the database to verify the connection
-	if err = db.Ping(); err != nil {
+	if err = pingWithRetry(db, cfg.DBConnectTimeout); err != nil {
+		db.Close()
 		return nil, fmt.Errorf("failed to connect to the database: %w", err)
 	}
 
-	log.Println("Successfully connected to PostgreSQL database!")
+	log.Printf("Successfully connected to %s database!", cfg.StorageDriver)
 	return db, nil
 }
 
+// Applies the connection pool settings of the configuration to db.
+func configurePool(cfg *config.Config, db *sql.DB) {
+	db.SetMaxOpenConns(cfg.DBMaxOpenConns)
+	db.SetMaxIdleConns(cfg.DBMaxIdleConns)
+	db.SetConnMaxLifetime(cfg.DBConnMaxLifetime)
+	db.SetConnMaxIdleTime(cfg.DBConnMaxIdleTime)
+}
+
+// Bounds of the delay between two startup pings, doubled after every failure.
+const (
+	minPingBackoff = 500 * time.Millisecond
+	maxPingBackoff = 5 * time.Second
+)
+
+// Pings db until it answers or timeout is spent, waiting longer after each failure. A zero timeout pings once.
+func pingWithRetry(db *sql.DB, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	backoff := minPingBackoff
+
+	for attempt := 1; ; attempt++ {
+		err := db.Ping()
+		if err == nil {
+			return nil
+		}
+
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return fmt.Errorf("gave up after %d attempts: %w", attempt, err)
+		}
+
+		wait := min(backoff, remaining)
+		log.Printf("Database not ready (attempt %d): %v; retrying in %s", attempt, err, wait)
+		time.Sleep(wait)
+
+		backoff = min(backoff*2, maxPingBackoff)
+	}
+}
+
+// Opens the read replicas listed in DB_REPLICA_CONNECTION_STRINGS and returns a router that sends
+// the reads of the repositories to them and everything else to the primary. Returns nil when no replica is configured.
+// Replicas are not pinged here: one that is down is skipped until its health check succeeds.
+func InitReplicas(cfg *config.Config, primary *sql.DB) (*postgresql.ReplicaRouter, error) {
+	if len(cfg.DBReplicaConnectionStrings) == 0 {
+		return nil, nil
+	}
+
+	replicas := make([]*sql.DB, 0, len(cfg.DBReplicaConnectionStrings))
+
+	for i, connStr := range cfg.DBReplicaConnectionStrings {
+		db, err := sql.Open("postgres", connStr)
+		if err != nil {
+			for _, opened := range replicas {
+				opened.Close()
+			}
+			return nil, fmt.Errorf("failed to open database replica %d: %w", i+1, err)
+		}
+
+		configurePool(cfg, db)
+		replicas = append(replicas, db)
+	}
+
+	log.Printf("Routing reads to %d database replicas", len(replicas))
+	return postgresql.NewReplicaRouter(primary, replicas, postgresql.DefaultReplicaCheckInterval), nil
+}
+
+// Returns the SQLite data source name for the file path, with the pragmas the adapter relies on.
+func sqliteDSN(path string) string {
+	if strings.Contains(path, "_pragma=") {
+		return path
+	}
+
+	separator := "?"
+	if strings.Contains(path, "?") {
+		separator = "&"
+	}
+
+	return path + separator + "_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)&_pragma=foreign_keys(1)&_txlock=immediate"
+}
+
+// Returns a migrator for the embedded migrations of the configured driver.
+func NewMigrator(cfg *config.Config, db *sql.DB) (*migrate.Migrator, error) {
+	switch cfg.StorageDriver {
+	case config.StoragePostgres:
+		return migrate.New(db, postgresql.Migrations(), migrate.Postgres)
+	case config.StorageSQLite:
+		return migrate.New(db, sqlite.Migrations(), migrate.SQLite)
+	}
+
+	return nil, fmt.Errorf("the %s storage driver has no migrations", cfg.StorageDriver)
+}
+
+// Returns the on-disk directory of the migrations of the configured driver, used to create new ones.
+func MigrationsDir(cfg *config.Config) (string, error) {
+	switch cfg.StorageDriver {
+	case config.StoragePostgres:
+		return postgresql.MigrationsDir, nil
+	case config.StorageSQLite:
+		return sqlite.MigrationsDir, nil
+	}
+
+	return "", fmt.Errorf("the %s storage driver has no migrations", cfg.StorageDriver)
+}
+
+// Applies every pending migration. Concurrent callers (e.g. several instances starting together)
+// are serialized by an advisory lock, so each migration runs exactly once.
+func RunMigrations(cfg *config.Config, db *sql.DB) error {
+	migrator, err := NewMigrator(cfg, db)
+	if err != nil {
+		return err
+	}
+
+	applied, err := migrator.Up(context.Background())
+	if err != nil {
+		return fmt.Errorf("failed to run database migrations: %w", err)
+	}
+
+	for _, migration := range applied {
+		log.Printf("Applied migration %04d_%s", migration.Version, migration.Name)
+	}
+
+	return nil
+}
+
 // Closes the database connection.
 func CloseDB(db *sql.DB) {
 	if db != nil {
@@ -0,0 +1,49 @@
+package platform
+
+import (
+	"Gin/internal/adapters/db/postgresql"
+	"Gin/internal/core/domain"
+	"database/sql"
+)
+
+// Implements the ports.DatabaseStatsPort interface over the pools opened by InitDB and InitReplicas.
+type DatabaseStats struct {
+	db       *sql.DB
+	replicas *postgresql.ReplicaRouter // nil without read replicas
+}
+
+// Creates a new instance of DatabaseStats.
+func NewDatabaseStats(db *sql.DB, replicas *postgresql.ReplicaRouter) *DatabaseStats {
+	return &DatabaseStats{db: db, replicas: replicas}
+}
+
+// Reports the primary pool, then the pool and health of each read replica.
+func (s *DatabaseStats) DatabaseStats() []domain.DBPoolStats {
+	stats := []domain.DBPoolStats{poolStats("primary", s.db.Stats())}
+
+	if s.replicas != nil {
+		for _, replica := range s.replicas.Replicas() {
+			replicaStats := poolStats(replica.Name, replica.Stats)
+			replicaStats.Healthy = &replica.Healthy
+			stats = append(stats, replicaStats)
+		}
+	}
+
+	return stats
+}
+
+// Converts the statistics of a database/sql pool.
+func poolStats(name string, stats sql.DBStats) domain.DBPoolStats {
+	return domain.DBPoolStats{
+		Name:               name,
+		MaxOpenConnections: stats.MaxOpenConnections,
+		OpenConnections:    stats.OpenConnections,
+		InUse:              stats.InUse,
+		Idle:               stats.Idle,
+		WaitCount:          stats.WaitCount,
+		WaitDurationMillis: stats.WaitDuration.Milliseconds(),
+		MaxIdleClosed:      stats.MaxIdleClosed,
+		MaxIdleTimeClosed:  stats.MaxIdleTimeClosed,
+		MaxLifetimeClosed:  stats.MaxLifetimeClosed,
+	}
+}
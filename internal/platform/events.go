@@ -0,0 +1,15 @@
+package platform
+
+import (
+	"Gin/internal/adapters/events"
+	"Gin/internal/config"
+)
+
+// Registers the in-process subscribers of the domain events.
+// Features that react to changes (notifications, search indexing...) subscribe here
+// rather than being called from the services.
+func subscribeEvents(cfg *config.Config, bus *events.Bus) {
+	if cfg.EventLog {
+		bus.SubscribeAsync("log", events.LogHandler)
+	}
+}
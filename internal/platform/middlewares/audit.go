@@ -0,0 +1,54 @@
+package middlewares
+
+import (
+	"Gin/internal/core/domain"
+	"strings"
+	"unicode"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// Headers read by AuditContextMiddleware.
+const (
+	ActorHeader     = "X-Actor"      // Who makes the request, as declared by the caller
+	RequestIDHeader = "X-Request-ID" // Kept when valid, generated otherwise, and echoed in the response
+)
+
+// Longest actor or request ID accepted from a header.
+const maxAuditHeaderLength = 200
+
+// AuditContextMiddleware attaches the actor, request ID and client IP of every request to its context,
+// so the changes it makes are attributed to it in the audit log. The request ID is also returned
+// in the response so callers can find their changes. The API has no authentication yet,
+// so the actor is whatever the caller declares in the X-Actor header.
+func AuditContextMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := auditHeader(c, RequestIDHeader)
+		if requestID == "" {
+			requestID = uuid.New().String()
+		}
+
+		c.Header(RequestIDHeader, requestID)
+
+		ctx := domain.WithAuditContext(c.Request.Context(), domain.AuditContext{
+			Actor:     auditHeader(c, ActorHeader),
+			RequestID: requestID,
+			ClientIP:  c.ClientIP(),
+		})
+
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
+
+// Returns the trimmed value of a header, or "" if it is too long or holds control characters.
+func auditHeader(c *gin.Context, name string) string {
+	value := strings.TrimSpace(c.GetHeader(name))
+
+	if len(value) > maxAuditHeaderLength || strings.ContainsFunc(value, unicode.IsControl) {
+		return ""
+	}
+
+	return value
+}
@@ -17,8 +17,8 @@ func CORSMiddleware() gin.HandlerFunc {
 			// Add your frontend origins here in production: e.g., "https://your-frontend.com"
 		},
 		AllowMethods:     []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization"},
-		ExposeHeaders:    []string{"Content-Length"},
+		AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization", "If-Match", "X-Actor", "X-Request-ID", "X-Tenant-ID"},
+		ExposeHeaders:    []string{"Content-Length", "ETag", "X-Request-ID"},
 		AllowCredentials: true,
 		MaxAge:           86400, // Cache preflight requests for 24 hours
 	})
@@ -0,0 +1,16 @@
+package middlewares
+
+import (
+	"Gin/internal/adapters/db/postgresql"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ReadYourWritesMiddleware gives every request its own read-your-writes session:
+// its reads go to the read replicas until it writes, and to the primary afterwards.
+func ReadYourWritesMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Request = c.Request.WithContext(postgresql.WithReadYourWrites(c.Request.Context()))
+		c.Next()
+	}
+}
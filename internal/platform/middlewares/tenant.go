@@ -0,0 +1,176 @@
+package middlewares
+
+import (
+	"Gin/internal/core/domain"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Reads the tenant of a request from one place. Returns "" when the request does not name one there.
+type TenantSource func(c *gin.Context) (string, error)
+
+// Returned by a TenantSource when the credentials holding the tenant are missing or not valid, which is answered with 401.
+var (
+	errMissingTenantToken = errors.New("tenant token is required")
+	errInvalidTenantToken = errors.New("invalid tenant token")
+)
+
+// TenantMiddleware resolves the tenant of every request from the given sources and attaches it to its context,
+// so every user and story repository only sees the data of that tenant. Each source that names a tenant must
+// name the same one, so a header cannot reach into another tenant than the one of the token or of the host.
+// Requests naming no tenant, or an invalid one, are rejected, and so are those without a token when TokenTenant is one of the sources.
+func TenantMiddleware(sources ...TenantSource) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tenant := ""
+
+		for _, source := range sources {
+			value, err := source(c)
+			if errors.Is(err, errMissingTenantToken) || errors.Is(err, errInvalidTenantToken) {
+				c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+				return
+			}
+			if err != nil {
+				c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+
+			if value == "" {
+				continue
+			}
+
+			if !domain.IsTenantID(value) {
+				c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid tenant " + value})
+				return
+			}
+
+			if tenant != "" && value != tenant {
+				c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "The tenant sources of the request disagree"})
+				return
+			}
+
+			tenant = value
+		}
+
+		if tenant == "" {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Tenant is required"})
+			return
+		}
+
+		c.Request = c.Request.WithContext(domain.WithTenant(c.Request.Context(), tenant))
+		c.Next()
+	}
+}
+
+// Returns a TenantSource reading the tenant from the given request header.
+// Any caller can send it, so it must come along with TokenTenant, whose tenant it can then only repeat.
+func HeaderTenant(name string) TenantSource {
+	return func(c *gin.Context) (string, error) {
+		return strings.TrimSpace(c.GetHeader(name)), nil
+	}
+}
+
+// Returns a TenantSource reading the tenant from the first label of the host, such as acme in acme.api.example.com
+// for the base domain api.example.com. Hosts outside of the base domain, and the base domain itself, name no tenant.
+// Like a header, the host is up to the caller, so it must come along with TokenTenant.
+func SubdomainTenant(baseDomain string) TenantSource {
+	suffix := "." + strings.ToLower(baseDomain)
+
+	return func(c *gin.Context) (string, error) {
+		host := strings.ToLower(c.Request.Host)
+		if withoutPort, _, err := net.SplitHostPort(host); err == nil {
+			host = withoutPort
+		}
+
+		label, ok := strings.CutSuffix(host, suffix)
+		if !ok || label == "" {
+			return "", nil
+		}
+
+		return label, nil // A nested subdomain has dots, so it is not a valid tenant
+	}
+}
+
+// Returns a TenantSource reading the tenant_id claim of the bearer token of the Authorization header,
+// a JWT signed with HS256 and secret. Requests without a token are rejected, as are expired tokens:
+// the other sources can then only name the tenant of the token.
+func TokenTenant(secret []byte) TenantSource {
+	return func(c *gin.Context) (string, error) {
+		token, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if !ok || strings.TrimSpace(token) == "" {
+			return "", errMissingTenantToken
+		}
+
+		claims, err := verifyTenantToken(strings.TrimSpace(token), secret)
+		if err != nil {
+			return "", err
+		}
+
+		if claims.TenantID == "" {
+			return "", errInvalidTenantToken
+		}
+
+		return claims.TenantID, nil
+	}
+}
+
+// Claims of a tenant token read by TokenTenant.
+type tenantClaims struct {
+	TenantID  string `json:"tenant_id"`
+	ExpiresAt *int64 `json:"exp"` // Unix time, optional
+}
+
+// Checks the signature and expiry of an HS256 JWT and returns its claims.
+func verifyTenantToken(token string, secret []byte) (*tenantClaims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, errInvalidTenantToken
+	}
+
+	var header struct {
+		Alg string `json:"alg"`
+	}
+	if err := decodeTokenPart(parts[0], &header); err != nil || header.Alg != "HS256" {
+		return nil, errInvalidTenantToken
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, errInvalidTenantToken
+	}
+
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(parts[0] + "." + parts[1]))
+	if !hmac.Equal(signature, mac.Sum(nil)) {
+		return nil, errInvalidTenantToken
+	}
+
+	claims := &tenantClaims{}
+	if err := decodeTokenPart(parts[1], claims); err != nil {
+		return nil, errInvalidTenantToken
+	}
+
+	if claims.ExpiresAt != nil && time.Now().Unix() >= *claims.ExpiresAt {
+		return nil, errInvalidTenantToken
+	}
+
+	return claims, nil
+}
+
+// Decodes a base64url JSON part of a JWT into target.
+func decodeTokenPart(part string, target any) error {
+	raw, err := base64.RawURLEncoding.DecodeString(part)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(raw, target)
+}
@@ -0,0 +1,101 @@
+package middlewares
+
+import (
+	"Gin/internal/core/domain"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const testTenantHeader = "X-Tenant-ID"
+
+var testTenantSecret = []byte("0123456789abcdef")
+
+// Returns an HS256 token signed with secret carrying the given claims.
+func signTenantToken(t *testing.T, secret []byte, claims map[string]any) string {
+	t.Helper()
+
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		t.Fatalf("failed to encode claims: %v", err)
+	}
+
+	unsigned := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`)) + "." + base64.RawURLEncoding.EncodeToString(payload)
+
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(unsigned))
+
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// Serves a request through TenantMiddleware and returns the status and the tenant the handler saw.
+func serveTenant(t *testing.T, request *http.Request, sources ...TenantSource) (int, string) {
+	t.Helper()
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	tenant := ""
+	router.GET("/", TenantMiddleware(sources...), func(c *gin.Context) {
+		tenant = domain.TenantFrom(c.Request.Context())
+		c.Status(http.StatusNoContent)
+	})
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+
+	return recorder.Code, tenant
+}
+
+func TestTenantMiddleware(t *testing.T) {
+	acmeToken := signTenantToken(t, testTenantSecret, map[string]any{"tenant_id": "acme"})
+	expiredToken := signTenantToken(t, testTenantSecret, map[string]any{"tenant_id": "acme", "exp": time.Now().Add(-time.Minute).Unix()})
+	forgedToken := signTenantToken(t, []byte("another secret"), map[string]any{"tenant_id": "acme"})
+
+	headerAndToken := []TenantSource{HeaderTenant(testTenantHeader), TokenTenant(testTenantSecret)}
+
+	tests := []struct {
+		name       string
+		sources    []TenantSource
+		header     string
+		token      string
+		wantStatus int
+		wantTenant string
+	}{
+		{name: "no tenant", sources: []TenantSource{HeaderTenant(testTenantHeader)}, wantStatus: http.StatusBadRequest},
+		{name: "invalid tenant", sources: headerAndToken, header: "Acme!", token: acmeToken, wantStatus: http.StatusBadRequest},
+		{name: "header and token agree", sources: headerAndToken, header: "acme", token: acmeToken, wantStatus: http.StatusNoContent, wantTenant: "acme"},
+		{name: "token only", sources: headerAndToken, token: acmeToken, wantStatus: http.StatusNoContent, wantTenant: "acme"},
+		{name: "header and token disagree", sources: headerAndToken, header: "globex", token: acmeToken, wantStatus: http.StatusBadRequest},
+		{name: "header without a token", sources: headerAndToken, header: "globex", wantStatus: http.StatusUnauthorized},
+		{name: "expired token", sources: headerAndToken, header: "acme", token: expiredToken, wantStatus: http.StatusUnauthorized},
+		{name: "token of another secret", sources: headerAndToken, header: "acme", token: forgedToken, wantStatus: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.header != "" {
+				request.Header.Set(testTenantHeader, tt.header)
+			}
+			if tt.token != "" {
+				request.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+
+			status, tenant := serveTenant(t, request, tt.sources...)
+			if status != tt.wantStatus {
+				t.Errorf("status = %d, want %d", status, tt.wantStatus)
+			}
+			if tenant != tt.wantTenant && tt.wantStatus == http.StatusNoContent {
+				t.Errorf("tenant = %q, want %q", tenant, tt.wantTenant)
+			}
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package middlewares
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// QueryTimeoutMiddleware bounds the context of every request with the given timeout.
+// Handlers pass the request context down to the repositories, so slow queries are cancelled
+// once the deadline passes, as they already are when the client disconnects.
+// Requests to the exempt routes (full paths such as "/api/stories/export") are not bounded,
+// for they stream results for as long as the client keeps reading or write large batches.
+func QueryTimeoutMiddleware(timeout time.Duration, exempt ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if slices.Contains(exempt, c.FullPath()) {
+			c.Next()
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), timeout)
+		defer cancel()
+
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
@@ -0,0 +1,317 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Matches migration file names such as 0001_create_users.up.sql.
+var fileNamePattern = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.(up|down)\.sql$`)
+
+// Represents a numbered schema change with the SQL to apply and revert it.
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+// Represents a migration together with the time it was applied (nil while pending).
+type MigrationStatus struct {
+	Migration
+	AppliedAt *time.Time
+}
+
+// Describes the database-specific parts of running migrations.
+type Dialect interface {
+	// Returns the placeholder for the n-th (1-based) query argument.
+	Placeholder(n int) string
+	// Returns the statement creating the schema_migrations table if it does not exist.
+	CreateTableSQL() string
+	// Blocks until this connection holds the exclusive migration lock.
+	Lock(ctx context.Context, conn *sql.Conn) error
+	// Releases the migration lock held by this connection.
+	Unlock(ctx context.Context, conn *sql.Conn) error
+}
+
+// Applies and reverts embedded migrations, tracking them in the schema_migrations table.
+type Migrator struct {
+	db         *sql.DB
+	dialect    Dialect
+	migrations []Migration
+}
+
+// Creates a new instance of Migrator reading *.up.sql and *.down.sql files from the root of fsys.
+func New(db *sql.DB, fsys fs.FS, dialect Dialect) (*Migrator, error) {
+	migrations, err := Load(fsys)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Migrator{db: db, dialect: dialect, migrations: migrations}, nil
+}
+
+// Reads the migrations from the root of fsys, ordered by version.
+func Load(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, fmt.Errorf("migrate: failed to read migrations: %w", err)
+	}
+
+	byVersion := make(map[int64]*Migration)
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		match := fileNamePattern.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("migrate: unexpected file %q, expected NNNN_name.up.sql or NNNN_name.down.sql", entry.Name())
+		}
+
+		version, _ := strconv.ParseInt(match[1], 10, 64)
+		content, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, fmt.Errorf("migrate: failed to read %s: %w", entry.Name(), err)
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = migration
+		} else if migration.Name != match[2] {
+			return nil, fmt.Errorf("migrate: version %d is used by both %q and %q", version, migration.Name, match[2])
+		}
+
+		if match[3] == "up" {
+			migration.Up = string(content)
+		} else {
+			migration.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Up == "" {
+			return nil, fmt.Errorf("migrate: migration %d_%s has no up file", migration.Version, migration.Name)
+		}
+		migrations = append(migrations, *migration)
+	}
+
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+
+	return migrations, nil
+}
+
+// Applies every pending migration in order and returns the ones applied.
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	applied := make([]Migration, 0)
+
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		done, err := m.appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range m.migrations {
+			if _, ok := done[migration.Version]; ok {
+				continue
+			}
+
+			insert := fmt.Sprintf(`INSERT INTO schema_migrations (version, name, applied_at) VALUES (%s, %s, %s)`,
+				m.dialect.Placeholder(1), m.dialect.Placeholder(2), m.dialect.Placeholder(3))
+
+			err := m.inTx(ctx, conn, migration.Up, insert, migration.Version, migration.Name, time.Now().UTC())
+			if err != nil {
+				return fmt.Errorf("migrate: failed to apply %d_%s: %w", migration.Version, migration.Name, err)
+			}
+
+			applied = append(applied, migration)
+		}
+
+		return nil
+	})
+
+	return applied, err
+}
+
+// Reverts the last `steps` applied migrations, newest first, and returns the ones reverted.
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	reverted := make([]Migration, 0, steps)
+
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		done, err := m.appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for i := len(m.migrations) - 1; i >= 0 && len(reverted) < steps; i-- {
+			migration := m.migrations[i]
+			if _, ok := done[migration.Version]; !ok {
+				continue
+			}
+
+			if migration.Down == "" {
+				return fmt.Errorf("migrate: migration %d_%s has no down file", migration.Version, migration.Name)
+			}
+
+			remove := fmt.Sprintf(`DELETE FROM schema_migrations WHERE version = %s`, m.dialect.Placeholder(1))
+
+			if err := m.inTx(ctx, conn, migration.Down, remove, migration.Version); err != nil {
+				return fmt.Errorf("migrate: failed to revert %d_%s: %w", migration.Version, migration.Name, err)
+			}
+
+			reverted = append(reverted, migration)
+		}
+
+		return nil
+	})
+
+	return reverted, err
+}
+
+// Returns every known migration with the time it was applied, if it was.
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	statuses := make([]MigrationStatus, 0, len(m.migrations))
+
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		done, err := m.appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range m.migrations {
+			status := MigrationStatus{Migration: migration}
+			if appliedAt, ok := done[migration.Version]; ok {
+				status.AppliedAt = &appliedAt
+			}
+			statuses = append(statuses, status)
+		}
+
+		return nil
+	})
+
+	return statuses, err
+}
+
+// Runs fn on a dedicated connection holding the migration lock, after making sure schema_migrations exists.
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *sql.Conn) error) error {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("migrate: failed to acquire connection: %w", err)
+	}
+	defer conn.Close()
+
+	if err := m.dialect.Lock(ctx, conn); err != nil {
+		return fmt.Errorf("migrate: failed to acquire migration lock: %w", err)
+	}
+
+	defer func() {
+		// Use a fresh context so the lock is released even if ctx was cancelled
+		if err := m.dialect.Unlock(context.Background(), conn); err != nil {
+			// Closing the connection ends the session, which also releases the lock
+			conn.Raw(func(any) error { return driver.ErrBadConn })
+		}
+	}()
+
+	if _, err := conn.ExecContext(ctx, m.dialect.CreateTableSQL()); err != nil {
+		return fmt.Errorf("migrate: failed to create schema_migrations table: %w", err)
+	}
+
+	return fn(conn)
+}
+
+// Executes the migration script and the bookkeeping statement in one transaction.
+func (m *Migrator) inTx(ctx context.Context, conn *sql.Conn, script, bookkeeping string, args ...any) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, script); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, bookkeeping, args...); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Returns the applied migration versions mapped to the time they were applied.
+func (m *Migrator) appliedVersions(ctx context.Context, conn *sql.Conn) (map[int64]time.Time, error) {
+	rows, err := conn.QueryContext(ctx, `SELECT version, applied_at FROM schema_migrations`)
+	if err != nil {
+		return nil, fmt.Errorf("migrate: failed to query schema_migrations: %w", err)
+	}
+	defer rows.Close()
+
+	done := make(map[int64]time.Time)
+
+	for rows.Next() {
+		var version int64
+		var appliedAt time.Time
+		if err := rows.Scan(&version, &appliedAt); err != nil {
+			return nil, fmt.Errorf("migrate: failed to scan schema_migrations row: %w", err)
+		}
+		done[version] = appliedAt
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("migrate: rows iteration error: %w", err)
+	}
+
+	return done, nil
+}
+
+// Writes an empty up/down migration pair to dir, numbered after the highest existing version.
+// Returns the paths of the created files.
+func Create(dir, name string) ([]string, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	name = regexp.MustCompile(`[^a-z0-9]+`).ReplaceAllString(name, "_")
+	name = strings.Trim(name, "_")
+
+	if name == "" {
+		return nil, errors.New("migrate: migration name is required")
+	}
+
+	migrations, err := Load(os.DirFS(dir))
+	if err != nil {
+		return nil, err
+	}
+
+	version := int64(1)
+	if len(migrations) > 0 {
+		version = migrations[len(migrations)-1].Version + 1
+	}
+
+	paths := make([]string, 0, 2)
+
+	for _, direction := range []string{"up", "down"} {
+		file := filepath.Join(dir, fmt.Sprintf("%04d_%s.%s.sql", version, name, direction))
+		header := fmt.Sprintf("-- %s: %s\n", filepath.Base(file), direction)
+
+		if err := os.WriteFile(file, []byte(header), 0o644); err != nil {
+			return nil, fmt.Errorf("migrate: failed to write %s: %w", file, err)
+		}
+
+		paths = append(paths, file)
+	}
+
+	return paths, nil
+}
@@ -0,0 +1,38 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// Arbitrary application-wide key of the PostgreSQL advisory lock guarding migrations.
+const postgresLockKey int64 = 7_263_190_418_552
+
+// Implements Dialect for PostgreSQL, serializing migrators with a session-level advisory lock.
+type postgresDialect struct{}
+
+// The PostgreSQL migration dialect.
+var Postgres Dialect = postgresDialect{}
+
+func (postgresDialect) Placeholder(n int) string {
+	return fmt.Sprintf("$%d", n)
+}
+
+func (postgresDialect) CreateTableSQL() string {
+	return `CREATE TABLE IF NOT EXISTS schema_migrations (
+		version BIGINT PRIMARY KEY,
+		name TEXT NOT NULL,
+		applied_at TIMESTAMPTZ NOT NULL
+	)`
+}
+
+func (postgresDialect) Lock(ctx context.Context, conn *sql.Conn) error {
+	_, err := conn.ExecContext(ctx, `SELECT pg_advisory_lock($1)`, postgresLockKey)
+	return err
+}
+
+func (postgresDialect) Unlock(ctx context.Context, conn *sql.Conn) error {
+	_, err := conn.ExecContext(ctx, `SELECT pg_advisory_unlock($1)`, postgresLockKey)
+	return err
+}
@@ -0,0 +1,33 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+)
+
+// Implements Dialect for SQLite. A SQLite database is a single local file served by one instance,
+// so no cross-process lock is taken; each migration still runs in its own transaction.
+type sqliteDialect struct{}
+
+// The SQLite migration dialect.
+var SQLite Dialect = sqliteDialect{}
+
+func (sqliteDialect) Placeholder(int) string {
+	return "?"
+}
+
+func (sqliteDialect) CreateTableSQL() string {
+	return `CREATE TABLE IF NOT EXISTS schema_migrations (
+		version INTEGER PRIMARY KEY,
+		name TEXT NOT NULL,
+		applied_at TIMESTAMP NOT NULL
+	)`
+}
+
+func (sqliteDialect) Lock(context.Context, *sql.Conn) error {
+	return nil
+}
+
+func (sqliteDialect) Unlock(context.Context, *sql.Conn) error {
+	return nil
+}
@@ -0,0 +1,36 @@
+package platform
+
+import (
+	"Gin/internal/core/domain"
+	"context"
+	"log"
+	"time"
+)
+
+// Runs deliver until ctx is cancelled, every interval. Used for the outbox relay and the webhook dispatcher.
+// As long as a run finds due deliveries the next one starts right away, so a backlog drains without waiting.
+// Several instances of the API can run against the same database: they claim different deliveries.
+func RunRelay(ctx context.Context, name string, deliver func(context.Context) (*domain.DeliveryRun, error), interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		run, err := deliver(ctx)
+
+		if err != nil {
+			log.Printf("%s: %v", name, err)
+		} else if run.Retried > 0 || run.Failed > 0 {
+			log.Printf("%s: %d delivered, %d to retry, %d failed for good", name, run.Delivered, run.Retried, run.Failed)
+		}
+
+		if err == nil && run.Total() > 0 {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,18 @@
+package routes
+
+import (
+	"Gin/internal/adapters/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Manages the routes for administrative operations.
+func AdminRoutes(rg *gin.RouterGroup, adminHandler *http.AdminHandler) {
+	admin := rg.Group("/admin")
+	{
+		admin.GET("/db/stats", adminHandler.GetDatabaseStats)
+		admin.GET("/cache/stats", adminHandler.GetCacheStats)
+		admin.GET("/outbox", adminHandler.GetOutboxEntries)
+		admin.POST("/outbox/:id/retry", adminHandler.RetryOutboxEntry)
+	}
+}
@@ -0,0 +1,13 @@
+package routes
+
+import (
+	"Gin/internal/adapters/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Manages the routes for reading and verifying the audit log.
+func AuditRoutes(rg *gin.RouterGroup, auditHandler *http.AuditHandler) {
+	rg.GET("/audit", auditHandler.GetAuditEvents)
+	rg.GET("/audit/verify", auditHandler.VerifyAudit)
+}
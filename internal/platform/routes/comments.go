@@ -0,0 +1,20 @@
+package routes
+
+import (
+	"Gin/internal/adapters/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Manages the routes for comment-related operations.
+// Comments are created and listed under their story, and changed by their own ID.
+func CommentRoutes(rg *gin.RouterGroup, commentHandler *http.CommentHandler) {
+	rg.POST("/stories/:id/comments", commentHandler.CreateComment)
+	rg.GET("/stories/:id/comments", commentHandler.GetComments)
+
+	comments := rg.Group("/comments")
+	{
+		comments.PUT("/:id", commentHandler.UpdateComment)
+		comments.DELETE("/:id", commentHandler.DeleteComment)
+	}
+}
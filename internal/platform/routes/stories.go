@@ -11,9 +11,21 @@ func StoryRoutes(rg *gin.RouterGroup, storyHandler *http.StoryHandler) {
 	stories := rg.Group("/stories")
 	{
 		stories.POST("", storyHandler.CreateStory)
+		stories.POST("/import", storyHandler.ImportStories)
+		stories.GET("/search", storyHandler.SearchStories)
+		stories.GET("/export", storyHandler.ExportStories)
 		stories.GET("/:id", storyHandler.GetStory)
 		stories.GET("", storyHandler.GetAllStories)
 		stories.PUT("/:id", storyHandler.UpdateStory) // <-- PUT is used for partial updates
 		stories.DELETE("/:id", storyHandler.DeleteStory)
+		stories.POST("/:id/restore", storyHandler.RestoreStory)
+		stories.GET("/:id/revisions", storyHandler.GetStoryRevisions)
+		stories.GET("/:id/revisions/:rev", storyHandler.GetStoryRevision)
+		stories.GET("/:id/revisions/:rev/diff", storyHandler.DiffStoryRevisions)
+		stories.POST("/:id/revisions/:rev/restore", storyHandler.RestoreStoryRevision)
 	}
+
+	// The stories of a user and the tags of stories, served by the story handler
+	rg.GET("/users/:id/stories", storyHandler.GetUserStories)
+	rg.GET("/tags", storyHandler.GetTags)
 }
@@ -13,8 +13,10 @@ func UserRoutes(rg *gin.RouterGroup, userHandler *http.UserHandler) {
 	{
 		users.POST("/", userHandler.CreateUser)
 		users.GET("/", userHandler.GetAllUsers)
+		users.GET("/export", userHandler.ExportUsers)
 		users.GET("/:id", userHandler.GetUserByID)
 		users.PUT("/:id", userHandler.UpdateUser)
 		users.DELETE("/:id", userHandler.DeleteUser)
+		users.POST("/:id/restore", userHandler.RestoreUser)
 	}
 }
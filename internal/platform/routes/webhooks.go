@@ -0,0 +1,20 @@
+package routes
+
+import (
+	"Gin/internal/adapters/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Manages the routes for webhook-related operations.
+func WebhookRoutes(rg *gin.RouterGroup, webhookHandler *http.WebhookHandler) {
+	webhooks := rg.Group("/webhooks")
+	{
+		webhooks.POST("", webhookHandler.CreateWebhook)
+		webhooks.GET("", webhookHandler.GetWebhooks)
+		webhooks.GET("/:id", webhookHandler.GetWebhook)
+		webhooks.DELETE("/:id", webhookHandler.DeleteWebhook)
+		webhooks.GET("/:id/deliveries", webhookHandler.GetWebhookDeliveries)
+		webhooks.POST("/:id/deliveries/:delivery_id/redeliver", webhookHandler.RedeliverWebhookDelivery)
+	}
+}
@@ -1,29 +1,67 @@
 package platform
 
 import (
+	"Gin/internal/adapters/db/postgresql"
+	"Gin/internal/config"
 	"Gin/internal/platform/middlewares"
 	"Gin/internal/platform/routes"
+	"context"
 	"database/sql"
 
 	"github.com/gin-gonic/gin"
 )
 
 // InitGinServer configures and returns a Gin Engine instance.
-func InitGinServer(db *sql.DB) *gin.Engine {
+// replicas is nil unless read replicas are configured.
+func InitGinServer(cfg *config.Config, db *sql.DB, replicas *postgresql.ReplicaRouter) *gin.Engine {
 	// Initialize the hexagonal architecture components
-	container := SetupContainer(db)
+	container := SetupContainer(cfg, db, replicas)
+
+	// Deliver the events recorded by the services in the background, for as long as the server runs
+	go RunRelay(context.Background(), "Outbox relay", container.Outbox.DeliverOutbox, cfg.OutboxPollInterval)
+	go RunRelay(context.Background(), "Webhook dispatcher", container.Webhooks.DispatchWebhooks, cfg.OutboxPollInterval)
+
+	// Sign checkpoints of the audit chain, which can then be exported out of the database
+	if cfg.AuditSigningKey != nil {
+		go RunAuditCheckpoints(context.Background(), container.Audit, cfg.AuditCheckpointInterval)
+	}
 
 	app := gin.Default() // Gin with default logger and recovery middleware
 
 	// Apply global middlewares
 	app.Use(middlewares.CORSMiddleware()) // Use your centralized CORS middleware here
 
+	// Attribute the changes made by each request to its caller in the audit log
+	app.Use(middlewares.AuditContextMiddleware())
+
+	// Scope the users and stories seen by each request to its tenant.
+	// Without tenant sources, every request belongs to the default tenant.
+	if len(cfg.TenantSources) > 0 {
+		app.Use(middlewares.TenantMiddleware(tenantSources(cfg)...))
+	}
+
+	// Cancel the database work of a request once it exceeds the configured deadline.
+	// Exports stream for as long as the client reads, imports write up to thousands of stories
+	// and the audit verification walks the whole log, so they are only cancelled on disconnect.
+	if cfg.DBQueryTimeout > 0 {
+		app.Use(middlewares.QueryTimeoutMiddleware(cfg.DBQueryTimeout, "/api/stories/export", "/api/users/export", "/api/stories/import", "/api/audit/verify"))
+	}
+
+	// Keep the reads of a request on the primary once it has written, as the replicas may lag behind
+	if replicas != nil {
+		app.Use(middlewares.ReadYourWritesMiddleware())
+	}
+
 	// Setup API routes group
 	api := app.Group("/api")
 	{
 		// Register user routes using the new routes package
 		routes.UserRoutes(api, container.UserHandler)
 		routes.StoryRoutes(api, container.StoryHandler)
+		routes.CommentRoutes(api, container.CommentHandler)
+		routes.AdminRoutes(api, container.AdminHandler)
+		routes.WebhookRoutes(api, container.WebhookHandler)
+		routes.AuditRoutes(api, container.AuditHandler)
 	}
 
 	// Routes to serve React/Astro frontend (later)
@@ -31,3 +69,21 @@ func InitGinServer(db *sql.DB) *gin.Engine {
 
 	return app
 }
+
+// Returns the tenant sources listed in the configuration, in their order.
+func tenantSources(cfg *config.Config) []middlewares.TenantSource {
+	sources := make([]middlewares.TenantSource, 0, len(cfg.TenantSources))
+
+	for _, source := range cfg.TenantSources {
+		switch source {
+		case config.TenantSourceHeader:
+			sources = append(sources, middlewares.HeaderTenant(cfg.TenantHeader))
+		case config.TenantSourceSubdomain:
+			sources = append(sources, middlewares.SubdomainTenant(cfg.TenantBaseDomain))
+		case config.TenantSourceToken:
+			sources = append(sources, middlewares.TokenTenant(cfg.TenantTokenSecret))
+		}
+	}
+
+	return sources
+}
@@ -29,6 +29,15 @@ func (e *ConflictError) Error() string {
 	return fmt.Sprintf("conflict error: %s", e.Message)
 }
 
+// Represents a failed precondition, such as an If-Match header holding a stale version.
+type PreconditionFailedError struct {
+	Message string
+}
+
+func (e *PreconditionFailedError) Error() string {
+	return fmt.Sprintf("precondition failed: %s", e.Message)
+}
+
 // Represents an internal error.
 type InternalError struct {
 	Message string
@@ -41,3 +50,8 @@ func (e *InternalError) Error() string {
 	}
 	return fmt.Sprintf("internal server error: %s", e.Message)
 }
+
+// Returns the error that caused this error, so errors.Is can match e.g. context.DeadlineExceeded.
+func (e *InternalError) Unwrap() error {
+	return e.Err
+}